@@ -25,6 +25,7 @@ import (
 
 	"github.com/go-sql-driver/mysql"
 	custommiddleware "github.com/marban004/factory_games_organizer/custom_middleware"
+	microservicelogiccalculator "github.com/marban004/factory_games_organizer/microservice_logic_calculator"
 )
 
 type AppCalculator struct {
@@ -32,6 +33,7 @@ type AppCalculator struct {
 	db          *sql.DB
 	config      Config
 	statTracker *custommiddleware.DefaultApiStatTracker
+	graphs      *microservicelogiccalculator.RecipeGraphCache
 }
 
 func New(config Config) *AppCalculator {
@@ -40,6 +42,7 @@ func New(config Config) *AppCalculator {
 	}
 	app.statTracker = &custommiddleware.DefaultApiStatTracker{MaxLen: config.TrackerCapacity, Period: config.TrackerTimePeriod, ApiStatsFile: config.ApiStatsFile, DumpStats: config.DumpStats}
 	app.loadDB()
+	app.graphs = microservicelogiccalculator.NewRecipeGraphCache(app.db, time.Duration(config.RecipeGraphTTL)*time.Millisecond)
 	app.loadRoutes()
 	return app
 }
@@ -32,6 +32,7 @@ type Config struct {
 	DumpStats         bool
 	TrackerCapacity   uint64
 	TrackerTimePeriod int64
+	RecipeGraphTTL    int64
 }
 
 func LoadConfig() Config {
@@ -45,6 +46,7 @@ func LoadConfig() Config {
 		TrackerTimePeriod: 60000,
 		ApiStatsFile:      "",
 		DumpStats:         true,
+		RecipeGraphTTL:    300000,
 	}
 	if dbAddr, exists := os.LookupEnv("MYSQL_ADDR"); exists {
 		cfg.DbAddress = dbAddr
@@ -92,5 +94,11 @@ func LoadConfig() Config {
 			}
 		}
 	}
+	if recipeGraphTTL, exists := os.LookupEnv("GRAPHTTL"); exists {
+		if recipeGraphTTLInt, err := strconv.ParseInt(recipeGraphTTL, 10, 64); err == nil {
+			cfg.RecipeGraphTTL = recipeGraphTTLInt
+			fmt.Println("Found recipe graph cache time to live:", cfg.RecipeGraphTTL)
+		}
+	}
 	return cfg
 }
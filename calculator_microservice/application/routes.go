@@ -30,8 +30,9 @@ import (
 func (a *AppCalculator) loadRoutes() {
 	router := chi.NewRouter()
 	calculatorHandler := &handler.Calculator{
-		DB:          a.db,
-		StatTracker: a.statTracker,
+		DB:           a.db,
+		RecipeGraphs: a.graphs,
+		StatTracker:  a.statTracker,
 	}
 
 	router.Use(middleware.Logger)
@@ -52,6 +53,10 @@ func (a *AppCalculator) loadRoutes() {
 	router.Get("/health", calculatorHandler.Health)
 	router.Get("/stats", calculatorHandler.Stats)
 	router.Get("/calculate", calculatorHandler.Calculate)
+	router.Post("/calculate", calculatorHandler.CalculateMultiple)
+	router.Post("/calculate/compare", calculatorHandler.Compare)
+	router.Get("/calculate/sweep", calculatorHandler.Sweep)
+	router.Post("/invalidate", calculatorHandler.Invalidate)
 	router.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL(fmt.Sprintf("https://%s:%d/swagger/doc.json", a.config.Host, a.config.ServerPort)), //The url pointing to API definition
 	))
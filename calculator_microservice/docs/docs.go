@@ -24,42 +24,492 @@ const docTemplate = `{
     "paths": {
         "/calculate": {
             "get": {
-                "description": "Calculate the machines and resources needed to produce target resource with provided production rate per second. Alternative Recipe and Alternative Machine parameters can be present multiple times in request query.",
+                "description": "Calculate the machines and resources needed to produce target resource with provided production rate. Alternative Recipe, Alternative Machine, Supply and Transport Tier parameters can be present multiple times in request query.",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
                 "tags": [
                     "Calculator"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Id of users whose data will be used as the base for calculation",
-                        "name": "userid",
-                        "in": "query",
-                        "required": true
+                        "description": "Id of users whose data will be used as the base for calculation",
+                        "name": "userid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource to be produced",
+                        "name": "resource",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target production rate for the specified resource, required unless mode is 'maximize'",
+                        "name": "rate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative recipe to take into consideration when calculating production tree",
+                        "name": "alt_recipe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative machine to take into consideration when calculating production tree",
+                        "name": "alt_machine",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts, 'maximize' ignores rate and calculates the highest rate achievable with supplied resources. Defaults to 'greedy'",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource available at limited rate in 'maximize' mode, in format 'resource:rate', SuppliedResourcesPerSecond in response contains consumed rate of every supplied resource. Can be present multiple times",
+                        "name": "supply",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the response, either 'json', 'dot' (Graphviz) or 'mermaid' diagram with edges labelled by resource, rate and lanes of transport tier. Defaults to 'json'",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'",
+                        "name": "objective",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption",
+                        "name": "power_target_mw",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'",
+                        "name": "cover_power",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers",
+                        "name": "transport_tier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'",
+                        "name": "split_nodes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'",
+                        "name": "sink_excess",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', BuildCost in summary totals resources needed to construct machines of the tree, MachinesWithoutBuildCost lists machines without known build cost. Defaults to 'false'",
+                        "name": "build_cost",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', Explanation of every node lists considered recipe and machine pairs and the rule that eliminated each of them. Defaults to 'false'",
+                        "name": "explain",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/microservicelogiccalculator.ProductionTree"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Calculate the machines and resources needed to produce all target resources with provided production rates in one production tree, intermediate resources needed by several targets are produced by shared nodes. Alternative Recipe and Alternative Machine parameters can be present multiple times in request query.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "Calculator"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of users whose data will be used as the base for calculation",
+                        "name": "userid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative recipe to take into consideration when calculating production tree",
+                        "name": "alt_recipe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative machine to take into consideration when calculating production tree",
+                        "name": "alt_machine",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts. Defaults to 'greedy'",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the response, either 'json', 'dot' (Graphviz) or 'mermaid' diagram with edges labelled by resource, rate and lanes of transport tier. Defaults to 'json'",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'",
+                        "name": "objective",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption",
+                        "name": "power_target_mw",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'",
+                        "name": "cover_power",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers",
+                        "name": "transport_tier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'",
+                        "name": "split_nodes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'",
+                        "name": "sink_excess",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', BuildCost in summary totals resources needed to construct machines of the tree, MachinesWithoutBuildCost lists machines without known build cost. Defaults to 'false'",
+                        "name": "build_cost",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', Explanation of every node lists considered recipe and machine pairs and the rule that eliminated each of them. Defaults to 'false'",
+                        "name": "explain",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Resources to be produced and their target production rates",
+                        "name": "targets",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CalculateMultipleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/microservicelogiccalculator.ProductionTree"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/calculate/compare": {
+            "post": {
+                "description": "Calculate production trees producing all targets once for every scenario and compare them with the first scenario, which is the baseline. Scenarios list alternative recipes and machines used in addition to alt_recipe and alt_machine parameters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calculator"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of users whose data will be used as the base for calculation",
+                        "name": "userid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative recipe to take into consideration in every scenario",
+                        "name": "alt_recipe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative machine to take into consideration in every scenario",
+                        "name": "alt_machine",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts. Defaults to 'greedy'",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'",
+                        "name": "objective",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption",
+                        "name": "power_target_mw",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'",
+                        "name": "cover_power",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers",
+                        "name": "transport_tier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'",
+                        "name": "split_nodes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'",
+                        "name": "sink_excess",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Resources to be produced, their target production rates and compared scenarios",
+                        "name": "comparison",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CompareInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/microservicelogiccalculator.Comparison"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/calculate/sweep": {
+            "get": {
+                "description": "Calculate production trees producing target resource at every rate from rate_from to rate_to, increased by rate_step, and return machine counts and raw resources consumed at every rate. At most 1000 rates can be calculated at once.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calculator"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of users whose data will be used as the base for calculation",
+                        "name": "userid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource to be produced",
+                        "name": "resource",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First target production rate",
+                        "name": "rate_from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last target production rate",
+                        "name": "rate_to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Difference between consecutive target production rates",
+                        "name": "rate_step",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Raw resource available at limited rate, in format 'resource:rate', ExceededSupplies lists raw resources consumed faster than supplied. Can be present multiple times",
+                        "name": "supply",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative recipe to take into consideration when calculating production tree",
+                        "name": "alt_recipe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative machine to take into consideration when calculating production tree",
+                        "name": "alt_machine",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts. Defaults to 'greedy'",
+                        "name": "mode",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Resource to be produced",
-                        "name": "resource",
-                        "in": "query",
-                        "required": true
+                        "description": "Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'",
+                        "name": "unit",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Target production rate for the specified resource",
-                        "name": "rate",
-                        "in": "query",
-                        "required": true
+                        "description": "Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'",
+                        "name": "objective",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Alternative recipe to take into consideration when calculating production tree",
-                        "name": "alt_recipe",
+                        "description": "Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption",
+                        "name": "power_target_mw",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Alternative machine to take into consideration when calculating production tree",
-                        "name": "alt_machine",
+                        "description": "If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'",
+                        "name": "cover_power",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers",
+                        "name": "transport_tier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'",
+                        "name": "split_nodes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'",
+                        "name": "sink_excess",
                         "in": "query"
                     }
                 ],
@@ -67,11 +517,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/microservicelogiccalculator.ProductionTree"
+                            "$ref": "#/definitions/microservicelogiccalculator.Sweep"
                         }
                     },
                     "400": {
-                        "description": "Bad request. One of required parameters is missing",
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
                         "schema": {
                             "type": "string"
                         }
@@ -107,6 +557,34 @@ const docTemplate = `{
                 }
             }
         },
+        "/invalidate": {
+            "post": {
+                "description": "Remove recipes, machines and resources of a user cached by the calculator, so that next calculation uses current data from database. Cached data also expires on its own after configured time. Called by CRUD microservice after user data is modified.",
+                "tags": [
+                    "Calculator"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of user whose cached data will be removed",
+                        "name": "userid",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/stats": {
             "get": {
                 "description": "Return the usage stats of microservice.",
@@ -131,42 +609,357 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "handler.CalculateMultipleInput": {
+            "type": "object",
+            "properties": {
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.CalculationTarget"
+                    }
+                }
+            }
+        },
+        "handler.CalculationTarget": {
+            "type": "object",
+            "properties": {
+                "rate": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "resource": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CompareInput": {
+            "type": "object",
+            "properties": {
+                "scenarios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ComparisonScenario"
+                    }
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.CalculationTarget"
+                    }
+                }
+            }
+        },
+        "handler.ComparisonScenario": {
+            "type": "object",
+            "properties": {
+                "altMachines": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "altRecipes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "handler.HealthResponse": {
             "type": "object",
             "properties": {
                 "databaseStatus": {
                     "type": "string"
                 },
-                "microserviceStatus": {
-                    "type": "string"
+                "microserviceStatus": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.StatsResponse": {
+            "type": "object",
+            "properties": {
+                "apiUsageStats": {
+                    "type": "object"
+                },
+                "noPeriods": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "trackingPeriodMs": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "microservicelogiccalculator.CandidateExplanation": {
+            "type": "object",
+            "properties": {
+                "chosen": {
+                    "type": "boolean"
+                },
+                "machineName": {
+                    "type": "string"
+                },
+                "recipeName": {
+                    "type": "string"
+                },
+                "rejection": {
+                    "type": "string"
+                },
+                "resourcePerSecond": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "resourcePerUnit": {
+                    "type": "number"
+                }
+            }
+        },
+        "microservicelogiccalculator.Comparison": {
+            "type": "object",
+            "properties": {
+                "baseline": {
+                    "type": "string"
+                },
+                "rateUnit": {
+                    "type": "string"
+                },
+                "resourceUnits": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "scenarios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/microservicelogiccalculator.ScenarioComparison"
+                    }
+                }
+            }
+        },
+        "microservicelogiccalculator.MachineSummary": {
+            "type": "object",
+            "properties": {
+                "machineCount": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "machineName": {
+                    "type": "string"
+                },
+                "machineNumber": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "totalPowerConsumedkW": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "microservicelogiccalculator.NodeExplanation": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/microservicelogiccalculator.CandidateExplanation"
+                    }
+                },
+                "resource": {
+                    "type": "string"
+                }
+            }
+        },
+        "microservicelogiccalculator.ProductionEdge": {
+            "type": "object",
+            "properties": {
+                "lanes": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "resourcePerSecond": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "resourcePerUnit": {
+                    "type": "number"
+                },
+                "sourceNode": {
+                    "type": "integer"
+                },
+                "targetNode": {
+                    "type": "integer"
+                },
+                "transportTier": {
+                    "type": "string"
+                }
+            }
+        },
+        "microservicelogiccalculator.ProductionSummary": {
+            "type": "object",
+            "properties": {
+                "buildCost": {
+                    "description": "BuildCost totals resources needed to construct whole machines of the tree, it is only filled on request",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "byproductsPerSecond": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "byproductsPerUnit": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "machines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/microservicelogiccalculator.MachineSummary"
+                    }
+                },
+                "machinesWithoutBuildCost": {
+                    "description": "MachinesWithoutBuildCost are machines of the tree, whose build cost is not known",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "powerBalancekW": {
+                    "description": "PowerBalancekW is power generated minus power consumed by the tree",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "rawResourcesPerSecond": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "rawResourcesPerUnit": {
+                    "description": "fields named PerUnit hold rates per RateUnit of the tree, they are only filled when rates are requested in\nunit other than seconds",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "sinkPointsPerSecond": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "sinkPointsPerUnit": {
+                    "type": "number"
+                },
+                "sunkResourcesPerSecond": {
+                    "description": "SunkResourcesPerSecond are excess resources put into sinks or consumed by disposal recipes",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "sunkResourcesPerUnit": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "totalPowerConsumedkW": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "totalPowerGeneratedkW": {
+                    "type": "integer",
+                    "format": "int64"
                 }
             }
         },
-        "handler.StatsResponse": {
+        "microservicelogiccalculator.ProductionTarget": {
             "type": "object",
             "properties": {
-                "apiUsageStats": {
-                    "type": "object"
+                "rate": {
+                    "type": "number",
+                    "format": "float32"
                 },
-                "noPeriods": {
-                    "type": "integer",
-                    "format": "int64"
+                "ratePerUnit": {
+                    "type": "number"
                 },
-                "trackingPeriodMs": {
-                    "type": "integer",
-                    "format": "int64"
+                "resource": {
+                    "type": "string"
+                },
+                "sourceNode": {
+                    "type": "integer"
                 }
             }
         },
         "microservicelogiccalculator.ProductionTree": {
             "type": "object",
             "properties": {
+                "edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/microservicelogiccalculator.ProductionEdge"
+                    }
+                },
                 "excessResources": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/microservicelogiccalculator.ResourceSource"
                     }
                 },
+                "rateUnit": {
+                    "type": "string"
+                },
+                "rejectedPairings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/microservicelogiccalculator.RejectedPairing"
+                    }
+                },
+                "resourceUnits": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/microservicelogiccalculator.ProductionSummary"
+                },
+                "suppliedResourcesPerSecond": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "suppliedResourcesPerUnit": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
                 "targetResource": {
                     "type": "string"
                 },
@@ -174,9 +967,18 @@ const docTemplate = `{
                     "type": "number",
                     "format": "float32"
                 },
+                "targetResourceRatePerUnit": {
+                    "type": "number"
+                },
                 "targetResourceSourceNode": {
                     "type": "integer"
                 },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/microservicelogiccalculator.ProductionTarget"
+                    }
+                },
                 "treeNodes": {
                     "type": "array",
                     "items": {
@@ -188,6 +990,17 @@ const docTemplate = `{
         "microservicelogiccalculator.ProductionTreeNode": {
             "type": "object",
             "properties": {
+                "clockPercentage": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "explanation": {
+                    "$ref": "#/definitions/microservicelogiccalculator.NodeExplanation"
+                },
+                "machineCount": {
+                    "type": "integer",
+                    "format": "int64"
+                },
                 "machineName": {
                     "type": "string"
                 },
@@ -198,6 +1011,10 @@ const docTemplate = `{
                 "nodeId": {
                     "type": "integer"
                 },
+                "powerGeneratedkW": {
+                    "type": "integer",
+                    "format": "int64"
+                },
                 "producedResourcesPerSecond": {
                     "type": "object",
                     "additionalProperties": {
@@ -205,6 +1022,13 @@ const docTemplate = `{
                         "format": "float32"
                     }
                 },
+                "producedResourcesPerUnit": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
                 "recipeName": {
                     "type": "string"
                 },
@@ -215,18 +1039,83 @@ const docTemplate = `{
                         "format": "float32"
                     }
                 },
+                "requiredResourcesPerUnit": {
+                    "description": "RequiredResourcesPerUnit and ProducedResourcesPerUnit hold the same rates per RateUnit of the tree, they are\nonly filled when rates are requested in unit other than seconds",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "sinkPointsPerSecond": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "sinkPointsPerUnit": {
+                    "type": "number"
+                },
                 "sourceNodes": {
                     "type": "array",
                     "items": {
                         "type": "integer"
                     }
                 },
+                "sunkResource": {
+                    "description": "SunkResource is the excess resource sunk or disposed of by the node, sink nodes have no recipe and no machine",
+                    "type": "string"
+                },
                 "totalPowerConsumedkW": {
                     "type": "integer",
                     "format": "int64"
                 }
             }
         },
+        "microservicelogiccalculator.RejectedPairing": {
+            "type": "object",
+            "properties": {
+                "machineName": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "recipeName": {
+                    "type": "string"
+                }
+            }
+        },
+        "microservicelogiccalculator.ResourceDelta": {
+            "type": "object",
+            "properties": {
+                "baselinePerSecond": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "baselinePerUnit": {
+                    "type": "number"
+                },
+                "deltaPerSecond": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "deltaPerUnit": {
+                    "type": "number"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "scenarioPerSecond": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "scenarioPerUnit": {
+                    "type": "number"
+                }
+            }
+        },
         "microservicelogiccalculator.ResourceSource": {
             "type": "object",
             "properties": {
@@ -234,11 +1123,197 @@ const docTemplate = `{
                     "type": "number",
                     "format": "float32"
                 },
+                "excessProducedResourcePerUnit": {
+                    "type": "number"
+                },
                 "excessResourceName": {
                     "type": "string"
                 },
+                "lanes": {
+                    "type": "integer",
+                    "format": "int64"
+                },
                 "nodeId": {
                     "type": "integer"
+                },
+                "transportTier": {
+                    "type": "string"
+                }
+            }
+        },
+        "microservicelogiccalculator.ScenarioComparison": {
+            "type": "object",
+            "properties": {
+                "byproductsPerSecond": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "byproductsPerUnit": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "machineCount": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "machineCountDelta": {
+                    "description": "deltas are differences between the scenario and the baseline, positive when the scenario uses or produces more",
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "machineNumber": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "machineNumberDelta": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "machines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/microservicelogiccalculator.MachineSummary"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "powerBalancekW": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "powerBalancekWDelta": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "rawResourcesPerSecond": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "rawResourcesPerUnit": {
+                    "description": "fields named PerUnit hold rates per RateUnit of the comparison, they are only filled for units other than seconds",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "resourceDeltas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/microservicelogiccalculator.ResourceDelta"
+                    }
+                },
+                "totalPowerConsumedkW": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "totalPowerConsumedkWDelta": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "totalPowerGeneratedkW": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "microservicelogiccalculator.Sweep": {
+            "type": "object",
+            "properties": {
+                "rateUnit": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "resourceUnits": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/microservicelogiccalculator.SweepStep"
+                    }
+                }
+            }
+        },
+        "microservicelogiccalculator.SweepNode": {
+            "type": "object",
+            "properties": {
+                "machineCount": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "machineName": {
+                    "type": "string"
+                },
+                "machineNumber": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "recipeName": {
+                    "type": "string"
+                }
+            }
+        },
+        "microservicelogiccalculator.SweepStep": {
+            "type": "object",
+            "properties": {
+                "exceededSupplies": {
+                    "description": "ExceededSupplies are raw resources consumed faster than they are supplied",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/microservicelogiccalculator.SweepNode"
+                    }
+                },
+                "nodesWithNewMachines": {
+                    "description": "NodesWithNewMachines are indexes in Nodes of nodes needing more machines than in the previous step",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "rate": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "rawResourcesPerSecond": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "rawResourcesPerUnit": {
+                    "description": "RawResourcesPerUnit holds raw resources per RateUnit of the sweep, it is only filled for units other than seconds",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "totalMachineCount": {
+                    "type": "integer",
+                    "format": "int64"
                 }
             }
         }
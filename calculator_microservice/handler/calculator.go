@@ -21,7 +21,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 
 	custommiddleware "github.com/marban004/factory_games_organizer/custom_middleware"
 	microservicelogiccalculator "github.com/marban004/factory_games_organizer/microservice_logic_calculator"
@@ -29,8 +32,36 @@ import (
 )
 
 type Calculator struct {
-	DB          *sql.DB
-	StatTracker *custommiddleware.DefaultApiStatTracker
+	DB           *sql.DB
+	RecipeGraphs *microservicelogiccalculator.RecipeGraphCache
+	StatTracker  *custommiddleware.DefaultApiStatTracker
+}
+
+type CalculationTarget struct {
+	Resource string
+	Rate     float32
+}
+
+type CalculateMultipleInput struct {
+	Targets []CalculationTarget
+}
+
+type ComparisonScenario struct {
+	Name        string
+	AltRecipes  []string
+	AltMachines []string
+}
+
+type CompareInput struct {
+	Targets   []CalculationTarget
+	Scenarios []ComparisonScenario
+}
+
+var productionTreeContentTypes = map[string]string{
+	"":                                     "application/json",
+	microservicelogiccalculator.FormatJSON: "application/json",
+	microservicelogiccalculator.FormatDot:  "text/vnd.graphviz",
+	microservicelogiccalculator.FormatMermaid: "text/plain",
 }
 
 type HealthResponse struct {
@@ -46,15 +77,28 @@ type StatsResponse struct {
 
 // Calculate return the calculated production tree for specified resource
 //
-//	@Description	Calculate the machines and resources needed to produce target resource with provided production rate per second. Alternative Recipe and Alternative Machine parameters can be present multiple times in request query.
-//	@Param			userid		query	string	true	"Id of users whose data will be used as the base for calculation"
-//	@Param			resource	query	string	true	"Resource to be produced"
-//	@Param			rate		query	string	true	"Target production rate for the specified resource"
-//	@Param			alt_recipe	query	string	false	"Alternative recipe to take into consideration when calculating production tree"
-//	@Param			alt_machine	query	string	false	"Alternative machine to take into consideration when calculating production tree"
+//	@Description	Calculate the machines and resources needed to produce target resource with provided production rate. Alternative Recipe, Alternative Machine, Supply and Transport Tier parameters can be present multiple times in request query.
+//	@Param			userid			query	string	true	"Id of users whose data will be used as the base for calculation"
+//	@Param			resource		query	string	true	"Resource to be produced"
+//	@Param			rate			query	string	false	"Target production rate for the specified resource, required unless mode is 'maximize'"
+//	@Param			alt_recipe		query	string	false	"Alternative recipe to take into consideration when calculating production tree"
+//	@Param			alt_machine		query	string	false	"Alternative machine to take into consideration when calculating production tree"
+//	@Param			mode			query	string	false	"Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts, 'maximize' ignores rate and calculates the highest rate achievable with supplied resources. Defaults to 'greedy'"
+//	@Param			supply			query	string	false	"Resource available at limited rate in 'maximize' mode, in format 'resource:rate', SuppliedResourcesPerSecond in response contains consumed rate of every supplied resource. Can be present multiple times"
+//	@Param			format			query	string	false	"Format of the response, either 'json', 'dot' (Graphviz) or 'mermaid' diagram with edges labelled by resource, rate and lanes of transport tier. Defaults to 'json'"
+//	@Param			unit			query	string	false	"Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'"
+//	@Param			objective		query	string	false	"Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'"
+//	@Param			power_target_mw	query	string	false	"Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption"
+//	@Param			cover_power		query	string	false	"If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'"
+//	@Param			transport_tier	query	string	false	"Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers"
+//	@Param			split_nodes		query	string	false	"If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'"
+//	@Param			sink_excess		query	string	false	"If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'"
+//	@Param			build_cost		query	string	false	"If 'true', BuildCost in summary totals resources needed to construct machines of the tree, MachinesWithoutBuildCost lists machines without known build cost. Defaults to 'false'"
+//	@Param			explain			query	string	false	"If 'true', Explanation of every node lists considered recipe and machine pairs and the rule that eliminated each of them. Defaults to 'false'"
 //	@Tags			Calculator
+//	@Produce		json,plain
 //	@Success		200	{object}	microservicelogiccalculator.ProductionTree
-//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing"
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
 //	@Failure		500	{string}	string	"Unexpected serverside error"
 //	@Router			/calculate [get]
 func (h *Calculator) Calculate(w http.ResponseWriter, r *http.Request) {
@@ -70,26 +114,530 @@ func (h *Calculator) Calculate(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("resource parameter cannot be empty"))
 		return
 	}
-	desiredRate, err := strconv.ParseFloat(r.URL.Query().Get("rate"), 32)
-	if err != nil || desiredRate <= 0 {
+	format := r.URL.Query().Get("format")
+	if _, formatSupported := productionTreeContentTypes[format]; !formatSupported {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("format should be either 'json', 'dot' or 'mermaid'"))
+		return
+	}
+	unit := r.URL.Query().Get("unit")
+	unitSeconds, unitSupported := microservicelogiccalculator.RateUnitSeconds(unit)
+	if !unitSupported {
 		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("rate should be a positive floating point number and cannot be empty"))
+		w.Write([]byte("unit should be either 's', 'min' or 'h'"))
+		return
+	}
+	options, err := parseCalculationOptions(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	mode := r.URL.Query().Get("mode")
+	var desiredRate float64
+	if mode != "maximize" {
+		desiredRate, err = strconv.ParseFloat(r.URL.Query().Get("rate"), 32)
+		if err != nil || desiredRate <= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("rate should be a positive floating point number and cannot be empty"))
+			return
+		}
+	}
+	var calculationResult *microservicelogiccalculator.ProductionTree
+	switch mode {
+	case "", "greedy":
+		calculationResult, err = microservicelogiccalculator.Calculate(r.Context(), userId, desiredResourceName, float32(desiredRate)/unitSeconds, options, h.RecipeGraphs)
+	case "optimize":
+		calculationResult, err = microservicelogiccalculator.CalculateOptimal(r.Context(), userId, desiredResourceName, float32(desiredRate)/unitSeconds, options, h.RecipeGraphs)
+	case "maximize":
+		supplies, parseErr := parseSupplies(r.URL.Query()["supply"], unitSeconds)
+		if parseErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(parseErr.Error()))
+			return
+		}
+		calculationResult, err = microservicelogiccalculator.CalculateMaximal(r.Context(), userId, desiredResourceName, supplies, options, h.RecipeGraphs)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("mode should be either 'greedy', 'optimize' or 'maximize'"))
 		return
 	}
-	recipes_names := r.URL.Query()["alt_recipe"]
-	machine_names := r.URL.Query()["alt_machine"]
-	byteJSONRepresentation, err := microservicelogiccalculator.Calculate(r.Context(), userId, desiredResourceName, float32(desiredRate), recipes_names, machine_names, h.DB)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte(fmt.Errorf("could not generate production tree for '%s', reason: %w", desiredResourceName, err).Error()))
 		return
 	}
-	w.WriteHeader(http.StatusOK)
-	w.Write(byteJSONRepresentation)
+	writeProductionTree(w, calculationResult, format, unit)
 	// test url 192.168.31.74:3000/calculate?userid=1&resource=reinforced_iron_plate&rate=0.5
 	// w.Write([]byte("works maybe"))
 }
 
+// parseCalculationOptions parses parameters affecting choice of recipes, machines and power generators
+func parseCalculationOptions(params url.Values) (microservicelogiccalculator.CalculationOptions, error) {
+	options := microservicelogiccalculator.CalculationOptions{RecipesNames: params["alt_recipe"], MachinesNames: params["alt_machine"], Objective: params.Get("objective")}
+	if !microservicelogiccalculator.IsObjectiveSupported(options.Objective) {
+		return options, fmt.Errorf("objective should be either 'rate', 'power', 'machines' or 'raw'")
+	}
+	if params.Has("power_target_mw") {
+		powerTargetMw, err := strconv.ParseFloat(params.Get("power_target_mw"), 64)
+		if err != nil || powerTargetMw < 0 {
+			return options, fmt.Errorf("power_target_mw should be a non negative floating point number")
+		}
+		options.PowerTargetKw = powerTargetMw * 1000
+	}
+	if params.Has("cover_power") {
+		coverPower, err := strconv.ParseBool(params.Get("cover_power"))
+		if err != nil {
+			return options, fmt.Errorf("cover_power should be either 'true' or 'false'")
+		}
+		options.CoverPowerConsumption = coverPower
+	}
+	options.TransportTiers = params["transport_tier"]
+	if params.Has("split_nodes") {
+		splitNodes, err := strconv.ParseBool(params.Get("split_nodes"))
+		if err != nil {
+			return options, fmt.Errorf("split_nodes should be either 'true' or 'false'")
+		}
+		options.SplitNodes = splitNodes
+	}
+	if params.Has("explain") {
+		explain, err := strconv.ParseBool(params.Get("explain"))
+		if err != nil {
+			return options, fmt.Errorf("explain should be either 'true' or 'false'")
+		}
+		options.Explain = explain
+	}
+	if params.Has("sink_excess") {
+		sinkExcess, err := strconv.ParseBool(params.Get("sink_excess"))
+		if err != nil {
+			return options, fmt.Errorf("sink_excess should be either 'true' or 'false'")
+		}
+		options.SinkExcess = sinkExcess
+	}
+	if params.Has("build_cost") {
+		buildCost, err := strconv.ParseBool(params.Get("build_cost"))
+		if err != nil {
+			return options, fmt.Errorf("build_cost should be either 'true' or 'false'")
+		}
+		options.BuildCost = buildCost
+	}
+	return options, nil
+}
+
+// parseSupplies parses supply parameters in format 'resource:rate'
+func parseSupplies(supplyParams []string, unitSeconds float32) (map[string]float32, error) {
+	if len(supplyParams) == 0 {
+		return nil, fmt.Errorf("at least one supply parameter has to be provided in 'maximize' mode")
+	}
+	supplies := make(map[string]float32)
+	for _, supplyParam := range supplyParams {
+		separatorIndex := strings.LastIndex(supplyParam, ":")
+		if separatorIndex <= 0 {
+			return nil, fmt.Errorf("supply '%s' should be in format 'resource:rate'", supplyParam)
+		}
+		rate, err := strconv.ParseFloat(supplyParam[separatorIndex+1:], 32)
+		if err != nil || rate < 0 {
+			return nil, fmt.Errorf("rate of supply '%s' should be a non negative floating point number", supplyParam)
+		}
+		supplies[supplyParam[:separatorIndex]] += float32(rate) / unitSeconds
+	}
+	return supplies, nil
+}
+
+// CalculateMultiple return the calculated production tree for several target resources
+//
+//	@Description	Calculate the machines and resources needed to produce all target resources with provided production rates in one production tree, intermediate resources needed by several targets are produced by shared nodes. Alternative Recipe and Alternative Machine parameters can be present multiple times in request query.
+//	@Param			userid			query	string							true	"Id of users whose data will be used as the base for calculation"
+//	@Param			alt_recipe		query	string							false	"Alternative recipe to take into consideration when calculating production tree"
+//	@Param			alt_machine		query	string							false	"Alternative machine to take into consideration when calculating production tree"
+//	@Param			mode			query	string							false	"Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts. Defaults to 'greedy'"
+//	@Param			format			query	string							false	"Format of the response, either 'json', 'dot' (Graphviz) or 'mermaid' diagram with edges labelled by resource, rate and lanes of transport tier. Defaults to 'json'"
+//	@Param			unit			query	string							false	"Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'"
+//	@Param			objective		query	string							false	"Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'"
+//	@Param			power_target_mw	query	string							false	"Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption"
+//	@Param			cover_power		query	string							false	"If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'"
+//	@Param			transport_tier	query	string							false	"Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers"
+//	@Param			split_nodes		query	string							false	"If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'"
+//	@Param			sink_excess		query	string							false	"If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'"
+//	@Param			build_cost		query	string							false	"If 'true', BuildCost in summary totals resources needed to construct machines of the tree, MachinesWithoutBuildCost lists machines without known build cost. Defaults to 'false'"
+//	@Param			explain			query	string							false	"If 'true', Explanation of every node lists considered recipe and machine pairs and the rule that eliminated each of them. Defaults to 'false'"
+//	@Param			targets			body	handler.CalculateMultipleInput	true	"Resources to be produced and their target production rates"
+//	@Tags			Calculator
+//
+//	@Accept			json
+//	@Produce		json,plain
+//
+//	@Success		200	{object}	microservicelogiccalculator.ProductionTree
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/calculate [post]
+func (h *Calculator) CalculateMultiple(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.Atoi(r.URL.Query().Get("userid"))
+	if err != nil || userId <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("userid should be a positive integer and cannot be empty"))
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if _, formatSupported := productionTreeContentTypes[format]; !formatSupported {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("format should be either 'json', 'dot' or 'mermaid'"))
+		return
+	}
+	unit := r.URL.Query().Get("unit")
+	unitSeconds, unitSupported := microservicelogiccalculator.RateUnitSeconds(unit)
+	if !unitSupported {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("unit should be either 's', 'min' or 'h'"))
+		return
+	}
+	options, err := parseCalculationOptions(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	inputData := CalculateMultipleInput{}
+	err = json.NewDecoder(r.Body).Decode(&inputData)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Errorf("could not parse received body, reason: %w", err).Error()))
+		return
+	}
+	targets, err := parseTargets(inputData.Targets, unitSeconds)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	var calculationResult *microservicelogiccalculator.ProductionTree
+	switch r.URL.Query().Get("mode") {
+	case "", "greedy":
+		calculationResult, err = microservicelogiccalculator.CalculateMultiple(r.Context(), userId, targets, options, h.RecipeGraphs)
+	case "optimize":
+		calculationResult, err = microservicelogiccalculator.CalculateMultipleOptimal(r.Context(), userId, targets, options, h.RecipeGraphs)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("mode should be either 'greedy' or 'optimize'"))
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not generate production tree, reason: %w", err).Error()))
+		return
+	}
+	writeProductionTree(w, calculationResult, format, unit)
+}
+
+// parseTargets validates targets of request body and converts their rates to rates per second
+func parseTargets(inputTargets []CalculationTarget, unitSeconds float32) ([]*microservicelogiccalculator.ProductionTarget, error) {
+	if len(inputTargets) == 0 {
+		return nil, fmt.Errorf("at least one target has to be provided")
+	}
+	targets := []*microservicelogiccalculator.ProductionTarget{}
+	for _, target := range inputTargets {
+		if target.Resource == "" {
+			return nil, fmt.Errorf("resource of a target cannot be empty")
+		}
+		if target.Rate <= 0 {
+			return nil, fmt.Errorf("rate of target '%s' should be a positive floating point number", target.Resource)
+		}
+		targets = append(targets, &microservicelogiccalculator.ProductionTarget{Resource: target.Resource, Rate: target.Rate / unitSeconds})
+	}
+	return targets, nil
+}
+
+// Compare return the comparison of production trees calculated with several sets of alternative recipes and machines
+//
+//	@Description	Calculate production trees producing all targets once for every scenario and compare them with the first scenario, which is the baseline. Scenarios list alternative recipes and machines used in addition to alt_recipe and alt_machine parameters.
+//	@Param			userid			query	string					true	"Id of users whose data will be used as the base for calculation"
+//	@Param			alt_recipe		query	string					false	"Alternative recipe to take into consideration in every scenario"
+//	@Param			alt_machine		query	string					false	"Alternative machine to take into consideration in every scenario"
+//	@Param			mode			query	string					false	"Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts. Defaults to 'greedy'"
+//	@Param			unit			query	string					false	"Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'"
+//	@Param			objective		query	string					false	"Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'"
+//	@Param			power_target_mw	query	string					false	"Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption"
+//	@Param			cover_power		query	string					false	"If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'"
+//	@Param			transport_tier	query	string					false	"Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers"
+//	@Param			split_nodes		query	string					false	"If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'"
+//	@Param			sink_excess		query	string					false	"If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'"
+//	@Param			comparison		body	handler.CompareInput	true	"Resources to be produced, their target production rates and compared scenarios"
+//	@Tags			Calculator
+//
+//	@Accept			json
+//	@Produce		json
+//
+//	@Success		200	{object}	microservicelogiccalculator.Comparison
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/calculate/compare [post]
+func (h *Calculator) Compare(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.Atoi(r.URL.Query().Get("userid"))
+	if err != nil || userId <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("userid should be a positive integer and cannot be empty"))
+		return
+	}
+	unit := r.URL.Query().Get("unit")
+	unitSeconds, unitSupported := microservicelogiccalculator.RateUnitSeconds(unit)
+	if !unitSupported {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("unit should be either 's', 'min' or 'h'"))
+		return
+	}
+	options, err := parseCalculationOptions(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	calculate := microservicelogiccalculator.CalculateMultiple
+	switch r.URL.Query().Get("mode") {
+	case "", "greedy":
+	case "optimize":
+		calculate = microservicelogiccalculator.CalculateMultipleOptimal
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("mode should be either 'greedy' or 'optimize'"))
+		return
+	}
+	inputData := CompareInput{}
+	err = json.NewDecoder(r.Body).Decode(&inputData)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Errorf("could not parse received body, reason: %w", err).Error()))
+		return
+	}
+	targets, err := parseTargets(inputData.Targets, unitSeconds)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	if len(inputData.Scenarios) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("at least one scenario has to be provided"))
+		return
+	}
+	names := []string{}
+	trees := []*microservicelogiccalculator.ProductionTree{}
+	for _, scenario := range inputData.Scenarios {
+		if scenario.Name == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("name of a scenario cannot be empty"))
+			return
+		}
+		if slices.Contains(names, scenario.Name) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("name of scenario '%s' should be unique", scenario.Name)))
+			return
+		}
+		scenarioOptions := options
+		scenarioOptions.RecipesNames = append(slices.Clone(options.RecipesNames), scenario.AltRecipes...)
+		scenarioOptions.MachinesNames = append(slices.Clone(options.MachinesNames), scenario.AltMachines...)
+		calculationResult, err := calculate(r.Context(), userId, targets, scenarioOptions, h.RecipeGraphs)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Errorf("could not generate production tree for scenario '%s', reason: %w", scenario.Name, err).Error()))
+			return
+		}
+		err = calculationResult.ConvertRates(unit)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Errorf("could not convert rates of production tree, reason: %w", err).Error()))
+			return
+		}
+		names = append(names, scenario.Name)
+		trees = append(trees, calculationResult)
+	}
+	comparison, err := microservicelogiccalculator.CompareProductionTrees(names, trees)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not compare production trees, reason: %w", err).Error()))
+		return
+	}
+	byteJSONRepresentation, err := json.Marshal(comparison)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not generate json representation of response, reason: %w", err).Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(byteJSONRepresentation)
+}
+
+// maxSweepSteps limits number of production trees calculated for a single sweep
+const maxSweepSteps = 1000
+
+// Sweep return machine counts and raw resources of production trees for a range of target rates
+//
+//	@Description	Calculate production trees producing target resource at every rate from rate_from to rate_to, increased by rate_step, and return machine counts and raw resources consumed at every rate. At most 1000 rates can be calculated at once.
+//	@Param			userid			query	string	true	"Id of users whose data will be used as the base for calculation"
+//	@Param			resource		query	string	true	"Resource to be produced"
+//	@Param			rate_from		query	string	true	"First target production rate"
+//	@Param			rate_to			query	string	true	"Last target production rate"
+//	@Param			rate_step		query	string	true	"Difference between consecutive target production rates"
+//	@Param			supply			query	string	false	"Raw resource available at limited rate, in format 'resource:rate', ExceededSupplies lists raw resources consumed faster than supplied. Can be present multiple times"
+//	@Param			alt_recipe		query	string	false	"Alternative recipe to take into consideration when calculating production tree"
+//	@Param			alt_machine		query	string	false	"Alternative machine to take into consideration when calculating production tree"
+//	@Param			mode			query	string	false	"Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts. Defaults to 'greedy'"
+//	@Param			unit			query	string	false	"Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'"
+//	@Param			objective		query	string	false	"Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'"
+//	@Param			power_target_mw	query	string	false	"Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption"
+//	@Param			cover_power		query	string	false	"If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'"
+//	@Param			transport_tier	query	string	false	"Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers"
+//	@Param			split_nodes		query	string	false	"If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'"
+//	@Param			sink_excess		query	string	false	"If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'"
+//	@Tags			Calculator
+//	@Produce		json
+//	@Success		200	{object}	microservicelogiccalculator.Sweep
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/calculate/sweep [get]
+func (h *Calculator) Sweep(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.Atoi(r.URL.Query().Get("userid"))
+	if err != nil || userId <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("userid should be a positive integer and cannot be empty"))
+		return
+	}
+	desiredResourceName := r.URL.Query().Get("resource")
+	if desiredResourceName == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("resource parameter cannot be empty"))
+		return
+	}
+	unit := r.URL.Query().Get("unit")
+	unitSeconds, unitSupported := microservicelogiccalculator.RateUnitSeconds(unit)
+	if !unitSupported {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("unit should be either 's', 'min' or 'h'"))
+		return
+	}
+	options, err := parseCalculationOptions(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	calculate := microservicelogiccalculator.Calculate
+	switch r.URL.Query().Get("mode") {
+	case "", "greedy":
+	case "optimize":
+		calculate = microservicelogiccalculator.CalculateOptimal
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("mode should be either 'greedy' or 'optimize'"))
+		return
+	}
+	rateFrom, err := strconv.ParseFloat(r.URL.Query().Get("rate_from"), 32)
+	if err != nil || rateFrom <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("rate_from should be a positive floating point number and cannot be empty"))
+		return
+	}
+	rateTo, err := strconv.ParseFloat(r.URL.Query().Get("rate_to"), 32)
+	if err != nil || rateTo < rateFrom {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("rate_to should be a floating point number not smaller than rate_from and cannot be empty"))
+		return
+	}
+	rateStep, err := strconv.ParseFloat(r.URL.Query().Get("rate_step"), 32)
+	if err != nil || rateStep <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("rate_step should be a positive floating point number and cannot be empty"))
+		return
+	}
+	if (rateTo-rateFrom)/rateStep >= maxSweepSteps {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("rate_step should be large enough to calculate at most %d rates", maxSweepSteps)))
+		return
+	}
+	var supplies map[string]float32
+	if r.URL.Query().Has("supply") {
+		// supplies are compared with production trees after their rates are converted to unit
+		supplies, err = parseSupplies(r.URL.Query()["supply"], 1)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+	}
+	rates := []float32{}
+	trees := []*microservicelogiccalculator.ProductionTree{}
+	for i := 0; rateFrom+float64(i)*rateStep <= rateTo*(1+1e-6); i++ {
+		rate := float32(rateFrom + float64(i)*rateStep)
+		calculationResult, err := calculate(r.Context(), userId, desiredResourceName, rate/unitSeconds, options, h.RecipeGraphs)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Errorf("could not generate production tree for '%s' at rate %g, reason: %w", desiredResourceName, rate, err).Error()))
+			return
+		}
+		err = calculationResult.ConvertRates(unit)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Errorf("could not convert rates of production tree, reason: %w", err).Error()))
+			return
+		}
+		rates = append(rates, rate)
+		trees = append(trees, calculationResult)
+	}
+	sweep, err := microservicelogiccalculator.SweepProductionTrees(desiredResourceName, rates, trees, supplies)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not summarize production trees, reason: %w", err).Error()))
+		return
+	}
+	byteJSONRepresentation, err := json.Marshal(sweep)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not generate json representation of response, reason: %w", err).Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(byteJSONRepresentation)
+}
+
+// writeProductionTree writes production tree to response in requested format and rate unit
+func writeProductionTree(w http.ResponseWriter, calculationResult *microservicelogiccalculator.ProductionTree, format string, unit string) {
+	err := calculationResult.ConvertRates(unit)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not convert rates of production tree, reason: %w", err).Error()))
+		return
+	}
+	byteRepresentation, err := microservicelogiccalculator.EncodeProductionTree(calculationResult, format)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not generate representation of production tree, reason: %w", err).Error()))
+		return
+	}
+	w.Header().Set("Content-Type", productionTreeContentTypes[format])
+	w.WriteHeader(http.StatusOK)
+	w.Write(byteRepresentation)
+}
+
+// Invalidate remove cached recipe data of a user
+//
+//	@Description	Remove recipes, machines and resources of a user cached by the calculator, so that next calculation uses current data from database. Cached data also expires on its own after configured time. Called by CRUD microservice after user data is modified.
+//	@Param			userid	query	string	true	"Id of user whose cached data will be removed"
+//	@Tags			Calculator
+//	@Success		200
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing"
+//	@Router			/invalidate [post]
+func (h *Calculator) Invalidate(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.Atoi(r.URL.Query().Get("userid"))
+	if err != nil || userId <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("userid should be a positive integer and cannot be empty"))
+		return
+	}
+	h.RecipeGraphs.Invalidate(userId)
+	w.WriteHeader(http.StatusOK)
+}
+
 // Health return the status of microservice and associated database
 //
 //	@Description	Return the status of microservice and it's database. Default working state is signified by status "up".
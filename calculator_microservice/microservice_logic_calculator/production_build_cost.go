@@ -0,0 +1,34 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+// setBuildCost totals resources needed to construct whole machines of production tree, counting machines of every
+// machine type from the summary. Machines without build cost are listed separately, as totals do not include them.
+func (t *ProductionTree) setBuildCost(graph *recipeGraph) {
+	t.Summary.BuildCost = make(map[string]uint64)
+	t.Summary.MachinesWithoutBuildCost = []string{}
+	for _, machineSummary := range t.Summary.Machines {
+		machine := graph.machine(machineSummary.MachineName)
+		if machine == nil || len(machine.BuildCost) == 0 {
+			t.Summary.MachinesWithoutBuildCost = append(t.Summary.MachinesWithoutBuildCost, machineSummary.MachineName)
+			continue
+		}
+		for resourceName, amount := range machine.BuildCost {
+			t.Summary.BuildCost[resourceName] += machineSummary.MachineCount * amount
+		}
+	}
+}
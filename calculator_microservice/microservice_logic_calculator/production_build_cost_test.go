@@ -0,0 +1,68 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetBuildCost(t *testing.T) {
+	graph := testRecipeGraph()
+	graph.machine("constructor").BuildCost = map[string]uint64{"iron_plate": 8, "cable": 4}
+	graph.machine("smelter").BuildCost = map[string]uint64{"iron_rod": 5, "cable": 2}
+	// 1 iron plate per second needs 3 constructors, 3 smelters and 2 miners
+	tree, err := computeGreedyProductionTree(graph, "iron_plate", 1, CalculationOptions{BuildCost: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]uint64{"iron_plate": 24, "iron_rod": 15, "cable": 18}
+	if !reflect.DeepEqual(tree.Summary.BuildCost, expected) {
+		t.Fatalf("expected build cost %v, got %v", expected, tree.Summary.BuildCost)
+	}
+	if !reflect.DeepEqual(tree.Summary.MachinesWithoutBuildCost, []string{"miner"}) {
+		t.Fatalf("expected only miner to have no build cost, got %v", tree.Summary.MachinesWithoutBuildCost)
+	}
+}
+
+func TestSetBuildCostOfUnknownMachine(t *testing.T) {
+	graph := testRecipeGraph()
+	graph.machine("smelter").BuildCost = map[string]uint64{"iron_rod": 5}
+	tree := &ProductionTree{Summary: &ProductionSummary{Machines: []*MachineSummary{
+		{MachineName: "smelter", MachineCount: 2, MachineNumber: 1.5},
+		{MachineName: "refinery", MachineCount: 1, MachineNumber: 1},
+	}}}
+	tree.setBuildCost(graph)
+	if !reflect.DeepEqual(tree.Summary.BuildCost, map[string]uint64{"iron_rod": 10}) {
+		t.Fatalf("expected build cost of whole smelters only, got %v", tree.Summary.BuildCost)
+	}
+	if !reflect.DeepEqual(tree.Summary.MachinesWithoutBuildCost, []string{"refinery"}) {
+		t.Fatalf("expected machine missing in recipe graph to have no build cost, got %v", tree.Summary.MachinesWithoutBuildCost)
+	}
+}
+
+func TestBuildCostNotRequested(t *testing.T) {
+	graph := testRecipeGraph()
+	graph.machine("smelter").BuildCost = map[string]uint64{"iron_rod": 5}
+	tree, err := computeGreedyProductionTree(graph, "iron_ingot", 1, CalculationOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tree.Summary.BuildCost != nil || tree.Summary.MachinesWithoutBuildCost != nil {
+		t.Fatalf("expected build cost to be left empty, got %v and %v", tree.Summary.BuildCost, tree.Summary.MachinesWithoutBuildCost)
+	}
+}
@@ -19,237 +19,162 @@ package microservicelogiccalculator
 import (
 	"container/list"
 	"context"
-	"database/sql"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 )
 
-type BestrecipeResult struct {
-	ID                      uint
-	RecipeName              string
-	AmountProduced          uint
-	ProductionTime          uint
-	MachineName             string
-	MachineSpeed            uint
-	Rate                    float32
-	MachinePowerConsumption uint64
-	MachineId               uint
-}
-
-type RequiredResourceResult struct {
-	ID             uint
-	ResourceName   string
-	AmountRequired float32
-	ProductionTime float32
-	MachineName    string
-	MachineSpeed   uint
-	Rate           float32
-}
-
-type ProducedResourceResult struct {
-	ID             uint
-	ResourceName   string
-	AmountProduced float32
-	ProductionTime float32
-	MachineName    string
-	MachineSpeed   uint
-	Rate           float32
-}
+var errProductionLoop = errors.New("production chain contains a loop")
 
 type ProductionTreeNode struct {
 	NodeId                     int
 	RecipeName                 string
 	MachineName                string
 	MachineNumber              float32
+	MachineCount               uint64
+	ClockPercentage            float32
 	TotalPowerConsumedkW       uint64
+	PowerGeneratedkW           uint64
 	RequiredResourcesPerSecond map[string]float32
 	ProducedResourcesPerSecond map[string]float32
-	SourceNodes                []int
+	// RequiredResourcesPerUnit and ProducedResourcesPerUnit hold the same rates per RateUnit of the tree, they are
+	// only filled when rates are requested in unit other than seconds
+	RequiredResourcesPerUnit map[string]float32 `json:",omitempty"`
+	ProducedResourcesPerUnit map[string]float32 `json:",omitempty"`
+	SourceNodes              []int
+	// SunkResource is the excess resource sunk or disposed of by the node, sink nodes have no recipe and no machine
+	SunkResource        string
+	SinkPointsPerSecond float32
+	SinkPointsPerUnit   float32 `json:",omitempty"`
+	Explanation         *NodeExplanation
+}
+
+// machineNumberTolerance lets number of machines exceed a whole number by a rounding error without
+// requiring an additional machine
+const machineNumberTolerance = 1e-5
+
+// setMachines sets the whole number of machines to build and the clock speed at which they produce exactly
+// as much as machineNumber machines running at full speed. Power consumption of a machine scales with
+// clock speed raised to its power clock exponent, power generation scales linearly with clock speed.
+func (n *ProductionTreeNode) setMachines(machineNumber float64, machine *graphMachine) {
+	n.MachineNumber = float32(machineNumber)
+	n.MachineCount = uint64(math.Ceil(machineNumber * (1 - machineNumberTolerance)))
+	if n.MachineCount == 0 {
+		n.ClockPercentage = 0
+		n.TotalPowerConsumedkW = 0
+		n.PowerGeneratedkW = 0
+		return
+	}
+	clock := machineNumber / float64(n.MachineCount)
+	n.ClockPercentage = float32(clock * 100)
+	n.TotalPowerConsumedkW = uint64(math.Round(float64(n.MachineCount) * float64(machine.PowerConsumptionKw) * math.Pow(clock, machine.PowerClockExponent)))
+	n.PowerGeneratedkW = uint64(math.Round(machineNumber * float64(machine.PowerGenerationKw)))
 }
 
 type ResourceSource struct {
 	NodeId                          int
 	ExcessResourceName              string
 	ExcessProducedResourcePerSecond float32
+	ExcessProducedResourcePerUnit   float32 `json:",omitempty"`
+	TransportTier                   string
+	Lanes                           uint64
 }
 
-type ProductionTree struct {
-	TreeNodes                []*ProductionTreeNode
-	TargetResource           string
-	TargetResourceRate       float32
-	TargetResourceSourceNode int
-	ExcessResources          []*ResourceSource
+type ProductionTarget struct {
+	Resource    string
+	Rate        float32
+	RatePerUnit float32 `json:",omitempty"`
+	SourceNode  int
 }
 
-func Calculate(ctx context.Context, userId int, desiredResourceName string, desiredRate float32, recipes_names []string, machines_names []string, db *sql.DB) ([]byte, error) {
-	excessResources := list.New()
-	calculationResult := ProductionTree{TreeNodes: make([]*ProductionTreeNode, 0), ExcessResources: make([]*ResourceSource, excessResources.Len())}
-	var err error
-	calculationResult.TargetResourceSourceNode, err = findAndComputeBestrecipeForResource(ctx, userId, desiredResourceName, desiredRate, recipes_names, machines_names, &calculationResult.TreeNodes, excessResources, db)
-	if err != nil {
-		return nil, fmt.Errorf("could not compute production chain for resource '%s': %w", desiredResourceName, err)
-	}
-	for e := excessResources.Front(); e != nil; e = e.Next() {
-		newEntry := ResourceSource{NodeId: e.Value.(ResourceSource).NodeId, ExcessResourceName: e.Value.(ResourceSource).ExcessResourceName, ExcessProducedResourcePerSecond: e.Value.(ResourceSource).ExcessProducedResourcePerSecond}
-		calculationResult.ExcessResources = append(calculationResult.ExcessResources, &newEntry)
-	}
-	calculationResult.TargetResource = desiredResourceName
-	calculationResult.TargetResourceRate = desiredRate
-	byteJSONRepresentation, err := json.Marshal(calculationResult)
-	if err != nil {
-		return nil, fmt.Errorf("could not generate json representation: %w", err)
-	}
-	return byteJSONRepresentation, nil
+type ProductionTree struct {
+	TreeNodes                  []*ProductionTreeNode
+	TargetResource             string
+	TargetResourceRate         float32
+	TargetResourceRatePerUnit  float32 `json:",omitempty"`
+	TargetResourceSourceNode   int
+	Targets                    []*ProductionTarget
+	ExcessResources            []*ResourceSource
+	Edges                      []*ProductionEdge
+	RejectedPairings           []*RejectedPairing
+	SuppliedResourcesPerSecond map[string]float32
+	SuppliedResourcesPerUnit   map[string]float32 `json:",omitempty"`
+	Summary                    *ProductionSummary
+	RateUnit                   string
+	ResourceUnits              map[string]string
 }
 
-func findBestrecipe(ctx context.Context, userId int, desiredResourceName string, recipes_names []string, machines_names []string, db *sql.DB, bestrecipe *BestrecipeResult) error {
-	var query string = `SELECT rcp.id, rcp.name AS recipe_name, ro.amount AS amount_produced, rcp.production_time_s AS production_time, m.name AS machine_name, m.speed as machine_speed, (CAST(ro.amount AS FLOAT)/rcp.production_time_s*m.speed) rate, m.power_consumption_kw AS machine_power_consumption, m.id AS machine_id
-							FROM recipes rcp
-							JOIN recipes_outputs ro ON rcp.id = ro.recipes_id
-							JOIN resources r ON ro.resources_id = r.id
-							JOIN machines_recipes mr ON rcp.id = mr.recipes_id
-							JOIN machines m ON mr.machines_id = m.id
-							WHERE r.name = '` + desiredResourceName + `'`
-	if len(recipes_names) == 0 {
-		query += ` AND rcp.default_choice = TRUE `
-	} else {
-		query += " AND (rcp.default_choice = TRUE OR rcp.name IN ("
-		for i, name := range recipes_names {
-			if i != 0 {
-				query += ","
-			}
-			query += "'" + name + "'"
-		}
-		query += ")) "
-	}
-	if len(machines_names) == 0 {
-		query += ` AND m.default_choice = TRUE `
-	} else {
-		query += " AND (m.default_choice = TRUE OR m.name IN ("
-		for i, name := range machines_names {
-			if i != 0 {
-				query += ","
-			}
-			query += "'" + name + "'"
-		}
-		query += ")) "
-	}
-	query += ` AND rcp.users_id = ` + fmt.Sprint(userId) + `
-			AND ro.users_id = ` + fmt.Sprint(userId) + `
-			AND r.users_id = ` + fmt.Sprint(userId) + `
-			AND mr.users_id = ` + fmt.Sprint(userId) + `
-			AND m.users_id = ` + fmt.Sprint(userId) + `
-			ORDER BY rcp.default_choice, rate DESC
-			LIMIT 1;`
-	err := db.QueryRowContext(ctx, query).Scan(&(*bestrecipe).ID, &(*bestrecipe).RecipeName, &(*bestrecipe).AmountProduced, &(*bestrecipe).ProductionTime, &(*bestrecipe).MachineName, &(*bestrecipe).MachineSpeed, &(*bestrecipe).Rate, &(*bestrecipe).MachinePowerConsumption, &(*bestrecipe).MachineId)
-	if errors.Is(err, sql.ErrNoRows) {
-		return fmt.Errorf("could not find recipe for '%s'", desiredResourceName)
-	}
+// Calculate computes production tree for desired resource, choosing recipe separately for each resource: with
+// ObjectiveRate allowed alternative recipes take precedence over default ones, then the highest production rate wins,
+// other objectives choose the recipe with the lowest cost of the whole chain per unit of resource. Excess resources
+// produced as byproducts are used before new nodes are added. If chosen recipes form a loop or power generators
+// are required, the steady state of the chain is computed instead.
+func Calculate(ctx context.Context, userId int, desiredResourceName string, desiredRate float32, options CalculationOptions, graphs *RecipeGraphCache) (*ProductionTree, error) {
+	graph, err := graphs.graph(ctx, userId)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("could not load recipes: %w", err)
 	}
-	return nil
+	return computeGreedyProductionTree(graph, desiredResourceName, desiredRate, options)
 }
 
-func getRequiredResources(ctx context.Context, userId int, recipe_id uint, machine_id uint, requiredResources *list.List, db *sql.DB) error {
-	var resource RequiredResourceResult
-	var query string = `SELECT r.id, r.name AS resource_name, ri.amount AS amount_required, rcp.production_time_s AS production_time, m.name AS machine_name, m.speed as machine_speed, (CAST(ri.amount AS FLOAT)/rcp.production_time_s*m.speed) rate FROM recipes rcp
-			JOIN recipes_inputs ri ON rcp.id = ri.recipes_id
-			JOIN resources r ON ri.resources_id = r.id
-			JOIN machines_recipes mr ON rcp.id = mr.recipes_id
-			JOIN machines m ON mr.machines_id = m.id
-			WHERE rcp.id = ?
-			AND m.id = ? 
-			AND rcp.users_id = '` + fmt.Sprint(userId) + `'
-			AND ri.users_id = '` + fmt.Sprint(userId) + `'
-			AND r.users_id = '` + fmt.Sprint(userId) + `'
-			AND mr.users_id = '` + fmt.Sprint(userId) + `'
-			AND m.users_id = '` + fmt.Sprint(userId) + `';`
-	rows, err := db.QueryContext(ctx, query, recipe_id, machine_id)
-	if err != nil {
-		return err
-	}
-	defer rows.Close()
-	for rows.Next() {
-		err = rows.Scan(&resource.ID, &resource.ResourceName, &resource.AmountRequired, &resource.ProductionTime, &resource.MachineName, &resource.MachineSpeed, &resource.Rate)
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil
-		}
+func computeGreedyProductionTree(graph *recipeGraph, desiredResourceName string, desiredRate float32, options CalculationOptions) (*ProductionTree, error) {
+	if options.requiresPower() {
+		targets := []*ProductionTarget{{Resource: desiredResourceName, Rate: desiredRate}}
+		calculationResult, err := computeSteadyStateProductionTree(graph, targets, options)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("could not compute production chain for %s: %w", describeTargets(targets), err)
 		}
-		requiredResources.PushBack(resource)
-	}
-	err = rows.Err()
-	if err != nil {
-		return err
+		return calculationResult, nil
 	}
-	return nil
-}
-
-func getProducedResources(ctx context.Context, userId int, recipe_id uint, machine_id uint, producedResources *list.List, db *sql.DB) error {
-	var resource ProducedResourceResult
-	var query string = `SELECT r.id, r.name AS resource_name, ro.amount AS amount_produced, rcp.production_time_s AS production_time, m.name AS machine_name, m.speed as machine_speed, (CAST(ro.amount AS FLOAT)/rcp.production_time_s*m.speed) rate FROM recipes rcp
-			JOIN recipes_outputs ro ON rcp.id = ro.recipes_id
-			JOIN resources r ON ro.resources_id = r.id
-			JOIN machines_recipes mr ON rcp.id = mr.recipes_id
-			JOIN machines m ON mr.machines_id = m.id
-			WHERE rcp.id = ?
-			AND m.id = ?
-			AND rcp.users_id = '` + fmt.Sprint(userId) + `'
-			AND ro.users_id = '` + fmt.Sprint(userId) + `'
-			AND r.users_id = '` + fmt.Sprint(userId) + `'
-			AND mr.users_id = '` + fmt.Sprint(userId) + `'
-			AND m.users_id = '` + fmt.Sprint(userId) + `';`
-	rows, err := db.QueryContext(ctx, query, recipe_id, machine_id)
-	if err != nil {
-		return err
-	}
-	defer rows.Close()
-	for rows.Next() {
-		err = rows.Scan(&resource.ID, &resource.ResourceName, &resource.AmountProduced, &resource.ProductionTime, &resource.MachineName, &resource.MachineSpeed, &resource.Rate)
+	excessResources := list.New()
+	calculationResult := ProductionTree{TreeNodes: make([]*ProductionTreeNode, 0), ExcessResources: make([]*ResourceSource, excessResources.Len())}
+	chooser := graph.chooser(options, nil)
+	var err error
+	calculationResult.TargetResourceSourceNode, err = findAndComputeBestrecipeForResource(chooser, desiredResourceName, desiredRate, &calculationResult.TreeNodes, excessResources, make(map[string]bool))
+	if errors.Is(err, errProductionLoop) {
+		targets := []*ProductionTarget{{Resource: desiredResourceName, Rate: desiredRate}}
+		loopResult, err := computeSteadyStateProductionTree(graph, targets, options)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("could not compute production chain for %s: %w", describeTargets(targets), err)
 		}
-		producedResources.PushBack(resource)
+		return loopResult, nil
 	}
-	err = rows.Err()
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("could not compute production chain for resource '%s': %w", desiredResourceName, err)
+	}
+	for e := excessResources.Front(); e != nil; e = e.Next() {
+		newEntry := ResourceSource{NodeId: e.Value.(ResourceSource).NodeId, ExcessResourceName: e.Value.(ResourceSource).ExcessResourceName, ExcessProducedResourcePerSecond: e.Value.(ResourceSource).ExcessProducedResourcePerSecond}
+		calculationResult.ExcessResources = append(calculationResult.ExcessResources, &newEntry)
+	}
+	calculationResult.TargetResource = desiredResourceName
+	calculationResult.TargetResourceRate = desiredRate
+	calculationResult.Targets = []*ProductionTarget{{Resource: desiredResourceName, Rate: desiredRate, SourceNode: calculationResult.TargetResourceSourceNode}}
+	calculationResult.finish(graph, options)
+	if options.Explain {
+		calculationResult.explain(graph, options, chooser)
 	}
-	return nil
+	return &calculationResult, nil
 }
 
-func findAndComputeBestrecipeForResource(ctx context.Context, userId int, desiredResourceName string, desiredRate float32, recipes_names []string, machines_names []string, ProductionTreeNodes *[]*ProductionTreeNode, ExcessResources *list.List, db *sql.DB) (int, error) {
-	var bestrecipe BestrecipeResult
+func findAndComputeBestrecipeForResource(chooser *recipeChooser, desiredResourceName string, desiredRate float32, ProductionTreeNodes *[]*ProductionTreeNode, ExcessResources *list.List, ResourcesInProgress map[string]bool) (int, error) {
 	var machinesRequired float32
 	var NewNode ProductionTreeNode = ProductionTreeNode{RequiredResourcesPerSecond: make(map[string]float32), ProducedResourcesPerSecond: make(map[string]float32)}
 	var RequiredResourcesTemp = make(map[string]float32)
-	err := findBestrecipe(ctx, userId, desiredResourceName, recipes_names, machines_names, db, &bestrecipe)
-	if err != nil {
-		return -1, fmt.Errorf("could not compute production chain for resource '%s': %w", desiredResourceName, err)
-	}
-	machinesRequired = desiredRate / bestrecipe.Rate
-	requiredResources := list.New()
-	producedResources := list.New()
-	err = getRequiredResources(ctx, userId, bestrecipe.ID, bestrecipe.MachineId, requiredResources, db)
-	if err != nil {
-		return -1, fmt.Errorf("could not find required resources for recipe '%s': %w", bestrecipe.RecipeName, err)
+	bestrecipe, found := chooser.best(desiredResourceName)
+	if !found {
+		return -1, fmt.Errorf("could not compute production chain for resource '%s': %w", desiredResourceName, chooser.graph.noRecipeError(desiredResourceName, chooser.options))
 	}
-	err = getProducedResources(ctx, userId, bestrecipe.ID, bestrecipe.MachineId, producedResources, db)
-	if err != nil {
-		return -1, fmt.Errorf("could not find produced resources for recipe '%s': %w", bestrecipe.RecipeName, err)
-	}
-	for e := requiredResources.Front(); e != nil; e = e.Next() {
+	machinesRequired = desiredRate / float32(bestrecipe.outputRate(desiredResourceName))
+	for _, resourceName := range sortedKeys(bestrecipe.Recipe.Inputs) {
 		inserted := false
-		NewNode.RequiredResourcesPerSecond[e.Value.(RequiredResourceResult).ResourceName] = e.Value.(RequiredResourceResult).Rate * machinesRequired
+		requiredRate := float32(bestrecipe.inputRate(resourceName)) * machinesRequired
+		NewNode.RequiredResourcesPerSecond[resourceName] = requiredRate
 		for ei := ExcessResources.Front(); ei != nil; ei = ei.Next() {
-			if ei.Value.(ResourceSource).ExcessResourceName == e.Value.(RequiredResourceResult).ResourceName {
-				if ei.Value.(ResourceSource).ExcessProducedResourcePerSecond < e.Value.(RequiredResourceResult).Rate*machinesRequired {
-					RequiredResourcesTemp[e.Value.(RequiredResourceResult).ResourceName] = (e.Value.(RequiredResourceResult).Rate * machinesRequired) - ei.Value.(ResourceSource).ExcessProducedResourcePerSecond
-				} else if ei.Value.(ResourceSource).ExcessProducedResourcePerSecond >= e.Value.(RequiredResourceResult).Rate*machinesRequired {
-					newEiElement := ResourceSource{NodeId: ei.Value.(ResourceSource).NodeId, ExcessResourceName: ei.Value.(ResourceSource).ExcessResourceName, ExcessProducedResourcePerSecond: ei.Value.(ResourceSource).ExcessProducedResourcePerSecond - e.Value.(RequiredResourceResult).Rate*machinesRequired}
+			if ei.Value.(ResourceSource).ExcessResourceName == resourceName {
+				if ei.Value.(ResourceSource).ExcessProducedResourcePerSecond < requiredRate {
+					RequiredResourcesTemp[resourceName] = requiredRate - ei.Value.(ResourceSource).ExcessProducedResourcePerSecond
+				} else if ei.Value.(ResourceSource).ExcessProducedResourcePerSecond >= requiredRate {
+					newEiElement := ResourceSource{NodeId: ei.Value.(ResourceSource).NodeId, ExcessResourceName: ei.Value.(ResourceSource).ExcessResourceName, ExcessProducedResourcePerSecond: ei.Value.(ResourceSource).ExcessProducedResourcePerSecond - requiredRate}
 					if newEiElement.ExcessProducedResourcePerSecond > 0 {
 						ExcessResources.InsertBefore(newEiElement, ei)
 					}
@@ -261,29 +186,33 @@ func findAndComputeBestrecipeForResource(ctx context.Context, userId int, desire
 		}
 
 		if !inserted {
-			RequiredResourcesTemp[e.Value.(RequiredResourceResult).ResourceName] = e.Value.(RequiredResourceResult).Rate * machinesRequired
+			RequiredResourcesTemp[resourceName] = requiredRate
 		}
 	}
-	for e := producedResources.Front(); e != nil; e = e.Next() {
-		NewNode.ProducedResourcesPerSecond[e.Value.(ProducedResourceResult).ResourceName] = e.Value.(ProducedResourceResult).Rate * machinesRequired
-		if e.Value.(ProducedResourceResult).ResourceName != desiredResourceName {
+	for _, resourceName := range sortedKeys(bestrecipe.Recipe.Outputs) {
+		NewNode.ProducedResourcesPerSecond[resourceName] = float32(bestrecipe.outputRate(resourceName)) * machinesRequired
+		if resourceName != desiredResourceName {
 			var excessResource ResourceSource
 			excessResource.NodeId = len(*ProductionTreeNodes)
-			excessResource.ExcessResourceName = e.Value.(ProducedResourceResult).ResourceName
-			excessResource.ExcessProducedResourcePerSecond = e.Value.(ProducedResourceResult).Rate * machinesRequired
+			excessResource.ExcessResourceName = resourceName
+			excessResource.ExcessProducedResourcePerSecond = float32(bestrecipe.outputRate(resourceName)) * machinesRequired
 			ExcessResources.PushBack(excessResource)
 		}
 	}
-	NewNode.MachineName = bestrecipe.MachineName
-	NewNode.MachineNumber = machinesRequired
-	NewNode.RecipeName = bestrecipe.RecipeName
+	NewNode.MachineName = bestrecipe.Machine.Name
+	NewNode.RecipeName = bestrecipe.Recipe.Name
 	NewNode.NodeId = len(*ProductionTreeNodes)
-	NewNode.TotalPowerConsumedkW = uint64(machinesRequired) * bestrecipe.MachinePowerConsumption
+	NewNode.setMachines(float64(machinesRequired), bestrecipe.Machine)
 	*ProductionTreeNodes = append(*ProductionTreeNodes, &NewNode)
-	for resourceName, requiredAmount := range RequiredResourcesTemp {
+	ResourcesInProgress[desiredResourceName] = true
+	defer delete(ResourcesInProgress, desiredResourceName)
+	for _, resourceName := range sortedKeys(RequiredResourcesTemp) {
+		requiredAmount := RequiredResourcesTemp[resourceName]
 		if requiredAmount > 0 {
-			var sourceNode int
-			sourceNode, err = findAndComputeBestrecipeForResource(ctx, userId, resourceName, requiredAmount, recipes_names, machines_names, ProductionTreeNodes, ExcessResources, db)
+			if ResourcesInProgress[resourceName] {
+				return -1, fmt.Errorf("%w, resource '%s' is required to produce itself", errProductionLoop, resourceName)
+			}
+			sourceNode, err := findAndComputeBestrecipeForResource(chooser, resourceName, requiredAmount, ProductionTreeNodes, ExcessResources, ResourcesInProgress)
 			if err != nil {
 				return -1, fmt.Errorf("could not compute production chain for resource '%s': %w", resourceName, err)
 			}
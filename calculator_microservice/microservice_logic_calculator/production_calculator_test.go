@@ -0,0 +1,96 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSetMachines(t *testing.T) {
+	smelter := &graphMachine{Name: "smelter", PowerConsumptionKw: 4000, PowerClockExponent: 1.321928}
+	assembler := &graphMachine{Name: "assembler", PowerConsumptionKw: 10000, PowerClockExponent: 1}
+	generator := &graphMachine{Name: "coal_generator", PowerGenerationKw: 75000, PowerClockExponent: 1.321928}
+	tests := []struct {
+		name              string
+		machineNumber     float64
+		machine           *graphMachine
+		expectedCount     uint64
+		expectedClock     float64
+		expectedConsumed  uint64
+		expectedGenerated uint64
+	}{
+		{
+			name:             "fractional number of machines is underclocked",
+			machineNumber:    1.5,
+			machine:          smelter,
+			expectedCount:    2,
+			expectedClock:    75,
+			expectedConsumed: 5469,
+		},
+		{
+			name:             "linear power clock exponent",
+			machineNumber:    2.5,
+			machine:          assembler,
+			expectedCount:    3,
+			expectedClock:    250.0 / 3,
+			expectedConsumed: 25000,
+		},
+		{
+			name:             "rounding error above whole number",
+			machineNumber:    3.00001,
+			machine:          smelter,
+			expectedCount:    3,
+			expectedClock:    100.00033,
+			expectedConsumed: 12000,
+		},
+		{
+			name:          "no machines",
+			machineNumber: 0,
+			machine:       smelter,
+		},
+		{
+			name:              "power generation scales linearly with clock",
+			machineNumber:     1.5,
+			machine:           generator,
+			expectedCount:     2,
+			expectedClock:     75,
+			expectedGenerated: 112500,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			node := ProductionTreeNode{}
+			node.setMachines(test.machineNumber, test.machine)
+			if node.MachineCount != test.expectedCount {
+				t.Fatalf("expected %d machines, got %d", test.expectedCount, node.MachineCount)
+			}
+			if math.Abs(float64(node.ClockPercentage)-test.expectedClock) > 1e-3 {
+				t.Fatalf("expected clock %g%%, got %g%%", test.expectedClock, node.ClockPercentage)
+			}
+			if node.TotalPowerConsumedkW != test.expectedConsumed {
+				t.Fatalf("expected %d kW consumed, got %d", test.expectedConsumed, node.TotalPowerConsumedkW)
+			}
+			if node.PowerGeneratedkW != test.expectedGenerated {
+				t.Fatalf("expected %d kW generated, got %d", test.expectedGenerated, node.PowerGeneratedkW)
+			}
+			if float64(node.MachineNumber) != float64(float32(test.machineNumber)) {
+				t.Fatalf("expected machine number %g, got %g", test.machineNumber, node.MachineNumber)
+			}
+		})
+	}
+}
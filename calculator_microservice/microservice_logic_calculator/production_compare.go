@@ -0,0 +1,149 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	DeltaKindRaw       = "raw"
+	DeltaKindByproduct = "byproduct"
+)
+
+// Comparison holds totals of production trees calculated for the same targets with different scenarios,
+// together with their differences from the baseline scenario, which is the first one.
+type Comparison struct {
+	Baseline      string
+	Scenarios     []*ScenarioComparison
+	RateUnit      string
+	ResourceUnits map[string]string
+}
+
+type ScenarioComparison struct {
+	Name                  string
+	MachineCount          uint64
+	MachineNumber         float32
+	TotalPowerConsumedkW  uint64
+	TotalPowerGeneratedkW uint64
+	PowerBalancekW        int64
+	Machines              []*MachineSummary
+	RawResourcesPerSecond map[string]float32
+	ByproductsPerSecond   map[string]float32
+	// fields named PerUnit hold rates per RateUnit of the comparison, they are only filled for units other than seconds
+	RawResourcesPerUnit map[string]float32 `json:",omitempty"`
+	ByproductsPerUnit   map[string]float32 `json:",omitempty"`
+	// deltas are differences between the scenario and the baseline, positive when the scenario uses or produces more
+	MachineCountDelta         int64
+	MachineNumberDelta        float32
+	TotalPowerConsumedkWDelta int64
+	PowerBalancekWDelta       int64
+	ResourceDeltas            []*ResourceDelta
+}
+
+// ResourceDelta compares consumption of a raw resource or production of a byproduct, depending on Kind,
+// between the baseline and a scenario. Resources missing in one of them are counted as zero.
+type ResourceDelta struct {
+	Resource          string
+	Kind              string
+	BaselinePerSecond float32
+	ScenarioPerSecond float32
+	DeltaPerSecond    float32
+	BaselinePerUnit   float32 `json:",omitempty"`
+	ScenarioPerUnit   float32 `json:",omitempty"`
+	DeltaPerUnit      float32 `json:",omitempty"`
+}
+
+// CompareProductionTrees compares summaries of production trees, the first tree being the baseline. Names
+// are names of scenarios used to calculate the trees, all trees have to use the same rate unit.
+func CompareProductionTrees(names []string, trees []*ProductionTree) (*Comparison, error) {
+	if len(names) != len(trees) || len(trees) == 0 {
+		return nil, fmt.Errorf("every compared production tree needs exactly one scenario name")
+	}
+	comparison := Comparison{Baseline: names[0], Scenarios: []*ScenarioComparison{}, RateUnit: trees[0].RateUnit, ResourceUnits: make(map[string]string)}
+	for i, tree := range trees {
+		if tree.Summary == nil {
+			return nil, fmt.Errorf("production tree of scenario '%s' has no summary", names[i])
+		}
+		if tree.RateUnit != comparison.RateUnit {
+			return nil, fmt.Errorf("production tree of scenario '%s' uses rate unit '%s' instead of '%s'", names[i], tree.RateUnit, comparison.RateUnit)
+		}
+		for resourceName, unit := range tree.ResourceUnits {
+			comparison.ResourceUnits[resourceName] = unit
+		}
+		scenario := ScenarioComparison{
+			Name:                  names[i],
+			TotalPowerConsumedkW:  tree.Summary.TotalPowerConsumedkW,
+			TotalPowerGeneratedkW: tree.Summary.TotalPowerGeneratedkW,
+			PowerBalancekW:        tree.Summary.PowerBalancekW,
+			Machines:              tree.Summary.Machines,
+			RawResourcesPerSecond: tree.Summary.RawResourcesPerSecond,
+			ByproductsPerSecond:   tree.Summary.ByproductsPerSecond,
+			RawResourcesPerUnit:   tree.Summary.RawResourcesPerUnit,
+			ByproductsPerUnit:     tree.Summary.ByproductsPerUnit,
+		}
+		for _, machine := range tree.Summary.Machines {
+			scenario.MachineCount += machine.MachineCount
+			scenario.MachineNumber += machine.MachineNumber
+		}
+		comparison.Scenarios = append(comparison.Scenarios, &scenario)
+	}
+	seconds, _ := RateUnitSeconds(comparison.RateUnit)
+	baseline := comparison.Scenarios[0]
+	for _, scenario := range comparison.Scenarios {
+		scenario.MachineCountDelta = int64(scenario.MachineCount) - int64(baseline.MachineCount)
+		scenario.MachineNumberDelta = scenario.MachineNumber - baseline.MachineNumber
+		scenario.TotalPowerConsumedkWDelta = int64(scenario.TotalPowerConsumedkW) - int64(baseline.TotalPowerConsumedkW)
+		scenario.PowerBalancekWDelta = scenario.PowerBalancekW - baseline.PowerBalancekW
+		scenario.ResourceDeltas = append(resourceDeltas(DeltaKindRaw, baseline.RawResourcesPerSecond, scenario.RawResourcesPerSecond, seconds),
+			resourceDeltas(DeltaKindByproduct, baseline.ByproductsPerSecond, scenario.ByproductsPerSecond, seconds)...)
+	}
+	return &comparison, nil
+}
+
+// resourceDeltas returns deltas of all resources present in baseline or scenario rates, sorted by resource name.
+// Per second rates are also expressed per unit lasting given number of seconds, unless the unit is a second.
+func resourceDeltas(kind string, baselineRates map[string]float32, scenarioRates map[string]float32, seconds float32) []*ResourceDelta {
+	resources := []string{}
+	for resourceName := range baselineRates {
+		resources = append(resources, resourceName)
+	}
+	for resourceName := range scenarioRates {
+		if _, exists := baselineRates[resourceName]; !exists {
+			resources = append(resources, resourceName)
+		}
+	}
+	sort.Strings(resources)
+	deltas := []*ResourceDelta{}
+	for _, resourceName := range resources {
+		delta := ResourceDelta{
+			Resource:          resourceName,
+			Kind:              kind,
+			BaselinePerSecond: baselineRates[resourceName],
+			ScenarioPerSecond: scenarioRates[resourceName],
+			DeltaPerSecond:    scenarioRates[resourceName] - baselineRates[resourceName],
+		}
+		if seconds != 1 {
+			delta.BaselinePerUnit = delta.BaselinePerSecond * seconds
+			delta.ScenarioPerUnit = delta.ScenarioPerSecond * seconds
+			delta.DeltaPerUnit = delta.DeltaPerSecond * seconds
+		}
+		deltas = append(deltas, &delta)
+	}
+	return deltas
+}
@@ -0,0 +1,170 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newCompareTestTrees returns baseline tree smelting iron ore with slag as byproduct and scenario tree
+// using constructors and coal, which generates power.
+func newCompareTestTrees(rateUnit string) []*ProductionTree {
+	baseline := &ProductionTree{
+		RateUnit:      rateUnit,
+		ResourceUnits: map[string]string{"iron_ore": "items", "slag": "items"},
+		Summary: &ProductionSummary{
+			Machines: []*MachineSummary{
+				{MachineName: "miner", MachineCount: 1, MachineNumber: 0.75, TotalPowerConsumedkW: 5000},
+				{MachineName: "smelter", MachineCount: 2, MachineNumber: 1.5, TotalPowerConsumedkW: 8000},
+			},
+			TotalPowerConsumedkW:  13000,
+			PowerBalancekW:        -13000,
+			RawResourcesPerSecond: map[string]float32{"iron_ore": 1.5},
+			ByproductsPerSecond:   map[string]float32{"slag": 0.5},
+		},
+	}
+	scenario := &ProductionTree{
+		RateUnit:      rateUnit,
+		ResourceUnits: map[string]string{"iron_ore": "items", "coal": "items"},
+		Summary: &ProductionSummary{
+			Machines: []*MachineSummary{
+				{MachineName: "constructor", MachineCount: 3, MachineNumber: 2.5, TotalPowerConsumedkW: 12000},
+			},
+			TotalPowerConsumedkW:  12000,
+			TotalPowerGeneratedkW: 20000,
+			PowerBalancekW:        8000,
+			RawResourcesPerSecond: map[string]float32{"iron_ore": 1, "coal": 0.5},
+			ByproductsPerSecond:   map[string]float32{},
+		},
+	}
+	return []*ProductionTree{baseline, scenario}
+}
+
+func TestCompareProductionTrees(t *testing.T) {
+	comparison, err := CompareProductionTrees([]string{"smelting", "coal"}, newCompareTestTrees(RateUnitMinute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if comparison.Baseline != "smelting" || comparison.RateUnit != RateUnitMinute || len(comparison.Scenarios) != 2 {
+		t.Fatalf("unexpected comparison %+v", comparison)
+	}
+	expectedUnits := map[string]string{"iron_ore": "items", "slag": "items", "coal": "items"}
+	if !reflect.DeepEqual(comparison.ResourceUnits, expectedUnits) {
+		t.Fatalf("expected resource units %v, got %v", expectedUnits, comparison.ResourceUnits)
+	}
+
+	baseline := comparison.Scenarios[0]
+	if baseline.MachineCount != 3 || baseline.MachineNumber != 2.25 {
+		t.Fatalf("expected baseline to total 3 machines and machine number 2.25, got %d and %f", baseline.MachineCount, baseline.MachineNumber)
+	}
+	if baseline.MachineCountDelta != 0 || baseline.MachineNumberDelta != 0 || baseline.TotalPowerConsumedkWDelta != 0 || baseline.PowerBalancekWDelta != 0 {
+		t.Fatalf("expected baseline deltas to be zero, got %+v", baseline)
+	}
+	for _, delta := range baseline.ResourceDeltas {
+		if delta.DeltaPerSecond != 0 || delta.DeltaPerUnit != 0 {
+			t.Fatalf("expected baseline resource deltas to be zero, got %+v", delta)
+		}
+	}
+
+	scenario := comparison.Scenarios[1]
+	if scenario.Name != "coal" || scenario.MachineCount != 3 || scenario.MachineNumber != 2.5 {
+		t.Fatalf("expected scenario 'coal' to total 3 machines and machine number 2.5, got '%s' with %d and %f", scenario.Name, scenario.MachineCount, scenario.MachineNumber)
+	}
+	if scenario.MachineCountDelta != 0 || scenario.MachineNumberDelta != 0.25 || scenario.TotalPowerConsumedkWDelta != -1000 || scenario.PowerBalancekWDelta != 21000 {
+		t.Fatalf("unexpected deltas of scenario %+v", scenario)
+	}
+	expectedDeltas := []*ResourceDelta{
+		{Resource: "coal", Kind: DeltaKindRaw, BaselinePerSecond: 0, ScenarioPerSecond: 0.5, DeltaPerSecond: 0.5, BaselinePerUnit: 0, ScenarioPerUnit: 30, DeltaPerUnit: 30},
+		{Resource: "iron_ore", Kind: DeltaKindRaw, BaselinePerSecond: 1.5, ScenarioPerSecond: 1, DeltaPerSecond: -0.5, BaselinePerUnit: 90, ScenarioPerUnit: 60, DeltaPerUnit: -30},
+		{Resource: "slag", Kind: DeltaKindByproduct, BaselinePerSecond: 0.5, ScenarioPerSecond: 0, DeltaPerSecond: -0.5, BaselinePerUnit: 30, ScenarioPerUnit: 0, DeltaPerUnit: -30},
+	}
+	if len(scenario.ResourceDeltas) != len(expectedDeltas) {
+		t.Fatalf("expected %d resource deltas, got %d", len(expectedDeltas), len(scenario.ResourceDeltas))
+	}
+	for i, delta := range scenario.ResourceDeltas {
+		if !reflect.DeepEqual(delta, expectedDeltas[i]) {
+			t.Fatalf("expected resource delta %d to be %+v, got %+v", i, expectedDeltas[i], delta)
+		}
+	}
+}
+
+func TestCompareProductionTreesInSeconds(t *testing.T) {
+	comparison, err := CompareProductionTrees([]string{"smelting", "coal"}, newCompareTestTrees(RateUnitSecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, delta := range comparison.Scenarios[1].ResourceDeltas {
+		if delta.DeltaPerSecond == 0 {
+			t.Fatalf("expected delta of '%s' per second to be filled", delta.Resource)
+		}
+		if delta.BaselinePerUnit != 0 || delta.ScenarioPerUnit != 0 || delta.DeltaPerUnit != 0 {
+			t.Fatalf("expected rates of '%s' per unit to be empty, got %+v", delta.Resource, delta)
+		}
+	}
+}
+
+func TestCompareProductionTreesErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		names    []string
+		trees    func() []*ProductionTree
+		expected string
+	}{
+		{
+			name:     "no trees",
+			names:    []string{},
+			trees:    func() []*ProductionTree { return []*ProductionTree{} },
+			expected: "every compared production tree needs exactly one scenario name",
+		},
+		{
+			name:     "missing scenario name",
+			names:    []string{"smelting"},
+			trees:    func() []*ProductionTree { return newCompareTestTrees(RateUnitSecond) },
+			expected: "every compared production tree needs exactly one scenario name",
+		},
+		{
+			name:  "missing summary",
+			names: []string{"smelting", "coal"},
+			trees: func() []*ProductionTree {
+				trees := newCompareTestTrees(RateUnitSecond)
+				trees[1].Summary = nil
+				return trees
+			},
+			expected: "production tree of scenario 'coal' has no summary",
+		},
+		{
+			name:  "different rate units",
+			names: []string{"smelting", "coal"},
+			trees: func() []*ProductionTree {
+				trees := newCompareTestTrees(RateUnitSecond)
+				trees[1].RateUnit = RateUnitHour
+				return trees
+			},
+			expected: "production tree of scenario 'coal' uses rate unit 'h' instead of 's'",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := CompareProductionTrees(test.names, test.trees())
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Fatalf("expected error containing %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
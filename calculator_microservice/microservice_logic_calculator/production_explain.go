@@ -0,0 +1,176 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"fmt"
+	"slices"
+)
+
+// NodeExplanation lists recipe and machine pairs considered for producing Resource in a node of production tree.
+// Resource is empty for nodes generating power, whose candidates are all generators.
+type NodeExplanation struct {
+	Resource   string
+	Candidates []*CandidateExplanation
+}
+
+// CandidateExplanation is a recipe and machine pair considered for a node. ResourcePerSecond is the production rate
+// of explained resource by a single machine at full speed, ResourcePerUnit is the same rate per RateUnit of the tree. Rejection is the rule that eliminated the pair,
+// it is empty for the pair chosen for the node.
+type CandidateExplanation struct {
+	RecipeName        string
+	MachineName       string
+	ResourcePerSecond float32
+	ResourcePerUnit   float32 `json:",omitempty"`
+	Chosen            bool
+	Rejection         string
+}
+
+// explain attaches explanation to every node of production tree, except nodes sinking excess resources. Candidates are compared the same way
+// as chooser compares them, nil chooser means that nodes were chosen by solving the whole recipe graph.
+func (t *ProductionTree) explain(graph *recipeGraph, options CalculationOptions, chooser *recipeChooser) {
+	type consideredPair struct {
+		candidate     recipeCandidate
+		slotsMismatch string
+		locked        string
+	}
+	for _, node := range t.TreeNodes {
+		if node.SunkResource != "" {
+			continue
+		}
+		explanation := NodeExplanation{Resource: t.explainedResource(node), Candidates: []*CandidateExplanation{}}
+		considered := func(candidate recipeCandidate) bool {
+			if explanation.Resource == "" {
+				return candidate.Machine.PowerGenerationKw > 0
+			}
+			return candidate.Recipe.Outputs[explanation.Resource] > 0
+		}
+		pairs := []consideredPair{}
+		chosen := recipeCandidate{}
+		for _, recipe := range graph.Recipes {
+			for _, machine := range recipe.Machines {
+				candidate := recipeCandidate{Recipe: recipe, Machine: machine}
+				if !considered(candidate) {
+					continue
+				}
+				pairs = append(pairs, consideredPair{candidate: candidate})
+				if recipe.Name == node.RecipeName && machine.Name == node.MachineName {
+					chosen = candidate
+				}
+			}
+			for _, pairing := range graph.RejectedPairings {
+				candidate := recipeCandidate{Recipe: pairing.Recipe, Machine: pairing.Machine}
+				if pairing.Recipe == recipe && considered(candidate) {
+					pairs = append(pairs, consideredPair{candidate: candidate, slotsMismatch: pairing.Reason})
+				}
+			}
+			for _, pairing := range graph.LockedPairings {
+				candidate := recipeCandidate{Recipe: pairing.Recipe, Machine: pairing.Machine}
+				if pairing.Recipe == recipe && considered(candidate) {
+					pairs = append(pairs, consideredPair{candidate: candidate, locked: pairing.describeUnlocks()})
+				}
+			}
+		}
+		for _, pair := range pairs {
+			candidate := pair.candidate
+			candidateExplanation := CandidateExplanation{RecipeName: candidate.Recipe.Name, MachineName: candidate.Machine.Name}
+			if explanation.Resource != "" {
+				candidateExplanation.ResourcePerSecond = float32(candidate.outputRate(explanation.Resource))
+			}
+			switch {
+			case candidate == chosen:
+				candidateExplanation.Chosen = true
+			case !candidate.Recipe.DefaultChoice && !slices.Contains(options.RecipesNames, candidate.Recipe.Name):
+				candidateExplanation.Rejection = "recipe is not default and is not listed in alt_recipe"
+			case !candidate.Machine.DefaultChoice && !slices.Contains(options.MachinesNames, candidate.Machine.Name):
+				candidateExplanation.Rejection = "machine is not default and is not listed in alt_machine"
+			case pair.slotsMismatch != "":
+				candidateExplanation.Rejection = "slot mismatch, " + pair.slotsMismatch
+			case pair.locked != "":
+				candidateExplanation.Rejection = "locked, " + pair.locked
+			case chooser == nil || chosen.Recipe == nil:
+				candidateExplanation.Rejection = t.optimalRejection(candidate)
+			default:
+				candidateExplanation.Rejection = chooser.rejection(candidate, chosen, explanation.Resource)
+			}
+			explanation.Candidates = append(explanation.Candidates, &candidateExplanation)
+		}
+		node.Explanation = &explanation
+	}
+}
+
+// explainedResource returns the resource a node was added for: a target, a resource consumed by nodes using
+// the node as a source or, for generators producing nothing used by the tree, an empty string for power.
+func (t *ProductionTree) explainedResource(node *ProductionTreeNode) string {
+	outputs := sortedKeys(node.ProducedResourcesPerSecond)
+	for _, resourceName := range outputs {
+		if slices.ContainsFunc(t.Targets, func(target *ProductionTarget) bool { return target.Resource == resourceName }) {
+			return resourceName
+		}
+	}
+	for _, resourceName := range outputs {
+		for _, consumer := range t.TreeNodes {
+			if consumer.SunkResource == "" && slices.Contains(consumer.SourceNodes, node.NodeId) && consumer.RequiredResourcesPerSecond[resourceName] > 0 {
+				return resourceName
+			}
+		}
+	}
+	if node.PowerGeneratedkW > 0 || len(outputs) == 0 {
+		return ""
+	}
+	return outputs[0]
+}
+
+// optimalRejection explains why an allowed candidate is not chosen for a node of production tree computed
+// by solving the whole recipe graph.
+func (t *ProductionTree) optimalRejection(candidate recipeCandidate) string {
+	for _, node := range t.TreeNodes {
+		if node.RecipeName == candidate.Recipe.Name && node.MachineName == candidate.Machine.Name {
+			return fmt.Sprintf("used by node %d", node.NodeId)
+		}
+	}
+	return "not used by the optimal solution"
+}
+
+// rejection explains why candidate lost to chosen for resource, following rules of best and bestGenerator.
+// Empty resource means that generators are compared.
+func (c *recipeChooser) rejection(candidate recipeCandidate, chosen recipeCandidate, resourceName string) string {
+	if c.options.Objective != "" && c.options.Objective != ObjectiveRate {
+		var candidateCost, chosenCost float64
+		if resourceName == "" {
+			candidateCost, chosenCost = c.generationCost(candidate), c.generationCost(chosen)
+		} else {
+			candidateCost, chosenCost = c.unitCost(candidate, resourceName), c.unitCost(chosen, resourceName)
+		}
+		if candidateCost > chosenCost*(1+simplexEpsilon) {
+			return fmt.Sprintf("higher cost according to objective '%s'", c.options.Objective)
+		}
+	}
+	if candidate.Recipe.DefaultChoice && !chosen.Recipe.DefaultChoice {
+		return "allowed alternative recipe takes precedence over default recipe"
+	}
+	if resourceName == "" {
+		if candidate.Machine.PowerGenerationKw < chosen.Machine.PowerGenerationKw {
+			return "lower power generation"
+		}
+		return "same power generation, chosen generator was found first"
+	}
+	if candidate.outputRate(resourceName) < chosen.outputRate(resourceName) {
+		return "lower production rate"
+	}
+	return "same production rate, chosen pair was found first"
+}
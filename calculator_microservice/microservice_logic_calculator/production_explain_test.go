@@ -0,0 +1,140 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import "testing"
+
+func TestExplainCandidates(t *testing.T) {
+	tests := []struct {
+		name      string
+		calculate func() (*ProductionTree, error)
+		// expected maps recipes of candidates for the screw node to their rejections, empty rejection marks
+		// the chosen candidate
+		expected map[string]string
+	}{
+		{
+			name: "default recipe",
+			calculate: func() (*ProductionTree, error) {
+				return computeGreedyProductionTree(slotsRecipeGraph(), "screw", 1, CalculationOptions{Explain: true})
+			},
+			expected: map[string]string{
+				"screw":      "",
+				"cast_screw": "recipe is not default and is not listed in alt_recipe",
+				"wet_screw":  "slot mismatch, recipe needs 1 liquid inputs, machine has 0",
+			},
+		},
+		{
+			name: "allowed alternative recipe",
+			calculate: func() (*ProductionTree, error) {
+				return computeGreedyProductionTree(slotsRecipeGraph(), "screw", 1, CalculationOptions{Explain: true, RecipesNames: []string{"cast_screw"}})
+			},
+			expected: map[string]string{
+				"screw":      "allowed alternative recipe takes precedence over default recipe",
+				"cast_screw": "",
+				"wet_screw":  "slot mismatch, recipe needs 1 liquid inputs, machine has 0",
+			},
+		},
+		{
+			name: "production rate",
+			calculate: func() (*ProductionTree, error) {
+				return computeGreedyProductionTree(objectivesRecipeGraph(), "screw", 1, CalculationOptions{Explain: true})
+			},
+			expected: map[string]string{
+				"screw":       "lower production rate",
+				"alloy_screw": "",
+			},
+		},
+		{
+			name: "objective",
+			calculate: func() (*ProductionTree, error) {
+				return computeGreedyProductionTree(objectivesRecipeGraph(), "screw", 1, CalculationOptions{Explain: true, Objective: ObjectivePower})
+			},
+			expected: map[string]string{
+				"screw":       "",
+				"alloy_screw": "higher cost according to objective 'power'",
+			},
+		},
+		{
+			name: "optimal solution",
+			calculate: func() (*ProductionTree, error) {
+				return computeOptimalProductionTree(objectivesRecipeGraph(), []*ProductionTarget{{Resource: "screw", Rate: 1}}, CalculationOptions{Explain: true, Objective: ObjectivePower})
+			},
+			expected: map[string]string{
+				"screw":       "",
+				"alloy_screw": "not used by the optimal solution",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tree, err := test.calculate()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var screwNode *ProductionTreeNode
+			for _, node := range tree.TreeNodes {
+				if node.Explanation == nil {
+					t.Fatalf("expected node %d to be explained", node.NodeId)
+				}
+				if node.ProducedResourcesPerSecond["screw"] > 0 {
+					screwNode = node
+				} else if node.Explanation.Resource == "screw" {
+					t.Fatalf("expected node %d producing %v not to be explained as screw producer", node.NodeId, node.ProducedResourcesPerSecond)
+				}
+			}
+			if screwNode == nil || screwNode.Explanation.Resource != "screw" {
+				t.Fatalf("expected node explained as screw producer")
+			}
+			if len(screwNode.Explanation.Candidates) != len(test.expected) {
+				t.Fatalf("expected %d candidates, got %d", len(test.expected), len(screwNode.Explanation.Candidates))
+			}
+			for _, candidate := range screwNode.Explanation.Candidates {
+				rejection, exists := test.expected[candidate.RecipeName]
+				if !exists {
+					t.Fatalf("unexpected candidate '%s'", candidate.RecipeName)
+				}
+				if candidate.Rejection != rejection || candidate.Chosen != (rejection == "") {
+					t.Fatalf("expected candidate '%s' to have rejection %q, got %q (chosen: %t)", candidate.RecipeName, rejection, candidate.Rejection, candidate.Chosen)
+				}
+				if candidate.Chosen && candidate.RecipeName != screwNode.RecipeName {
+					t.Fatalf("expected chosen candidate to be recipe of the node '%s', got '%s'", screwNode.RecipeName, candidate.RecipeName)
+				}
+			}
+		})
+	}
+}
+
+func TestExplainCandidateRates(t *testing.T) {
+	tree, err := computeGreedyProductionTree(testRecipeGraph(), "screw", 1, CalculationOptions{Explain: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// screw recipe produces 40 screws per minute, cast_screw recipe 50
+	expected := map[string]float32{"screw": 40.0 / 60, "cast_screw": 50.0 / 60}
+	for _, node := range tree.TreeNodes {
+		if node.RecipeName != "screw" {
+			continue
+		}
+		for _, candidate := range node.Explanation.Candidates {
+			if candidate.ResourcePerSecond != expected[candidate.RecipeName] {
+				t.Fatalf("expected candidate '%s' to produce %f screws per second, got %f", candidate.RecipeName, expected[candidate.RecipeName], candidate.ResourcePerSecond)
+			}
+		}
+		return
+	}
+	t.Fatalf("expected node with screw recipe")
+}
@@ -0,0 +1,248 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// recipeSelection holds recipe chosen for every resource needed to produce the targets,
+// together with loops found between the chosen recipes.
+type recipeSelection struct {
+	Chosen     map[string]recipeCandidate
+	Candidates []recipeCandidate
+	Loops      [][]string
+}
+
+// CalculateMultiple computes one production tree producing all targets, with intermediate resources shared
+// between them. Recipes are chosen by the same rules as in Calculate, but the flow through the whole chain is solved
+// at once, which makes it also usable when chosen recipes form a loop. Nodes of a loop point back to each other
+// through SourceNodes.
+func CalculateMultiple(ctx context.Context, userId int, targets []*ProductionTarget, options CalculationOptions, graphs *RecipeGraphCache) (*ProductionTree, error) {
+	graph, err := graphs.graph(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("could not load recipes: %w", err)
+	}
+	calculationResult, err := computeSteadyStateProductionTree(graph, mergeTargets(targets), options)
+	if err != nil {
+		return nil, fmt.Errorf("could not compute production chain for %s: %w", describeTargets(targets), err)
+	}
+	return calculationResult, nil
+}
+
+func computeSteadyStateProductionTree(graph *recipeGraph, targets []*ProductionTarget, options CalculationOptions) (*ProductionTree, error) {
+	demands := targetsDemands(targets)
+	selection, err := graph.selectRecipes(demands, nil, options)
+	if err != nil {
+		return nil, err
+	}
+	machines, err := solveSelection(selection, demands, nil, options)
+	if err != nil {
+		return nil, err
+	}
+	calculationResult := buildNetworkProductionTree(selection.Candidates, machines, demands)
+	calculationResult.setTargets(targets)
+	calculationResult.finish(graph, options)
+	if options.Explain {
+		calculationResult.explain(graph, options, graph.chooser(options, nil))
+	}
+	return calculationResult, nil
+}
+
+// selectRecipes walks the production chain depth first starting from demanded resources, choosing one recipe
+// for each resource. Supplied resources are treated as inputs of the chain and are not expanded.
+// Every edge pointing back to a resource that is still being expanded closes a loop. When power is required,
+// a generator is chosen after demanded resources and its fuel chain is walked the same way.
+func (g *recipeGraph) selectRecipes(demands map[string]float64, supplies map[string]float64, options CalculationOptions) (*recipeSelection, error) {
+	selection := recipeSelection{Chosen: make(map[string]recipeCandidate)}
+	chooser := g.chooser(options, supplies)
+	const (
+		notVisited = iota
+		inProgress
+		done
+	)
+	state := make(map[string]int)
+	path := []string{}
+	var visit func(resourceName string) error
+	var visitInputs func(candidate recipeCandidate) error
+	visit = func(resourceName string) error {
+		candidate, found := chooser.best(resourceName)
+		if !found {
+			return g.noRecipeError(resourceName, options)
+		}
+		state[resourceName] = inProgress
+		path = append(path, resourceName)
+		selection.Chosen[resourceName] = candidate
+		err := visitInputs(candidate)
+		if err != nil {
+			return err
+		}
+		path = path[:len(path)-1]
+		state[resourceName] = done
+		return nil
+	}
+	visitInputs = func(candidate recipeCandidate) error {
+		inputs := []string{}
+		for inputName := range candidate.Recipe.Inputs {
+			inputs = append(inputs, inputName)
+		}
+		sort.Strings(inputs)
+		for _, inputName := range inputs {
+			if _, supplied := supplies[inputName]; supplied {
+				continue
+			}
+			switch state[inputName] {
+			case notVisited:
+				err := visit(inputName)
+				if err != nil {
+					return fmt.Errorf("could not compute production chain for resource '%s': %w", inputName, err)
+				}
+			case inProgress:
+				for i := len(path) - 1; i >= 0; i-- {
+					if path[i] == inputName {
+						loop := append([]string{}, path[i:]...)
+						selection.Loops = append(selection.Loops, append(loop, inputName))
+						break
+					}
+				}
+			}
+		}
+		return nil
+	}
+	demandedResources := []string{}
+	for resourceName := range demands {
+		demandedResources = append(demandedResources, resourceName)
+	}
+	sort.Strings(demandedResources)
+	for _, resourceName := range demandedResources {
+		if state[resourceName] != notVisited {
+			continue
+		}
+		err := visit(resourceName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var generator *recipeCandidate
+	if options.requiresPower() {
+		candidate, found := chooser.bestGenerator()
+		if !found {
+			return nil, fmt.Errorf("could not find recipe generating power")
+		}
+		generator = &candidate
+		err := visitInputs(candidate)
+		if err != nil {
+			return nil, fmt.Errorf("could not compute fuel chain of '%s': %w", candidate.Recipe.Name, err)
+		}
+	}
+
+	chosenResources := []string{}
+	for resourceName := range selection.Chosen {
+		chosenResources = append(chosenResources, resourceName)
+	}
+	sort.Strings(chosenResources)
+	for _, resourceName := range chosenResources {
+		candidate := selection.Chosen[resourceName]
+		duplicate := false
+		for _, added := range selection.Candidates {
+			if added == candidate {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			selection.Candidates = append(selection.Candidates, candidate)
+		}
+	}
+	if generator != nil && !slices.Contains(selection.Candidates, *generator) {
+		selection.Candidates = append(selection.Candidates, *generator)
+	}
+	return &selection, nil
+}
+
+// solveSelection computes number of machines for every selected recipe, so that every resource is produced
+// at least as fast as it is consumed, with the lowest cost according to objective.
+func solveSelection(selection *recipeSelection, demands map[string]float64, supplies map[string]float64, options CalculationOptions) ([]float64, error) {
+	machines, err := solveLinearProgram(options.costs(selection.Candidates, supplies), balanceConstraints(selection.Candidates, demands, supplies, options))
+	if errors.Is(err, errLinearProgramInfeasible) {
+		if len(selection.Loops) > 0 {
+			descriptions := []string{}
+			for _, loop := range selection.Loops {
+				descriptions = append(descriptions, "'"+strings.Join(loop, "' -> '")+"'")
+			}
+			return nil, fmt.Errorf("production loop %s has no positive steady state, the loop consumes at least as much as it produces", strings.Join(descriptions, ", "))
+		}
+		if options.requiresPower() {
+			return nil, fmt.Errorf("demanded resources and power cannot be produced using chosen recipes, generators may consume at least as much power as they generate")
+		}
+		return nil, fmt.Errorf("demanded resources cannot be produced using chosen recipes")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not solve flow through production chain: %w", err)
+	}
+	return machines, nil
+}
+
+// mergeTargets sums rates of targets with the same resource, keeping order of first occurrence.
+func mergeTargets(targets []*ProductionTarget) []*ProductionTarget {
+	merged := []*ProductionTarget{}
+	for _, target := range targets {
+		index := slices.IndexFunc(merged, func(added *ProductionTarget) bool { return added.Resource == target.Resource })
+		if index == -1 {
+			merged = append(merged, &ProductionTarget{Resource: target.Resource, Rate: target.Rate})
+			continue
+		}
+		merged[index].Rate += target.Rate
+	}
+	return merged
+}
+
+func targetsDemands(targets []*ProductionTarget) map[string]float64 {
+	demands := make(map[string]float64)
+	for _, target := range targets {
+		demands[target.Resource] += float64(target.Rate)
+	}
+	return demands
+}
+
+func describeTargets(targets []*ProductionTarget) string {
+	names := []string{}
+	for _, target := range targets {
+		names = append(names, "'"+target.Resource+"'")
+	}
+	return "resources " + strings.Join(names, ", ")
+}
+
+// setTargets fills Targets of the tree. TargetResource fields are only filled for a single target,
+// otherwise TargetResourceSourceNode is set to -1.
+func (t *ProductionTree) setTargets(targets []*ProductionTarget) {
+	t.Targets = []*ProductionTarget{}
+	for _, target := range targets {
+		t.Targets = append(t.Targets, &ProductionTarget{Resource: target.Resource, Rate: target.Rate, SourceNode: t.mainProducer(target.Resource)})
+	}
+	t.TargetResourceSourceNode = -1
+	if len(t.Targets) == 1 {
+		t.TargetResource = t.Targets[0].Resource
+		t.TargetResourceRate = t.Targets[0].Rate
+		t.TargetResourceSourceNode = t.Targets[0].SourceNode
+	}
+}
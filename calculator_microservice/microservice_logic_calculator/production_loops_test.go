@@ -0,0 +1,134 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// loopRecipeGraph returns recipes of plastic and rubber converted into each other. Rubber recipe returns
+// rubberOutput rubber from 20 plastic, plastic recipe returns 60 plastic from 30 rubber.
+func loopRecipeGraph(rubberOutput float64) *recipeGraph {
+	graph := &recipeGraph{Resources: map[string]*graphResource{}, Producers: map[string][]*graphRecipe{}}
+	refinery := &graphMachine{Id: 1, Name: "refinery", InputsSolid: 1, OutputsSolid: 1, Speed: 1, PowerConsumptionKw: 30000, DefaultChoice: true}
+	addRecipe := func(id uint, name string, inputs map[string]float64, outputs map[string]float64) {
+		recipe := &graphRecipe{Id: id, Name: name, ProductionTimeS: 60, DefaultChoice: true, Inputs: inputs, Outputs: outputs, Machines: []*graphMachine{refinery}}
+		graph.Recipes = append(graph.Recipes, recipe)
+		for resource := range outputs {
+			graph.Producers[resource] = append(graph.Producers[resource], recipe)
+			graph.Resources[resource] = &graphResource{Name: resource}
+		}
+	}
+	addRecipe(1, "plastic", map[string]float64{"rubber": 30}, map[string]float64{"plastic": 60})
+	addRecipe(2, "rubber", map[string]float64{"plastic": 20}, map[string]float64{"rubber": rubberOutput})
+	return graph
+}
+
+func TestSelectRecipesDetectsLoops(t *testing.T) {
+	tests := []struct {
+		name     string
+		graph    *recipeGraph
+		demand   string
+		expected [][]string
+	}{
+		{
+			name:   "chain without loop",
+			graph:  testRecipeGraph(),
+			demand: "reinforced_iron_plate",
+		},
+		{
+			name:     "loop between two recipes",
+			graph:    loopRecipeGraph(40),
+			demand:   "plastic",
+			expected: [][]string{{"plastic", "rubber", "plastic"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selection, err := test.graph.selectRecipes(map[string]float64{test.demand: 1}, nil, CalculationOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(selection.Loops, test.expected) {
+				t.Fatalf("expected loops %v, got %v", test.expected, selection.Loops)
+			}
+		})
+	}
+}
+
+func TestComputeSteadyStateOfLoop(t *testing.T) {
+	tests := []struct {
+		name      string
+		calculate func(graph *recipeGraph) (*ProductionTree, error)
+	}{
+		{
+			name: "steady state calculation",
+			calculate: func(graph *recipeGraph) (*ProductionTree, error) {
+				return computeSteadyStateProductionTree(graph, []*ProductionTarget{{Resource: "plastic", Rate: 1}}, CalculationOptions{})
+			},
+		},
+		{
+			name: "greedy calculation falling back to steady state",
+			calculate: func(graph *recipeGraph) (*ProductionTree, error) {
+				return computeGreedyProductionTree(graph, "plastic", 1, CalculationOptions{})
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tree, err := test.calculate(loopRecipeGraph(40))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// plastic machines produce 1 plastic/s on top of 1/3 plastic/s consumed by one rubber machine,
+			// which produces 2/3 rubber/s consumed by them
+			expected := map[string]float64{"plastic": 4.0 / 3, "rubber": 1}
+			nodes := make(map[string]*ProductionTreeNode)
+			for _, node := range tree.TreeNodes {
+				nodes[node.RecipeName] = node
+			}
+			if len(nodes) != len(expected) {
+				t.Fatalf("expected nodes of recipes %v, got %d nodes", expected, len(tree.TreeNodes))
+			}
+			for recipe, machineNumber := range expected {
+				if node := nodes[recipe]; node == nil || math.Abs(float64(node.MachineNumber)-machineNumber) > 1e-5 {
+					t.Fatalf("expected %g machines of recipe '%s', got %v", machineNumber, recipe, node)
+				}
+			}
+			if !reflect.DeepEqual(nodes["plastic"].SourceNodes, []int{nodes["rubber"].NodeId}) || !reflect.DeepEqual(nodes["rubber"].SourceNodes, []int{nodes["plastic"].NodeId}) {
+				t.Fatalf("expected nodes of loop to point to each other, got %v and %v", nodes["plastic"].SourceNodes, nodes["rubber"].SourceNodes)
+			}
+			if tree.TargetResourceSourceNode != nodes["plastic"].NodeId {
+				t.Fatalf("expected target to be produced by node %d, got %d", nodes["plastic"].NodeId, tree.TargetResourceSourceNode)
+			}
+			if len(tree.ExcessResources) != 0 {
+				t.Fatalf("expected no excess resources, got %d", len(tree.ExcessResources))
+			}
+		})
+	}
+}
+
+func TestComputeSteadyStateOfLoopWithoutPositiveSteadyState(t *testing.T) {
+	// rubber machine returns less rubber than plastic machine needs to produce the plastic consumed by it
+	_, err := computeSteadyStateProductionTree(loopRecipeGraph(10), []*ProductionTarget{{Resource: "plastic", Rate: 1}}, CalculationOptions{})
+	if err == nil || !strings.Contains(err.Error(), "production loop 'plastic' -> 'rubber' -> 'plastic' has no positive steady state") {
+		t.Fatalf("expected error about loop without positive steady state, got %v", err)
+	}
+}
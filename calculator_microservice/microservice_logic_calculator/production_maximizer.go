@@ -0,0 +1,104 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// CalculateMaximal returns the production tree producing as much of desired resource as possible when
+// supplied resources are available only at provided rates per second. Recipes and machines are chosen
+// the same way as in Calculate, supplied resources are not produced by the tree.
+func CalculateMaximal(ctx context.Context, userId int, desiredResourceName string, supplies map[string]float32, options CalculationOptions, graphs *RecipeGraphCache) (*ProductionTree, error) {
+	graph, err := graphs.graph(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("could not load recipes: %w", err)
+	}
+	calculationResult, err := computeMaximalProductionTree(graph, desiredResourceName, supplies, options)
+	if err != nil {
+		return nil, err
+	}
+	return calculationResult, nil
+}
+
+func computeMaximalProductionTree(graph *recipeGraph, desiredResourceName string, supplies map[string]float32, options CalculationOptions) (*ProductionTree, error) {
+	availableSupplies := make(map[string]float64)
+	for resourceName, rate := range supplies {
+		if resourceName != desiredResourceName {
+			availableSupplies[resourceName] = float64(rate)
+		}
+	}
+	selection, err := graph.selectRecipes(map[string]float64{desiredResourceName: 0}, availableSupplies, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// the last variable is the production rate of desired resource, maximized by minimizing its negation
+	variables := len(selection.Candidates) + 1
+	objective := make([]float64, variables)
+	objective[variables-1] = -1
+	constraints := balanceConstraints(selection.Candidates, map[string]float64{desiredResourceName: 0}, availableSupplies, options)
+	for i := range constraints {
+		constraints[i].Coefficients = append(constraints[i].Coefficients, 0)
+	}
+	for i, resourceName := range candidatesResources(selection.Candidates, map[string]float64{desiredResourceName: 0}) {
+		if resourceName == desiredResourceName {
+			constraints[i].Coefficients[variables-1] = -1
+		}
+	}
+	solution, err := solveLinearProgram(objective, constraints)
+	if errors.Is(err, errLinearProgramUnbounded) {
+		return nil, fmt.Errorf("production rate of '%s' is not limited by provided supplies", desiredResourceName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not compute maximal production rate of '%s': %w", desiredResourceName, err)
+	}
+	maximalRate := solution[variables-1]
+	if maximalRate <= minimalMachineNumber {
+		return nil, fmt.Errorf("'%s' cannot be produced from provided supplies", desiredResourceName)
+	}
+
+	// among chains producing the maximal rate, the one with the lowest cost according to objective is returned
+	demands := map[string]float64{desiredResourceName: maximalRate}
+	machines, err := solveSelection(selection, demands, availableSupplies, options)
+	if err != nil {
+		return nil, fmt.Errorf("could not compute production chain for '%s': %w", desiredResourceName, err)
+	}
+	calculationResult := buildNetworkProductionTree(selection.Candidates, machines, demands)
+	calculationResult.setTargets([]*ProductionTarget{{Resource: desiredResourceName, Rate: float32(maximalRate)}})
+	calculationResult.SuppliedResourcesPerSecond = suppliedResourcesUsage(selection.Candidates, machines, availableSupplies)
+	calculationResult.finish(graph, options)
+	if options.Explain {
+		calculationResult.explain(graph, options, graph.chooser(options, availableSupplies))
+	}
+	return calculationResult, nil
+}
+
+// suppliedResourcesUsage returns how much of every supplied resource is consumed by the production chain.
+func suppliedResourcesUsage(candidates []recipeCandidate, machines []float64, supplies map[string]float64) map[string]float32 {
+	usage := make(map[string]float32)
+	for resourceName := range supplies {
+		consumed := 0.0
+		for i, candidate := range candidates {
+			consumed -= candidate.netRate(resourceName) * machines[i]
+		}
+		usage[resourceName] = float32(max(consumed, 0))
+	}
+	return usage
+}
@@ -0,0 +1,97 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComputeMaximalProductionTree(t *testing.T) {
+	tests := []struct {
+		name             string
+		resource         string
+		supplies         map[string]float32
+		expectedRate     float64
+		expectedMachines map[string]uint64
+		expectedUsage    map[string]float64
+		expectedErr      string
+	}{
+		{
+			name:             "raw resource supply",
+			resource:         "iron_plate",
+			supplies:         map[string]float32{"iron_ore": 2},
+			expectedRate:     4.0 / 3,
+			expectedMachines: map[string]uint64{"iron_plate": 4, "iron_ingot": 4},
+			expectedUsage:    map[string]float64{"iron_ore": 2},
+		},
+		{
+			name:             "only one of supplies limits production",
+			resource:         "reinforced_iron_plate",
+			supplies:         map[string]float32{"iron_plate": 1.5, "iron_ingot": 10},
+			expectedRate:     0.25,
+			expectedMachines: map[string]uint64{"reinforced_iron_plate": 3, "screw": 5, "iron_rod": 3},
+			expectedUsage:    map[string]float64{"iron_plate": 1.5, "iron_ingot": 0.75},
+		},
+		{
+			name:        "resource produced without supplies",
+			resource:    "iron_plate",
+			supplies:    map[string]float32{"copper_ore": 1},
+			expectedErr: "production rate of 'iron_plate' is not limited by provided supplies",
+		},
+		{
+			name:        "empty supply",
+			resource:    "iron_plate",
+			supplies:    map[string]float32{"iron_ingot": 0},
+			expectedErr: "'iron_plate' cannot be produced from provided supplies",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tree, err := computeMaximalProductionTree(testRecipeGraph(), test.resource, test.supplies, CalculationOptions{})
+			if test.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectedErr) {
+					t.Fatalf("expected error '%s', got %v", test.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(float64(tree.TargetResourceRate)-test.expectedRate) > 1e-5 {
+				t.Fatalf("expected rate %g, got %g", test.expectedRate, tree.TargetResourceRate)
+			}
+			machineCounts := make(map[string]uint64)
+			for _, node := range tree.TreeNodes {
+				machineCounts[node.RecipeName] += node.MachineCount
+			}
+			if !reflect.DeepEqual(machineCounts, test.expectedMachines) {
+				t.Fatalf("expected machines %v, got %v", test.expectedMachines, machineCounts)
+			}
+			if len(tree.SuppliedResourcesPerSecond) != len(test.expectedUsage) {
+				t.Fatalf("expected usage of supplies %v, got %v", test.expectedUsage, tree.SuppliedResourcesPerSecond)
+			}
+			for resourceName, usage := range test.expectedUsage {
+				if math.Abs(float64(tree.SuppliedResourcesPerSecond[resourceName])-usage) > 1e-5 {
+					t.Fatalf("expected usage of supplies %v, got %v", test.expectedUsage, tree.SuppliedResourcesPerSecond)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"math"
+	"slices"
+)
+
+const (
+	ObjectiveRate     = "rate"
+	ObjectiveMachines = "machines"
+	ObjectivePower    = "power"
+	ObjectiveRaw      = "raw"
+)
+
+// tieBreakingCost is added to the cost of every machine, so that objectives ignoring some machines
+// (e.g. power for machines without power consumption) do not allow building them without a reason
+const tieBreakingCost = 1e-6
+
+// CalculationOptions holds choices affecting which recipes and machines are used in production tree.
+type CalculationOptions struct {
+	RecipesNames  []string
+	MachinesNames []string
+	// Objective is one of ObjectiveRate, ObjectiveMachines, ObjectivePower or ObjectiveRaw, empty means ObjectiveRate
+	Objective string
+	// PowerTargetKw is power that has to be provided by generators of production tree on top of covered consumption
+	PowerTargetKw float64
+	// CoverPowerConsumption requires generators to cover power consumed by all machines of production tree,
+	// including machines producing fuel for the generators
+	CoverPowerConsumption bool
+	// TransportTiers are names of transport tiers allowed to carry resources between nodes, empty means all tiers
+	TransportTiers []string
+	// SplitNodes splits nodes into identical copies, so that no edge of production tree needs more than one lane
+	SplitNodes bool
+	// SinkExcess routes excess resources into sinks and disposal recipes instead of leaving them as byproducts
+	SinkExcess bool
+	// BuildCost totals resources needed to construct whole machines of production tree in its summary
+	BuildCost bool
+	// Explain attaches to every node recipe and machine pairs considered for it and rules that eliminated them
+	Explain bool
+}
+
+// IsObjectiveSupported checks if objective is one of supported objectives.
+func IsObjectiveSupported(objective string) bool {
+	return slices.Contains([]string{"", ObjectiveRate, ObjectiveMachines, ObjectivePower, ObjectiveRaw}, objective)
+}
+
+// cost returns the cost of running a single machine of candidate at full speed. Supplied resources are counted
+// as raw resources.
+func (o CalculationOptions) cost(candidate recipeCandidate, supplies map[string]float64) float64 {
+	switch o.Objective {
+	case ObjectivePower:
+		return float64(candidate.Machine.PowerConsumptionKw) + tieBreakingCost
+	case ObjectiveRaw:
+		cost := tieBreakingCost
+		if len(candidate.Recipe.Inputs) == 0 {
+			for resourceName := range candidate.Recipe.Outputs {
+				cost += candidate.outputRate(resourceName)
+			}
+		}
+		for resourceName := range supplies {
+			cost += candidate.inputRate(resourceName)
+		}
+		return cost
+	}
+	return 1
+}
+
+func (o CalculationOptions) costs(candidates []recipeCandidate, supplies map[string]float64) []float64 {
+	costs := make([]float64, len(candidates))
+	for i, candidate := range candidates {
+		costs[i] = o.cost(candidate, supplies)
+	}
+	return costs
+}
+
+// recipeChooser picks recipe and machine for every resource separately, according to calculation options.
+type recipeChooser struct {
+	graph      *recipeGraph
+	options    CalculationOptions
+	producers  map[string][]recipeCandidate
+	generators []recipeCandidate
+	unitCosts  map[string]float64
+}
+
+// chooser prepares recipeChooser. For objectives other than rate, cost of producing one unit of every resource
+// is computed for the whole production chain, with the whole cost of a recipe assigned to each of its outputs.
+func (g *recipeGraph) chooser(options CalculationOptions, supplies map[string]float64) *recipeChooser {
+	chooser := recipeChooser{graph: g, options: options, producers: make(map[string][]recipeCandidate), unitCosts: make(map[string]float64)}
+	candidates := g.candidates(options)
+	for _, candidate := range candidates {
+		for resourceName := range candidate.Recipe.Outputs {
+			chooser.producers[resourceName] = append(chooser.producers[resourceName], candidate)
+		}
+		if candidate.Machine.PowerGenerationKw > 0 {
+			chooser.generators = append(chooser.generators, candidate)
+		}
+	}
+	if options.Objective == "" || options.Objective == ObjectiveRate {
+		return &chooser
+	}
+	for resourceName := range supplies {
+		chooser.unitCosts[resourceName] = 0
+		if options.Objective == ObjectiveRaw {
+			chooser.unitCosts[resourceName] = 1
+		}
+	}
+	// costs only decrease, loops with positive output make them converge geometrically, hence the iteration limit
+	for iteration := 0; iteration < 1000; iteration++ {
+		changed := false
+		for _, candidate := range candidates {
+			for resourceName := range candidate.Recipe.Outputs {
+				unitCost := chooser.unitCost(candidate, resourceName)
+				if currentCost, exists := chooser.unitCosts[resourceName]; !exists || unitCost < currentCost*(1-simplexEpsilon) {
+					if !math.IsInf(unitCost, 1) {
+						chooser.unitCosts[resourceName] = unitCost
+						changed = true
+					}
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return &chooser
+}
+
+// unitCost returns the cost of producing one unit of resource with candidate, including costs of its inputs.
+func (c *recipeChooser) unitCost(candidate recipeCandidate, resourceName string) float64 {
+	cost := c.options.cost(candidate, nil)
+	for inputName := range candidate.Recipe.Inputs {
+		inputCost, exists := c.unitCosts[inputName]
+		if !exists {
+			return math.Inf(1)
+		}
+		cost += candidate.inputRate(inputName) * inputCost
+	}
+	return cost / candidate.outputRate(resourceName)
+}
+
+// best chooses recipe and machine for resource. For rate objective allowed alternative recipes take precedence
+// over default ones, then the highest production rate wins. For other objectives the lowest unit cost wins, with
+// ties resolved the same way as for rate objective.
+func (c *recipeChooser) best(resourceName string) (recipeCandidate, bool) {
+	var best recipeCandidate
+	bestCost := math.Inf(1)
+	found := false
+	for _, candidate := range c.producers[resourceName] {
+		cost := 0.0
+		if c.options.Objective != "" && c.options.Objective != ObjectiveRate {
+			cost = c.unitCost(candidate, resourceName)
+		}
+		better := !found || cost < bestCost*(1-simplexEpsilon)
+		if found && !better && cost <= bestCost*(1+simplexEpsilon) {
+			better = (best.Recipe.DefaultChoice && !candidate.Recipe.DefaultChoice) ||
+				(best.Recipe.DefaultChoice == candidate.Recipe.DefaultChoice && candidate.outputRate(resourceName) > best.outputRate(resourceName))
+		}
+		if better {
+			best = candidate
+			bestCost = cost
+			found = true
+		}
+	}
+	return best, found
+}
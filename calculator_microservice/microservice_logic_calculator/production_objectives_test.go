@@ -0,0 +1,103 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import "testing"
+
+// objectivesRecipeGraph returns two default recipes of screws: "screw" is cheap in power and iron ore, while
+// "alloy_screw" produces more screws per machine.
+func objectivesRecipeGraph() *recipeGraph {
+	graph := &recipeGraph{Resources: map[string]*graphResource{}, Producers: map[string][]*graphRecipe{}}
+	miner := &graphMachine{Id: 1, Name: "miner", OutputsSolid: 1, Speed: 1, PowerConsumptionKw: 5000, DefaultChoice: true}
+	constructor := &graphMachine{Id: 2, Name: "constructor", InputsSolid: 1, OutputsSolid: 1, Speed: 1, PowerConsumptionKw: 4000, DefaultChoice: true}
+	assembler := &graphMachine{Id: 3, Name: "assembler", InputsSolid: 2, OutputsSolid: 1, Speed: 1, PowerConsumptionKw: 15000, DefaultChoice: true}
+	addRecipe := func(id uint, name string, inputs map[string]float64, outputs map[string]float64, machine *graphMachine) {
+		recipe := &graphRecipe{Id: id, Name: name, ProductionTimeS: 60, DefaultChoice: true, Inputs: inputs, Outputs: outputs, Machines: []*graphMachine{machine}}
+		graph.Recipes = append(graph.Recipes, recipe)
+		for resource := range outputs {
+			graph.Producers[resource] = append(graph.Producers[resource], recipe)
+			graph.Resources[resource] = &graphResource{Name: resource}
+		}
+	}
+	addRecipe(1, "iron_ore", map[string]float64{}, map[string]float64{"iron_ore": 60}, miner)
+	addRecipe(2, "screw", map[string]float64{"iron_ore": 10}, map[string]float64{"screw": 40}, constructor)
+	addRecipe(3, "alloy_screw", map[string]float64{"iron_ore": 30}, map[string]float64{"screw": 60}, assembler)
+	return graph
+}
+
+func TestObjectives(t *testing.T) {
+	tests := []struct {
+		objective string
+		expected  string
+	}{
+		// alloy_screw produces 60 screws per minute, screw only 40
+		{objective: "", expected: "alloy_screw"},
+		{objective: ObjectiveRate, expected: "alloy_screw"},
+		// one screw per second needs 1.5 machines with alloy_screw and 1.75 machines with screw, miners included
+		{objective: ObjectiveMachines, expected: "alloy_screw"},
+		// one screw per second needs 17500 kW with alloy_screw and 7250 kW with screw
+		{objective: ObjectivePower, expected: "screw"},
+		// one screw per second needs 0.5 iron ore per second with alloy_screw and 0.25 with screw
+		{objective: ObjectiveRaw, expected: "screw"},
+	}
+	calculations := map[string]func(graph *recipeGraph, options CalculationOptions) (*ProductionTree, error){
+		"greedy": func(graph *recipeGraph, options CalculationOptions) (*ProductionTree, error) {
+			return computeGreedyProductionTree(graph, "screw", 1, options)
+		},
+		"optimal": func(graph *recipeGraph, options CalculationOptions) (*ProductionTree, error) {
+			return computeOptimalProductionTree(graph, []*ProductionTarget{{Resource: "screw", Rate: 1}}, options)
+		},
+	}
+	for _, test := range tests {
+		for calculationName, calculate := range calculations {
+			t.Run(calculationName+" "+test.objective, func(t *testing.T) {
+				tree, err := calculate(objectivesRecipeGraph(), CalculationOptions{Objective: test.objective})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				producers := []string{}
+				for _, node := range tree.TreeNodes {
+					if node.ProducedResourcesPerSecond["screw"] > 0 {
+						producers = append(producers, node.RecipeName)
+					}
+				}
+				if len(producers) != 1 || producers[0] != test.expected {
+					t.Fatalf("expected screws to be produced by recipe '%s', got %v", test.expected, producers)
+				}
+			})
+		}
+	}
+}
+
+func TestIsObjectiveSupported(t *testing.T) {
+	tests := []struct {
+		objective string
+		expected  bool
+	}{
+		{objective: "", expected: true},
+		{objective: ObjectiveRate, expected: true},
+		{objective: ObjectiveMachines, expected: true},
+		{objective: ObjectivePower, expected: true},
+		{objective: ObjectiveRaw, expected: true},
+		{objective: "profit", expected: false},
+	}
+	for _, test := range tests {
+		if supported := IsObjectiveSupported(test.objective); supported != test.expected {
+			t.Errorf("expected support of objective '%s' to be %t, got %t", test.objective, test.expected, supported)
+		}
+	}
+}
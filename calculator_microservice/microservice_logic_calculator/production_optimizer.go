@@ -0,0 +1,275 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+const minimalMachineNumber = 1e-7
+
+// CalculateOptimal formulates the whole recipe graph as a linear program and returns the production tree
+// which produces desired resource with the lowest cost according to objective of options, with the total number
+// of machines minimized for ObjectiveRate and ObjectiveMachines. Unlike Calculate, it can split demand for
+// a resource across several recipes and consume byproducts of one recipe in another.
+func CalculateOptimal(ctx context.Context, userId int, desiredResourceName string, desiredRate float32, options CalculationOptions, graphs *RecipeGraphCache) (*ProductionTree, error) {
+	return CalculateMultipleOptimal(ctx, userId, []*ProductionTarget{{Resource: desiredResourceName, Rate: desiredRate}}, options, graphs)
+}
+
+// CalculateMultipleOptimal works like CalculateOptimal, but produces all targets in one production tree.
+func CalculateMultipleOptimal(ctx context.Context, userId int, targets []*ProductionTarget, options CalculationOptions, graphs *RecipeGraphCache) (*ProductionTree, error) {
+	graph, err := graphs.graph(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("could not load recipes: %w", err)
+	}
+	calculationResult, err := computeOptimalProductionTree(graph, mergeTargets(targets), options)
+	if err != nil {
+		return nil, err
+	}
+	return calculationResult, nil
+}
+
+func computeOptimalProductionTree(graph *recipeGraph, targets []*ProductionTarget, options CalculationOptions) (*ProductionTree, error) {
+	demands := targetsDemands(targets)
+	candidates := relevantCandidates(graph.candidates(options), demands, options)
+	for _, target := range targets {
+		if !slices.ContainsFunc(candidates, func(candidate recipeCandidate) bool { return candidate.Recipe.Outputs[target.Resource] > 0 }) {
+			return nil, graph.noRecipeError(target.Resource, options)
+		}
+	}
+	machines, err := solveLinearProgram(options.costs(candidates, nil), balanceConstraints(candidates, demands, nil, options))
+	if errors.Is(err, errLinearProgramInfeasible) {
+		if options.requiresPower() {
+			return nil, fmt.Errorf("%s and required power cannot be produced using allowed recipes and machines", describeTargets(targets))
+		}
+		return nil, fmt.Errorf("%s cannot be produced using allowed recipes and machines", describeTargets(targets))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not compute optimal production chain for %s: %w", describeTargets(targets), err)
+	}
+	calculationResult := buildNetworkProductionTree(candidates, machines, demands)
+	calculationResult.setTargets(targets)
+	calculationResult.finish(graph, options)
+	if options.Explain {
+		calculationResult.explain(graph, options, nil)
+	}
+	return calculationResult, nil
+}
+
+// relevantCandidates drops candidates that cannot contribute to producing demanded resources
+// or, when power is required, to generating power.
+func relevantCandidates(candidates []recipeCandidate, demands map[string]float64, options CalculationOptions) []recipeCandidate {
+	neededResources := make(map[string]bool)
+	queue := []string{}
+	for resourceName := range demands {
+		neededResources[resourceName] = true
+		queue = append(queue, resourceName)
+	}
+	used := make([]bool, len(candidates))
+	for i, candidate := range candidates {
+		if !options.requiresPower() || candidate.Machine.PowerGenerationKw == 0 {
+			continue
+		}
+		used[i] = true
+		for inputName := range candidate.Recipe.Inputs {
+			if !neededResources[inputName] {
+				neededResources[inputName] = true
+				queue = append(queue, inputName)
+			}
+		}
+	}
+	for len(queue) > 0 {
+		resourceName := queue[0]
+		queue = queue[1:]
+		for i, candidate := range candidates {
+			if used[i] || candidate.Recipe.Outputs[resourceName] <= 0 {
+				continue
+			}
+			used[i] = true
+			for inputName := range candidate.Recipe.Inputs {
+				if !neededResources[inputName] {
+					neededResources[inputName] = true
+					queue = append(queue, inputName)
+				}
+			}
+		}
+	}
+	result := []recipeCandidate{}
+	for i, candidate := range candidates {
+		if used[i] {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+// balanceConstraints requires every resource used by candidates to be produced at least as fast as it is consumed,
+// with demanded resources additionally covering their demand. Supplied resources may be consumed up to their supply.
+// Constraints for resources are ordered as candidatesResources, followed by constraint on power if it is required.
+func balanceConstraints(candidates []recipeCandidate, demands map[string]float64, supplies map[string]float64, options CalculationOptions) []linearConstraint {
+	constraints := []linearConstraint{}
+	for _, resourceName := range candidatesResources(candidates, demands) {
+		constraint := linearConstraint{Coefficients: make([]float64, len(candidates)), Kind: greaterOrEqual, Bound: demands[resourceName] - supplies[resourceName]}
+		for i, candidate := range candidates {
+			constraint.Coefficients[i] = candidate.netRate(resourceName)
+		}
+		constraints = append(constraints, constraint)
+	}
+	return append(constraints, powerConstraints(candidates, options)...)
+}
+
+func candidatesResources(candidates []recipeCandidate, demands map[string]float64) []string {
+	resources := []string{}
+	for resourceName := range demands {
+		resources = append(resources, resourceName)
+	}
+	for _, candidate := range candidates {
+		for resourceName := range candidate.Recipe.Inputs {
+			resources = append(resources, resourceName)
+		}
+		for resourceName := range candidate.Recipe.Outputs {
+			resources = append(resources, resourceName)
+		}
+	}
+	sort.Strings(resources)
+	return slices.Compact(resources)
+}
+
+// buildNetworkProductionTree creates one node for each candidate with non zero number of machines.
+// Nodes are ordered breadth first starting from producers of demanded resources.
+func buildNetworkProductionTree(candidates []recipeCandidate, machines []float64, demands map[string]float64) *ProductionTree {
+	calculationResult := ProductionTree{TreeNodes: make([]*ProductionTreeNode, 0), ExcessResources: make([]*ResourceSource, 0)}
+	active := []int{}
+	for i := range candidates {
+		if machines[i] > minimalMachineNumber {
+			active = append(active, i)
+		}
+	}
+	producers := func(resourceName string) []int {
+		result := []int{}
+		for _, i := range active {
+			if candidates[i].Recipe.Outputs[resourceName] > 0 {
+				result = append(result, i)
+			}
+		}
+		return result
+	}
+
+	order := []int{}
+	visited := make(map[int]bool)
+	queue := []int{}
+	demandedResources := []string{}
+	for resourceName := range demands {
+		demandedResources = append(demandedResources, resourceName)
+	}
+	sort.Strings(demandedResources)
+	for _, resourceName := range demandedResources {
+		queue = append(queue, producers(resourceName)...)
+	}
+	for _, i := range active {
+		if candidates[i].Machine.PowerGenerationKw > 0 {
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		if visited[i] {
+			continue
+		}
+		visited[i] = true
+		order = append(order, i)
+		inputs := []string{}
+		for resourceName := range candidates[i].Recipe.Inputs {
+			inputs = append(inputs, resourceName)
+		}
+		sort.Strings(inputs)
+		for _, resourceName := range inputs {
+			queue = append(queue, producers(resourceName)...)
+		}
+	}
+	for _, i := range active {
+		if !visited[i] {
+			order = append(order, i)
+		}
+	}
+
+	nodeIds := make(map[int]int)
+	for nodeId, i := range order {
+		nodeIds[i] = nodeId
+		candidate := candidates[i]
+		newNode := ProductionTreeNode{
+			NodeId:                     nodeId,
+			RecipeName:                 candidate.Recipe.Name,
+			MachineName:                candidate.Machine.Name,
+			RequiredResourcesPerSecond: make(map[string]float32),
+			ProducedResourcesPerSecond: make(map[string]float32),
+		}
+		newNode.setMachines(machines[i], candidate.Machine)
+		for resourceName := range candidate.Recipe.Inputs {
+			newNode.RequiredResourcesPerSecond[resourceName] = float32(candidate.inputRate(resourceName) * machines[i])
+		}
+		for resourceName := range candidate.Recipe.Outputs {
+			newNode.ProducedResourcesPerSecond[resourceName] = float32(candidate.outputRate(resourceName) * machines[i])
+		}
+		calculationResult.TreeNodes = append(calculationResult.TreeNodes, &newNode)
+	}
+	for _, node := range calculationResult.TreeNodes {
+		for resourceName := range node.RequiredResourcesPerSecond {
+			for _, i := range producers(resourceName) {
+				node.SourceNodes = append(node.SourceNodes, nodeIds[i])
+			}
+		}
+		sort.Ints(node.SourceNodes)
+		node.SourceNodes = slices.Compact(node.SourceNodes)
+	}
+
+	for _, resourceName := range candidatesResources(candidates, demands) {
+		produced := 0.0
+		consumed := demands[resourceName]
+		for _, i := range active {
+			produced += candidates[i].outputRate(resourceName) * machines[i]
+			consumed += candidates[i].inputRate(resourceName) * machines[i]
+		}
+		surplus := produced - consumed
+		if surplus <= minimalMachineNumber*produced || surplus <= minimalMachineNumber {
+			continue
+		}
+		for _, i := range producers(resourceName) {
+			share := candidates[i].outputRate(resourceName) * machines[i] / produced
+			calculationResult.ExcessResources = append(calculationResult.ExcessResources, &ResourceSource{NodeId: nodeIds[i], ExcessResourceName: resourceName, ExcessProducedResourcePerSecond: float32(surplus * share)})
+		}
+	}
+	return &calculationResult
+}
+
+// mainProducer returns id of the node producing the most of resource, or -1 if no node produces it.
+func (t *ProductionTree) mainProducer(resourceName string) int {
+	nodeId := -1
+	var bestRate float32
+	for _, node := range t.TreeNodes {
+		if rate := node.ProducedResourcesPerSecond[resourceName]; rate > bestRate {
+			bestRate = rate
+			nodeId = node.NodeId
+		}
+	}
+	return nodeId
+}
@@ -0,0 +1,86 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import "math"
+
+// requiresPower checks if generators have to be added to production tree.
+func (o CalculationOptions) requiresPower() bool {
+	return o.PowerTargetKw > 0 || o.CoverPowerConsumption
+}
+
+// powerRate returns power generated minus power consumed by a single machine of candidate running at full speed.
+// Consumed power is only counted when it has to be covered by generators.
+func (o CalculationOptions) powerRate(candidate recipeCandidate) float64 {
+	rate := float64(candidate.Machine.PowerGenerationKw)
+	if o.CoverPowerConsumption {
+		rate -= float64(candidate.Machine.PowerConsumptionKw)
+	}
+	return rate
+}
+
+// powerConstraints requires candidates to generate at least the power target, together with consumed power
+// if it has to be covered. No constraint is returned when generators are not required.
+func powerConstraints(candidates []recipeCandidate, options CalculationOptions) []linearConstraint {
+	if !options.requiresPower() {
+		return []linearConstraint{}
+	}
+	constraint := linearConstraint{Coefficients: make([]float64, len(candidates)), Kind: greaterOrEqual, Bound: options.PowerTargetKw}
+	for i, candidate := range candidates {
+		constraint.Coefficients[i] = options.powerRate(candidate)
+	}
+	return []linearConstraint{constraint}
+}
+
+// bestGenerator chooses recipe and machine generating power. For rate objective allowed alternative recipes take
+// precedence over default ones, then the highest power generation wins. For other objectives the lowest cost of
+// generating one kW, including cost of the fuel chain, wins.
+func (c *recipeChooser) bestGenerator() (recipeCandidate, bool) {
+	var best recipeCandidate
+	bestCost := math.Inf(1)
+	found := false
+	for _, candidate := range c.generators {
+		cost := 0.0
+		if c.options.Objective != "" && c.options.Objective != ObjectiveRate {
+			cost = c.generationCost(candidate)
+		}
+		better := !found || cost < bestCost*(1-simplexEpsilon)
+		if found && !better && cost <= bestCost*(1+simplexEpsilon) {
+			better = (best.Recipe.DefaultChoice && !candidate.Recipe.DefaultChoice) ||
+				(best.Recipe.DefaultChoice == candidate.Recipe.DefaultChoice && candidate.Machine.PowerGenerationKw > best.Machine.PowerGenerationKw)
+		}
+		if better {
+			best = candidate
+			bestCost = cost
+			found = true
+		}
+	}
+	return best, found
+}
+
+// generationCost returns the cost of generating one kW with candidate, including cost of its fuel chain.
+func (c *recipeChooser) generationCost(candidate recipeCandidate) float64 {
+	cost := c.options.cost(candidate, nil)
+	for inputName := range candidate.Recipe.Inputs {
+		inputCost, exists := c.unitCosts[inputName]
+		if !exists {
+			return math.Inf(1)
+		}
+		cost += candidate.inputRate(inputName) * inputCost
+	}
+	return cost / float64(candidate.Machine.PowerGenerationKw)
+}
@@ -0,0 +1,103 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// powerRecipeGraph returns test recipe graph extended with coal generators burning 15 coal per minute.
+func powerRecipeGraph() *recipeGraph {
+	graph := testRecipeGraph()
+	miner := graph.machine("miner")
+	generator := &graphMachine{Id: 5, Name: "coal_generator", InputsSolid: 1, Speed: 1, PowerGenerationKw: 75000, DefaultChoice: true}
+	coal := &graphRecipe{Id: 8, Name: "coal", ProductionTimeS: 60, DefaultChoice: true, Inputs: map[string]float64{}, Outputs: map[string]float64{"coal": 60}, Machines: []*graphMachine{miner}}
+	coalPower := &graphRecipe{Id: 9, Name: "coal_power", ProductionTimeS: 60, DefaultChoice: true, Inputs: map[string]float64{"coal": 15}, Outputs: map[string]float64{}, Machines: []*graphMachine{generator}}
+	graph.Recipes = append(graph.Recipes, coal, coalPower)
+	graph.Producers["coal"] = []*graphRecipe{coal}
+	graph.Resources["coal"] = &graphResource{Name: "coal"}
+	return graph
+}
+
+func TestGeneratorNodes(t *testing.T) {
+	tests := []struct {
+		name              string
+		options           CalculationOptions
+		expectedNumber    float64
+		expectedGenerated uint64
+	}{
+		{
+			name:              "power target",
+			options:           CalculationOptions{PowerTargetKw: 150000},
+			expectedNumber:    2,
+			expectedGenerated: 150000,
+		},
+		{
+			// one smelter and half of miner of iron ore consume 6500 kW, miners of coal consume 5000 kW
+			// per 4 generators
+			name:              "covered power consumption",
+			options:           CalculationOptions{CoverPowerConsumption: true},
+			expectedNumber:    6500.0 / 73750,
+			expectedGenerated: 6610,
+		},
+	}
+	calculations := map[string]func(graph *recipeGraph, options CalculationOptions) (*ProductionTree, error){
+		"greedy": func(graph *recipeGraph, options CalculationOptions) (*ProductionTree, error) {
+			return computeGreedyProductionTree(graph, "iron_ingot", 0.5, options)
+		},
+		"optimal": func(graph *recipeGraph, options CalculationOptions) (*ProductionTree, error) {
+			return computeOptimalProductionTree(graph, []*ProductionTarget{{Resource: "iron_ingot", Rate: 0.5}}, options)
+		},
+	}
+	for _, test := range tests {
+		for calculationName, calculate := range calculations {
+			t.Run(calculationName+" "+test.name, func(t *testing.T) {
+				tree, err := calculate(powerRecipeGraph(), test.options)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				nodes := make(map[string]*ProductionTreeNode)
+				for _, node := range tree.TreeNodes {
+					nodes[node.RecipeName] = node
+				}
+				generatorNode := nodes["coal_power"]
+				if generatorNode == nil {
+					t.Fatalf("expected generator node, got %d nodes", len(tree.TreeNodes))
+				}
+				if math.Abs(float64(generatorNode.MachineNumber)-test.expectedNumber) > 1e-5 || generatorNode.PowerGeneratedkW != test.expectedGenerated {
+					t.Fatalf("expected %g generators generating %d kW, got %g generating %d kW", test.expectedNumber, test.expectedGenerated, generatorNode.MachineNumber, generatorNode.PowerGeneratedkW)
+				}
+				// every generator burns a quarter of coal per second, mined by a quarter of miner
+				if coalNode := nodes["coal"]; coalNode == nil || math.Abs(float64(coalNode.MachineNumber)-test.expectedNumber/4) > 1e-5 {
+					t.Fatalf("expected %g miners of coal, got %v", test.expectedNumber/4, coalNode)
+				}
+				if tree.Summary.TotalPowerGeneratedkW != test.expectedGenerated {
+					t.Fatalf("expected summary to report %d kW generated, got %d", test.expectedGenerated, tree.Summary.TotalPowerGeneratedkW)
+				}
+			})
+		}
+	}
+}
+
+func TestGeneratorNodesWithoutGenerator(t *testing.T) {
+	_, err := computeGreedyProductionTree(testRecipeGraph(), "iron_ingot", 0.5, CalculationOptions{PowerTargetKw: 1000})
+	if err == nil || !strings.Contains(err.Error(), "could not find recipe generating power") {
+		t.Fatalf("expected error about missing generator, got %v", err)
+	}
+}
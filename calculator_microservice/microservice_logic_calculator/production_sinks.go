@@ -0,0 +1,65 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+// sinkExcess routes every excess resource of production tree out of the tree. Resources with sink value are put
+// into sink nodes, which have no recipe and no machine and earn points for every unit sunk. Other resources are
+// consumed by the disposal recipe allowed by options with the highest consumption rate, a disposal recipe has
+// the resource as its only input and no outputs. Resources which can be neither sunk nor disposed of remain excess.
+// Every excess resource gets its own node, so that it is supplied only by the node producing the excess.
+func (t *ProductionTree) sinkExcess(graph *recipeGraph, options CalculationOptions) {
+	excessResources := []*ResourceSource{}
+	for _, excessResource := range t.ExcessResources {
+		resourceName := excessResource.ExcessResourceName
+		rate := excessResource.ExcessProducedResourcePerSecond
+		node := ProductionTreeNode{
+			NodeId:                     len(t.TreeNodes),
+			RequiredResourcesPerSecond: map[string]float32{resourceName: rate},
+			ProducedResourcesPerSecond: make(map[string]float32),
+			SourceNodes:                []int{excessResource.NodeId},
+			SunkResource:               resourceName,
+		}
+		if resource, exists := graph.Resources[resourceName]; exists && resource.SinkValue > 0 {
+			node.SinkPointsPerSecond = float32(float64(rate) * resource.SinkValue)
+		} else if disposal, found := graph.disposal(resourceName, options); found {
+			node.RecipeName = disposal.Recipe.Name
+			node.MachineName = disposal.Machine.Name
+			node.setMachines(float64(rate)/disposal.inputRate(resourceName), disposal.Machine)
+		} else {
+			excessResources = append(excessResources, excessResource)
+			continue
+		}
+		t.TreeNodes = append(t.TreeNodes, &node)
+	}
+	t.ExcessResources = excessResources
+}
+
+// disposal chooses recipe and machine disposing of resource, preferring the highest consumption rate.
+func (g *recipeGraph) disposal(resourceName string, options CalculationOptions) (recipeCandidate, bool) {
+	var best recipeCandidate
+	found := false
+	for _, candidate := range g.candidates(options) {
+		if len(candidate.Recipe.Outputs) != 0 || len(candidate.Recipe.Inputs) != 1 || candidate.Recipe.Inputs[resourceName] <= 0 {
+			continue
+		}
+		if !found || candidate.inputRate(resourceName) > best.inputRate(resourceName) {
+			best = candidate
+			found = true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,138 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+// sinksRecipeGraph returns test recipe graph extended with slag worth 10 sink points and with drains disposing
+// of 30 or 60 water per minute, or 120 water per minute with alternative recipe.
+func sinksRecipeGraph() *recipeGraph {
+	graph := testRecipeGraph()
+	graph.Resources["slag"] = &graphResource{Name: "slag", SinkValue: 10}
+	graph.Resources["water"] = &graphResource{Name: "water", Liquid: true}
+	graph.Resources["dust"] = &graphResource{Name: "dust"}
+	drain := &graphMachine{Id: 5, Name: "drain", InputsLiquid: 1, Speed: 1, PowerConsumptionKw: 1000, DefaultChoice: true}
+	addDrain := func(id uint, name string, defaultChoice bool, rate float64) {
+		graph.Recipes = append(graph.Recipes, &graphRecipe{Id: id, Name: name, ProductionTimeS: 60, DefaultChoice: defaultChoice, Inputs: map[string]float64{"water": rate}, Outputs: map[string]float64{}, Machines: []*graphMachine{drain}})
+	}
+	addDrain(8, "slow_drain", true, 30)
+	addDrain(9, "fast_drain", true, 60)
+	addDrain(10, "alternative_drain", false, 120)
+	return graph
+}
+
+// newSinksTestTree returns tree of one smelter node producing slag, water and dust as excess resources.
+func newSinksTestTree() *ProductionTree {
+	return &ProductionTree{
+		TreeNodes: []*ProductionTreeNode{{
+			NodeId:                     0,
+			RecipeName:                 "iron_ingot",
+			MachineName:                "smelter",
+			RequiredResourcesPerSecond: map[string]float32{},
+			ProducedResourcesPerSecond: map[string]float32{"iron_ingot": 1, "slag": 0.5, "water": 1, "dust": 0.25},
+		}},
+		Targets: []*ProductionTarget{{Resource: "iron_ingot", Rate: 1, SourceNode: 0}},
+		ExcessResources: []*ResourceSource{
+			{NodeId: 0, ExcessResourceName: "slag", ExcessProducedResourcePerSecond: 0.5},
+			{NodeId: 0, ExcessResourceName: "water", ExcessProducedResourcePerSecond: 1},
+			{NodeId: 0, ExcessResourceName: "dust", ExcessProducedResourcePerSecond: 0.25},
+		},
+	}
+}
+
+func TestSinkExcess(t *testing.T) {
+	tests := []struct {
+		name                  string
+		options               CalculationOptions
+		expectedDrain         string
+		expectedDrainMachines float32
+	}{
+		{
+			name:                  "default disposal recipes",
+			options:               CalculationOptions{SinkExcess: true},
+			expectedDrain:         "fast_drain",
+			expectedDrainMachines: 1,
+		},
+		{
+			name:                  "allowed alternative disposal recipe",
+			options:               CalculationOptions{SinkExcess: true, RecipesNames: []string{"alternative_drain"}},
+			expectedDrain:         "alternative_drain",
+			expectedDrainMachines: 0.5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tree := newSinksTestTree()
+			tree.sinkExcess(sinksRecipeGraph(), test.options)
+			if len(tree.TreeNodes) != 3 {
+				t.Fatalf("expected sink node and disposal node to be added, got %d nodes", len(tree.TreeNodes))
+			}
+
+			sink := tree.TreeNodes[1]
+			expectedSink := &ProductionTreeNode{
+				NodeId:                     1,
+				RequiredResourcesPerSecond: map[string]float32{"slag": 0.5},
+				ProducedResourcesPerSecond: map[string]float32{},
+				SourceNodes:                []int{0},
+				SunkResource:               "slag",
+				SinkPointsPerSecond:        5,
+			}
+			if !reflect.DeepEqual(sink, expectedSink) {
+				t.Fatalf("expected sink node %+v, got %+v", expectedSink, sink)
+			}
+
+			drain := tree.TreeNodes[2]
+			if drain.NodeId != 2 || drain.SunkResource != "water" || drain.RecipeName != test.expectedDrain || drain.MachineName != "drain" {
+				t.Fatalf("expected water to be disposed of by '%s' on 'drain', got %+v", test.expectedDrain, drain)
+			}
+			if drain.MachineNumber != test.expectedDrainMachines || drain.MachineCount != 1 || drain.SinkPointsPerSecond != 0 {
+				t.Fatalf("expected %f drains, got %+v", test.expectedDrainMachines, drain)
+			}
+			if !reflect.DeepEqual(drain.SourceNodes, []int{0}) || drain.RequiredResourcesPerSecond["water"] != 1 {
+				t.Fatalf("expected drain to consume 1 water per second from node 0, got %+v", drain)
+			}
+
+			if len(tree.ExcessResources) != 1 || tree.ExcessResources[0].ExcessResourceName != "dust" {
+				t.Fatalf("expected only dust to remain excess, got %d excess resources", len(tree.ExcessResources))
+			}
+		})
+	}
+}
+
+func TestSinkExcessEdges(t *testing.T) {
+	tree := newSinksTestTree()
+	tree.finish(sinksRecipeGraph(), CalculationOptions{SinkExcess: true})
+	expected := []*ProductionEdge{
+		{SourceNode: 0, TargetNode: 1, Resource: "slag", ResourcePerSecond: 0.5},
+		{SourceNode: 0, TargetNode: 2, Resource: "water", ResourcePerSecond: 1},
+		{SourceNode: 0, TargetNode: -1, Resource: "iron_ingot", ResourcePerSecond: 1},
+	}
+	if len(tree.Edges) != len(expected) {
+		t.Fatalf("expected %d edges, got %d", len(expected), len(tree.Edges))
+	}
+	for i, edge := range tree.Edges {
+		if !reflect.DeepEqual(edge, expected[i]) {
+			t.Fatalf("expected edge %d to be %+v, got %+v", i, expected[i], edge)
+		}
+	}
+	if tree.Summary.SunkResourcesPerSecond["slag"] != 0.5 || tree.Summary.SunkResourcesPerSecond["water"] != 1 || tree.Summary.SinkPointsPerSecond != 5 {
+		t.Fatalf("unexpected summary of sunk resources %+v", tree.Summary)
+	}
+}
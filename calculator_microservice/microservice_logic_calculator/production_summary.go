@@ -0,0 +1,101 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"sort"
+)
+
+type ProductionSummary struct {
+	Machines              []*MachineSummary
+	TotalPowerConsumedkW  uint64
+	TotalPowerGeneratedkW uint64
+	// PowerBalancekW is power generated minus power consumed by the tree
+	PowerBalancekW        int64
+	RawResourcesPerSecond map[string]float32
+	ByproductsPerSecond   map[string]float32
+	// SunkResourcesPerSecond are excess resources put into sinks or consumed by disposal recipes
+	SunkResourcesPerSecond map[string]float32
+	SinkPointsPerSecond    float32
+	// fields named PerUnit hold rates per RateUnit of the tree, they are only filled when rates are requested in
+	// unit other than seconds
+	RawResourcesPerUnit  map[string]float32 `json:",omitempty"`
+	ByproductsPerUnit    map[string]float32 `json:",omitempty"`
+	SunkResourcesPerUnit map[string]float32 `json:",omitempty"`
+	SinkPointsPerUnit    float32            `json:",omitempty"`
+	// BuildCost totals resources needed to construct whole machines of the tree, it is only filled on request
+	BuildCost map[string]uint64
+	// MachinesWithoutBuildCost are machines of the tree, whose build cost is not known
+	MachinesWithoutBuildCost []string
+}
+
+type MachineSummary struct {
+	MachineName          string
+	MachineCount         uint64
+	MachineNumber        float32
+	TotalPowerConsumedkW uint64
+}
+
+// setSummary aggregates nodes of production tree. Raw resources are resources consumed by the tree but not produced
+// by any of its nodes, together with resources extracted by recipes without inputs. Byproducts are aggregated
+// from excess resources. Sink nodes, having no machine, are left out of machine totals.
+func (t *ProductionTree) setSummary() {
+	summary := ProductionSummary{Machines: []*MachineSummary{}, RawResourcesPerSecond: make(map[string]float32), ByproductsPerSecond: make(map[string]float32), SunkResourcesPerSecond: make(map[string]float32)}
+	machines := make(map[string]*MachineSummary)
+	required := make(map[string]float32)
+	produced := make(map[string]float32)
+	for _, node := range t.TreeNodes {
+		if node.SunkResource != "" {
+			summary.SunkResourcesPerSecond[node.SunkResource] += node.RequiredResourcesPerSecond[node.SunkResource]
+			summary.SinkPointsPerSecond += node.SinkPointsPerSecond
+		}
+		for resourceName, rate := range node.RequiredResourcesPerSecond {
+			required[resourceName] += rate
+		}
+		if node.MachineName == "" {
+			continue
+		}
+		machineSummary, exists := machines[node.MachineName]
+		if !exists {
+			machineSummary = &MachineSummary{MachineName: node.MachineName}
+			machines[node.MachineName] = machineSummary
+			summary.Machines = append(summary.Machines, machineSummary)
+		}
+		machineSummary.MachineCount += node.MachineCount
+		machineSummary.MachineNumber += node.MachineNumber
+		machineSummary.TotalPowerConsumedkW += node.TotalPowerConsumedkW
+		summary.TotalPowerConsumedkW += node.TotalPowerConsumedkW
+		summary.TotalPowerGeneratedkW += node.PowerGeneratedkW
+		for resourceName, rate := range node.ProducedResourcesPerSecond {
+			produced[resourceName] += rate
+			if len(node.RequiredResourcesPerSecond) == 0 {
+				summary.RawResourcesPerSecond[resourceName] += rate
+			}
+		}
+	}
+	for resourceName, rate := range required {
+		if produced[resourceName] == 0 {
+			summary.RawResourcesPerSecond[resourceName] += rate
+		}
+	}
+	for _, excessResource := range t.ExcessResources {
+		summary.ByproductsPerSecond[excessResource.ExcessResourceName] += excessResource.ExcessProducedResourcePerSecond
+	}
+	summary.PowerBalancekW = int64(summary.TotalPowerGeneratedkW) - int64(summary.TotalPowerConsumedkW)
+	sort.Slice(summary.Machines, func(i, j int) bool { return summary.Machines[i].MachineName < summary.Machines[j].MachineName })
+	t.Summary = &summary
+}
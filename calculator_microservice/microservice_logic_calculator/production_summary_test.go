@@ -0,0 +1,58 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetSummary(t *testing.T) {
+	tree := ProductionTree{
+		TreeNodes: []*ProductionTreeNode{
+			{NodeId: 0, RecipeName: "iron_ore", MachineName: "miner", MachineCount: 1, MachineNumber: 1, TotalPowerConsumedkW: 5000,
+				ProducedResourcesPerSecond: map[string]float32{"iron_ore": 1}},
+			{NodeId: 1, RecipeName: "iron_ingot", MachineName: "smelter", MachineCount: 1, MachineNumber: 1, TotalPowerConsumedkW: 4000,
+				RequiredResourcesPerSecond: map[string]float32{"iron_ore": 0.5, "coal": 0.25}, ProducedResourcesPerSecond: map[string]float32{"iron_ingot": 0.5, "slag": 0.25}},
+			{NodeId: 2, RecipeName: "iron_ingot", MachineName: "smelter", MachineCount: 1, MachineNumber: 0.5, TotalPowerConsumedkW: 1500,
+				RequiredResourcesPerSecond: map[string]float32{"iron_ore": 0.25, "coal": 0.125}, ProducedResourcesPerSecond: map[string]float32{"iron_ingot": 0.25, "slag": 0.125}},
+			{NodeId: 3, RecipeName: "coal_power", MachineName: "coal_generator", MachineCount: 1, MachineNumber: 0.5, PowerGeneratedkW: 37500,
+				RequiredResourcesPerSecond: map[string]float32{"coal": 0.125}},
+			{NodeId: 4, SunkResource: "slag", SinkPointsPerSecond: 2, RequiredResourcesPerSecond: map[string]float32{"slag": 0.25}},
+		},
+		ExcessResources: []*ResourceSource{{NodeId: 2, ExcessResourceName: "slag", ExcessProducedResourcePerSecond: 0.125}},
+	}
+	tree.setSummary()
+	expected := &ProductionSummary{
+		Machines: []*MachineSummary{
+			{MachineName: "coal_generator", MachineCount: 1, MachineNumber: 0.5},
+			{MachineName: "miner", MachineCount: 1, MachineNumber: 1, TotalPowerConsumedkW: 5000},
+			{MachineName: "smelter", MachineCount: 2, MachineNumber: 1.5, TotalPowerConsumedkW: 5500},
+		},
+		TotalPowerConsumedkW:  10500,
+		TotalPowerGeneratedkW: 37500,
+		PowerBalancekW:        27000,
+		// iron ore is extracted by recipe without inputs, coal is consumed but not produced by the tree
+		RawResourcesPerSecond:  map[string]float32{"iron_ore": 1, "coal": 0.5},
+		ByproductsPerSecond:    map[string]float32{"slag": 0.125},
+		SunkResourcesPerSecond: map[string]float32{"slag": 0.25},
+		SinkPointsPerSecond:    2,
+	}
+	if !reflect.DeepEqual(tree.Summary, expected) {
+		t.Fatalf("expected summary %+v, got %+v", expected, tree.Summary)
+	}
+}
@@ -0,0 +1,114 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Sweep holds production trees of one target resource calculated for a range of rates, reduced to machine counts
+// and raw resources, so that rates at which new machines or more raw resources are needed can be found.
+type Sweep struct {
+	Resource      string
+	Steps         []*SweepStep
+	RateUnit      string
+	ResourceUnits map[string]string
+}
+
+type SweepStep struct {
+	Rate              float32
+	TotalMachineCount uint64
+	Nodes             []*SweepNode
+	// NodesWithNewMachines are indexes in Nodes of nodes needing more machines than in the previous step
+	NodesWithNewMachines  []int
+	RawResourcesPerSecond map[string]float32
+	// RawResourcesPerUnit holds raw resources per RateUnit of the sweep, it is only filled for units other than seconds
+	RawResourcesPerUnit map[string]float32 `json:",omitempty"`
+	// ExceededSupplies are raw resources consumed faster than they are supplied
+	ExceededSupplies []string
+}
+
+// SweepNode aggregates all nodes running the same recipe on the same machine.
+type SweepNode struct {
+	RecipeName    string
+	MachineName   string
+	MachineCount  uint64
+	MachineNumber float32
+}
+
+// SweepProductionTrees reduces production trees calculated for rates of resource to steps of a sweep. All trees
+// have to use the same rate unit, supplies are expressed in that unit.
+func SweepProductionTrees(resourceName string, rates []float32, trees []*ProductionTree, supplies map[string]float32) (*Sweep, error) {
+	if len(rates) != len(trees) || len(trees) == 0 {
+		return nil, fmt.Errorf("every production tree of a sweep needs exactly one rate")
+	}
+	sweep := Sweep{Resource: resourceName, Steps: []*SweepStep{}, RateUnit: trees[0].RateUnit, ResourceUnits: make(map[string]string)}
+	seconds, _ := RateUnitSeconds(sweep.RateUnit)
+	previousCounts := make(map[[2]string]uint64)
+	for i, tree := range trees {
+		if tree.Summary == nil {
+			return nil, fmt.Errorf("production tree for rate %g has no summary", rates[i])
+		}
+		if tree.RateUnit != sweep.RateUnit {
+			return nil, fmt.Errorf("production tree for rate %g uses rate unit '%s' instead of '%s'", rates[i], tree.RateUnit, sweep.RateUnit)
+		}
+		for resourceName, unit := range tree.ResourceUnits {
+			sweep.ResourceUnits[resourceName] = unit
+		}
+		step := SweepStep{Rate: rates[i], Nodes: []*SweepNode{}, NodesWithNewMachines: []int{}, RawResourcesPerSecond: tree.Summary.RawResourcesPerSecond, RawResourcesPerUnit: tree.Summary.RawResourcesPerUnit, ExceededSupplies: []string{}}
+		nodes := make(map[[2]string]*SweepNode)
+		for _, node := range tree.TreeNodes {
+			if node.MachineName == "" {
+				continue
+			}
+			key := [2]string{node.RecipeName, node.MachineName}
+			sweepNode, exists := nodes[key]
+			if !exists {
+				sweepNode = &SweepNode{RecipeName: node.RecipeName, MachineName: node.MachineName}
+				nodes[key] = sweepNode
+				step.Nodes = append(step.Nodes, sweepNode)
+			}
+			sweepNode.MachineCount += node.MachineCount
+			sweepNode.MachineNumber += node.MachineNumber
+		}
+		sort.Slice(step.Nodes, func(a, b int) bool {
+			if step.Nodes[a].RecipeName != step.Nodes[b].RecipeName {
+				return step.Nodes[a].RecipeName < step.Nodes[b].RecipeName
+			}
+			return step.Nodes[a].MachineName < step.Nodes[b].MachineName
+		})
+		counts := make(map[[2]string]uint64)
+		for nodeIndex, sweepNode := range step.Nodes {
+			key := [2]string{sweepNode.RecipeName, sweepNode.MachineName}
+			counts[key] = sweepNode.MachineCount
+			step.TotalMachineCount += sweepNode.MachineCount
+			if i > 0 && sweepNode.MachineCount > previousCounts[key] {
+				step.NodesWithNewMachines = append(step.NodesWithNewMachines, nodeIndex)
+			}
+		}
+		previousCounts = counts
+		for resourceName, supply := range supplies {
+			if step.RawResourcesPerSecond[resourceName]*seconds > supply*(1+machineNumberTolerance) {
+				step.ExceededSupplies = append(step.ExceededSupplies, resourceName)
+			}
+		}
+		sort.Strings(step.ExceededSupplies)
+		sweep.Steps = append(sweep.Steps, &step)
+	}
+	return &sweep, nil
+}
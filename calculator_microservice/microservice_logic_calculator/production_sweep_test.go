@@ -0,0 +1,175 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sweepTestTrees returns greedy production trees of iron plates for rates per second, with rates converted
+// to rateUnit.
+func sweepTestTrees(t *testing.T, rates []float32, rateUnit string) []*ProductionTree {
+	trees := []*ProductionTree{}
+	for _, rate := range rates {
+		tree, err := computeGreedyProductionTree(testRecipeGraph(), "iron_plate", rate, CalculationOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err = tree.ConvertRates(rateUnit); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		trees = append(trees, tree)
+	}
+	return trees
+}
+
+func TestSweepProductionTrees(t *testing.T) {
+	// one constructor makes 1/3 iron plate per second from 1/2 iron ingot, one smelter makes 1/2 iron ingot and one
+	// miner extracts 1 iron ore per second
+	rates := []float32{0.25, 0.5, 1}
+	tests := []struct {
+		name     string
+		rateUnit string
+		supplies map[string]float32
+	}{
+		{
+			name:     "seconds",
+			rateUnit: RateUnitSecond,
+			supplies: map[string]float32{"iron_ore": 0.75, "coal": 0},
+		},
+		{
+			name:     "minutes",
+			rateUnit: RateUnitMinute,
+			supplies: map[string]float32{"iron_ore": 45, "coal": 0},
+		},
+	}
+	expectedCounts := [][]uint64{{1, 1, 1}, {2, 1, 2}, {3, 2, 3}}
+	expectedTotals := []uint64{3, 5, 8}
+	expectedNew := [][]int{{}, {0, 2}, {0, 1, 2}}
+	// iron ore is consumed at 0.375, 0.75 and 1.5 per second
+	expectedExceeded := [][]string{{}, {}, {"iron_ore"}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sweep, err := SweepProductionTrees("iron_plate", rates, sweepTestTrees(t, rates, test.rateUnit), test.supplies)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sweep.Resource != "iron_plate" || sweep.RateUnit != test.rateUnit || len(sweep.Steps) != len(rates) {
+				t.Fatalf("unexpected sweep %+v", sweep)
+			}
+			for i, step := range sweep.Steps {
+				if step.Rate != rates[i] {
+					t.Fatalf("expected step %d to have rate %f, got %f", i, rates[i], step.Rate)
+				}
+				recipes := []string{}
+				counts := []uint64{}
+				for _, node := range step.Nodes {
+					recipes = append(recipes, node.RecipeName)
+					counts = append(counts, node.MachineCount)
+				}
+				if !reflect.DeepEqual(recipes, []string{"iron_ingot", "iron_ore", "iron_plate"}) {
+					t.Fatalf("expected nodes of step %d to be sorted by recipe, got %v", i, recipes)
+				}
+				if !reflect.DeepEqual(counts, expectedCounts[i]) || step.TotalMachineCount != expectedTotals[i] {
+					t.Fatalf("expected step %d to have machine counts %v totaling %d, got %v totaling %d", i, expectedCounts[i], expectedTotals[i], counts, step.TotalMachineCount)
+				}
+				if !reflect.DeepEqual(step.NodesWithNewMachines, expectedNew[i]) {
+					t.Fatalf("expected step %d to have new machines in nodes %v, got %v", i, expectedNew[i], step.NodesWithNewMachines)
+				}
+				if !reflect.DeepEqual(step.ExceededSupplies, expectedExceeded[i]) {
+					t.Fatalf("expected step %d to exceed supplies %v, got %v", i, expectedExceeded[i], step.ExceededSupplies)
+				}
+			}
+		})
+	}
+}
+
+func TestSweepProductionTreesMergesNodes(t *testing.T) {
+	tree := &ProductionTree{
+		RateUnit: RateUnitSecond,
+		Summary:  &ProductionSummary{RawResourcesPerSecond: map[string]float32{}},
+		TreeNodes: []*ProductionTreeNode{
+			{NodeId: 0, RecipeName: "iron_ingot", MachineName: "smelter", MachineCount: 1, MachineNumber: 0.5},
+			{NodeId: 1, RecipeName: "iron_ingot", MachineName: "smelter", MachineCount: 2, MachineNumber: 1.25},
+			{NodeId: 2, SunkResource: "slag"},
+		},
+	}
+	sweep, err := SweepProductionTrees("iron_ingot", []float32{1}, []*ProductionTree{tree}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []*SweepNode{{RecipeName: "iron_ingot", MachineName: "smelter", MachineCount: 3, MachineNumber: 1.75}}
+	nodes := sweep.Steps[0].Nodes
+	if len(nodes) != len(expected) {
+		t.Fatalf("expected nodes of the same recipe and machine to be merged and sink nodes to be left out, got %d nodes", len(nodes))
+	}
+	if !reflect.DeepEqual(nodes[0], expected[0]) {
+		t.Fatalf("expected merged node %+v, got %+v", expected[0], nodes[0])
+	}
+}
+
+func TestSweepProductionTreesErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		rates    []float32
+		trees    func() []*ProductionTree
+		expected string
+	}{
+		{
+			name:     "no trees",
+			rates:    []float32{},
+			trees:    func() []*ProductionTree { return []*ProductionTree{} },
+			expected: "every production tree of a sweep needs exactly one rate",
+		},
+		{
+			name:     "missing rate",
+			rates:    []float32{1},
+			trees:    func() []*ProductionTree { return sweepTestTrees(t, []float32{1, 2}, RateUnitSecond) },
+			expected: "every production tree of a sweep needs exactly one rate",
+		},
+		{
+			name:  "missing summary",
+			rates: []float32{1, 2},
+			trees: func() []*ProductionTree {
+				trees := sweepTestTrees(t, []float32{1, 2}, RateUnitSecond)
+				trees[1].Summary = nil
+				return trees
+			},
+			expected: "production tree for rate 2 has no summary",
+		},
+		{
+			name:  "different rate units",
+			rates: []float32{1, 2},
+			trees: func() []*ProductionTree {
+				trees := sweepTestTrees(t, []float32{1, 2}, RateUnitSecond)
+				trees[1].RateUnit = RateUnitMinute
+				return trees
+			},
+			expected: "production tree for rate 2 uses rate unit 'min' instead of 's'",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := SweepProductionTrees("iron_plate", test.rates, test.trees(), nil)
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Fatalf("expected error containing %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeTargets(t *testing.T) {
+	tests := []struct {
+		name     string
+		targets  []*ProductionTarget
+		expected []*ProductionTarget
+	}{
+		{
+			name:     "distinct resources",
+			targets:  []*ProductionTarget{{Resource: "iron_plate", Rate: 1}, {Resource: "iron_rod", Rate: 0.5}},
+			expected: []*ProductionTarget{{Resource: "iron_plate", Rate: 1}, {Resource: "iron_rod", Rate: 0.5}},
+		},
+		{
+			name:     "repeated resource keeps order of first occurrence",
+			targets:  []*ProductionTarget{{Resource: "iron_rod", Rate: 0.5}, {Resource: "iron_plate", Rate: 1}, {Resource: "iron_rod", Rate: 0.25}},
+			expected: []*ProductionTarget{{Resource: "iron_rod", Rate: 0.75}, {Resource: "iron_plate", Rate: 1}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged := mergeTargets(test.targets)
+			if !reflect.DeepEqual(merged, test.expected) {
+				t.Fatalf("expected targets %v, got %v", test.expected, merged)
+			}
+		})
+	}
+}
+
+func TestComputeProductionTreeOfMultipleTargets(t *testing.T) {
+	tests := []struct {
+		name      string
+		calculate func(graph *recipeGraph, targets []*ProductionTarget) (*ProductionTree, error)
+	}{
+		{
+			name: "steady state calculation",
+			calculate: func(graph *recipeGraph, targets []*ProductionTarget) (*ProductionTree, error) {
+				return computeSteadyStateProductionTree(graph, targets, CalculationOptions{})
+			},
+		},
+		{
+			name: "optimal calculation",
+			calculate: func(graph *recipeGraph, targets []*ProductionTarget) (*ProductionTree, error) {
+				return computeOptimalProductionTree(graph, targets, CalculationOptions{})
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			targets := mergeTargets([]*ProductionTarget{{Resource: "iron_plate", Rate: 0.5}, {Resource: "iron_rod", Rate: 0.5}, {Resource: "iron_plate", Rate: 0.5}})
+			tree, err := test.calculate(testRecipeGraph(), targets)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// iron ingots for both targets are produced by one shared node
+			expected := map[string]uint64{"iron_plate": 3, "iron_rod": 2, "iron_ingot": 4, "iron_ore": 2}
+			machineCounts := make(map[string]uint64)
+			for _, node := range tree.TreeNodes {
+				if _, exists := machineCounts[node.RecipeName]; exists {
+					t.Fatalf("expected one node of recipe '%s'", node.RecipeName)
+				}
+				machineCounts[node.RecipeName] = node.MachineCount
+			}
+			if !reflect.DeepEqual(machineCounts, expected) {
+				t.Fatalf("expected machines %v, got %v", expected, machineCounts)
+			}
+			if len(tree.Targets) != 2 || tree.Targets[0].Resource != "iron_plate" || tree.Targets[0].Rate != 1 || tree.Targets[1].Resource != "iron_rod" || tree.Targets[1].Rate != 0.5 {
+				t.Fatalf("unexpected targets %v", tree.Targets)
+			}
+			for _, target := range tree.Targets {
+				if tree.TreeNodes[target.SourceNode].RecipeName != target.Resource {
+					t.Fatalf("expected target '%s' to be produced by node of its recipe, got node %d", target.Resource, target.SourceNode)
+				}
+			}
+			if tree.TargetResource != "" || tree.TargetResourceSourceNode != -1 {
+				t.Fatalf("expected single target fields to be empty, got '%s' and %d", tree.TargetResource, tree.TargetResourceSourceNode)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// ProductionEdge is a flow of resource between two nodes of production tree. SourceNode is -1 for resources
+// consumed by the tree but not produced by any of its nodes, TargetNode is -1 for flows delivering targets.
+// Lanes is the number of lanes of TransportTier needed to carry the flow, TransportTier is empty if no allowed
+// transport tier can carry the resource.
+type ProductionEdge struct {
+	SourceNode        int
+	TargetNode        int
+	Resource          string
+	ResourcePerSecond float32
+	ResourcePerUnit   float32 `json:",omitempty"`
+	TransportTier     string
+	Lanes             uint64
+}
+
+// setEdges derives edges of production tree from source nodes of its nodes and from targets. When several nodes
+// supply the same resource to a node, the flow is split proportionally to amounts they produce.
+func (t *ProductionTree) setEdges() {
+	t.Edges = []*ProductionEdge{}
+	treeNodes := make(map[int]*ProductionTreeNode)
+	for _, node := range t.TreeNodes {
+		treeNodes[node.NodeId] = node
+	}
+	for _, node := range t.TreeNodes {
+		for _, resourceName := range sortedKeys(node.RequiredResourcesPerSecond) {
+			required := node.RequiredResourcesPerSecond[resourceName]
+			sources := []*ProductionTreeNode{}
+			var produced float32
+			for _, sourceNodeId := range node.SourceNodes {
+				if source, exists := treeNodes[sourceNodeId]; exists && source.ProducedResourcesPerSecond[resourceName] > 0 {
+					sources = append(sources, source)
+					produced += source.ProducedResourcesPerSecond[resourceName]
+				}
+			}
+			if len(sources) == 0 {
+				t.Edges = append(t.Edges, &ProductionEdge{SourceNode: -1, TargetNode: node.NodeId, Resource: resourceName, ResourcePerSecond: required})
+				continue
+			}
+			for _, source := range sources {
+				share := source.ProducedResourcesPerSecond[resourceName] / produced
+				t.Edges = append(t.Edges, &ProductionEdge{SourceNode: source.NodeId, TargetNode: node.NodeId, Resource: resourceName, ResourcePerSecond: required * share})
+			}
+		}
+	}
+	for _, target := range t.Targets {
+		if _, exists := treeNodes[target.SourceNode]; exists {
+			t.Edges = append(t.Edges, &ProductionEdge{SourceNode: target.SourceNode, TargetNode: -1, Resource: target.Resource, ResourcePerSecond: target.Rate})
+		}
+	}
+}
+
+// setLanes chooses transport tier for every edge and excess resource of production tree
+// and computes the number of its lanes needed to carry the flow.
+func (t *ProductionTree) setLanes(graph *recipeGraph, options CalculationOptions) {
+	for _, edge := range t.Edges {
+		edge.TransportTier, edge.Lanes = graph.lanes(edge.Resource, edge.ResourcePerSecond, options)
+	}
+	for _, excessResource := range t.ExcessResources {
+		excessResource.TransportTier, excessResource.Lanes = graph.lanes(excessResource.ExcessResourceName, excessResource.ExcessProducedResourcePerSecond, options)
+	}
+}
+
+// lanes returns the transport tier carrying resource and the number of its lanes needed to carry rate per second.
+// The allowed tier with the highest capacity is chosen among tiers for liquids if resource is liquid,
+// and among tiers for solids otherwise.
+func (g *recipeGraph) lanes(resourceName string, rate float32, options CalculationOptions) (string, uint64) {
+	resource, exists := g.Resources[resourceName]
+	liquid := exists && resource.Liquid
+	for _, transportTier := range g.TransportTiers {
+		if transportTier.Liquid != liquid {
+			continue
+		}
+		if len(options.TransportTiers) > 0 && !slices.Contains(options.TransportTiers, transportTier.Name) {
+			continue
+		}
+		return transportTier.Name, uint64(math.Ceil(float64(rate) / transportTier.CapacityPerS * (1 - machineNumberTolerance)))
+	}
+	return "", 0
+}
+
+// splitNodes replaces every node with as many identical copies as lanes needed by the busiest of its edges
+// and excess resources, so that none of them needs more than one lane. Copies share flows of the node equally.
+// Flow of an edge is assigned between copies of its source and target by filling them in order, which connects
+// every copy to as few copies of the other node as possible. Targets point to the first copy of their source node.
+func (t *ProductionTree) splitNodes(graph *recipeGraph) {
+	copies := make(map[int]int)
+	machines := make(map[int]*graphMachine)
+	for _, node := range t.TreeNodes {
+		copies[node.NodeId] = 1
+		machines[node.NodeId] = graph.machine(node.MachineName)
+	}
+	split := false
+	require := func(nodeId int, lanes uint64) {
+		if _, exists := copies[nodeId]; exists && machines[nodeId] != nil && lanes > uint64(copies[nodeId]) {
+			copies[nodeId] = int(lanes)
+			split = true
+		}
+	}
+	for _, edge := range t.Edges {
+		require(edge.SourceNode, edge.Lanes)
+		require(edge.TargetNode, edge.Lanes)
+	}
+	for _, excessResource := range t.ExcessResources {
+		require(excessResource.NodeId, excessResource.Lanes)
+	}
+	if !split {
+		return
+	}
+
+	firstCopy := make(map[int]int)
+	nodes := []*ProductionTreeNode{}
+	for _, node := range t.TreeNodes {
+		firstCopy[node.NodeId] = len(nodes)
+		count := copies[node.NodeId]
+		for i := 0; i < count; i++ {
+			newNode := *node
+			newNode.NodeId = len(nodes)
+			newNode.SourceNodes = nil
+			newNode.RequiredResourcesPerSecond = make(map[string]float32)
+			newNode.ProducedResourcesPerSecond = make(map[string]float32)
+			for resourceName, rate := range node.RequiredResourcesPerSecond {
+				newNode.RequiredResourcesPerSecond[resourceName] = rate / float32(count)
+			}
+			for resourceName, rate := range node.ProducedResourcesPerSecond {
+				newNode.ProducedResourcesPerSecond[resourceName] = rate / float32(count)
+			}
+			if count > 1 {
+				newNode.setMachines(float64(node.MachineNumber)/float64(count), machines[node.NodeId])
+			}
+			nodes = append(nodes, &newNode)
+		}
+	}
+	copyIds := func(nodeId int) []int {
+		first, exists := firstCopy[nodeId]
+		if !exists {
+			return []int{-1}
+		}
+		ids := []int{}
+		for i := 0; i < copies[nodeId]; i++ {
+			ids = append(ids, first+i)
+		}
+		return ids
+	}
+
+	edges := []*ProductionEdge{}
+	for _, edge := range t.Edges {
+		sources := copyIds(edge.SourceNode)
+		targets := copyIds(edge.TargetNode)
+		// the flow is cut into len(sources) and len(targets) equal parts, every overlap of a source part
+		// with a target part becomes an edge
+		sourceParts, targetParts := len(sources), len(targets)
+		for i, j := 0, 0; i < sourceParts && j < targetParts; {
+			start := max(i*targetParts, j*sourceParts)
+			end := min((i+1)*targetParts, (j+1)*sourceParts)
+			edges = append(edges, &ProductionEdge{
+				SourceNode:        sources[i],
+				TargetNode:        targets[j],
+				Resource:          edge.Resource,
+				ResourcePerSecond: edge.ResourcePerSecond * float32(end-start) / float32(sourceParts*targetParts),
+			})
+			if end == (i+1)*targetParts {
+				i++
+			}
+			if end == (j+1)*sourceParts {
+				j++
+			}
+		}
+	}
+	for _, edge := range edges {
+		if edge.SourceNode >= 0 && edge.TargetNode >= 0 {
+			nodes[edge.TargetNode].SourceNodes = append(nodes[edge.TargetNode].SourceNodes, edge.SourceNode)
+		}
+	}
+	for _, node := range nodes {
+		sort.Ints(node.SourceNodes)
+		node.SourceNodes = slices.Compact(node.SourceNodes)
+	}
+
+	excessResources := []*ResourceSource{}
+	for _, excessResource := range t.ExcessResources {
+		ids := copyIds(excessResource.NodeId)
+		for _, id := range ids {
+			excessResources = append(excessResources, &ResourceSource{
+				NodeId:                          id,
+				ExcessResourceName:              excessResource.ExcessResourceName,
+				ExcessProducedResourcePerSecond: excessResource.ExcessProducedResourcePerSecond / float32(len(ids)),
+			})
+		}
+	}
+	for _, target := range t.Targets {
+		if first, exists := firstCopy[target.SourceNode]; exists {
+			target.SourceNode = first
+		}
+	}
+	if first, exists := firstCopy[t.TargetResourceSourceNode]; exists {
+		t.TargetResourceSourceNode = first
+	}
+	t.TreeNodes = nodes
+	t.Edges = edges
+	t.ExcessResources = excessResources
+}
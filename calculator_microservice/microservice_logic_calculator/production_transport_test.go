@@ -0,0 +1,218 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"reflect"
+	"testing"
+)
+
+// transportRecipeGraph returns test recipe graph with water and two belts and a pipe, ordered by capacity.
+func transportRecipeGraph() *recipeGraph {
+	graph := testRecipeGraph()
+	graph.Resources["water"] = &graphResource{Name: "water", Liquid: true}
+	graph.TransportTiers = []*graphTransportTier{
+		{Id: 3, Name: "pipe", Liquid: true, CapacityPerS: 5},
+		{Id: 2, Name: "belt_mk2", CapacityPerS: 2},
+		{Id: 1, Name: "belt_mk1", CapacityPerS: 1},
+	}
+	return graph
+}
+
+func TestLanes(t *testing.T) {
+	tests := []struct {
+		name          string
+		resource      string
+		rate          float32
+		options       CalculationOptions
+		expectedTier  string
+		expectedLanes uint64
+	}{
+		{
+			name:          "solid resource on the fastest belt",
+			resource:      "iron_ore",
+			rate:          3,
+			expectedTier:  "belt_mk2",
+			expectedLanes: 2,
+		},
+		{
+			name:          "rate exceeding capacity by rounding error",
+			resource:      "iron_ore",
+			rate:          4.00001,
+			expectedTier:  "belt_mk2",
+			expectedLanes: 2,
+		},
+		{
+			name:          "rate exceeding capacity",
+			resource:      "iron_ore",
+			rate:          4.1,
+			expectedTier:  "belt_mk2",
+			expectedLanes: 3,
+		},
+		{
+			name:          "unknown resource carried as solid",
+			resource:      "unknown",
+			rate:          1,
+			expectedTier:  "belt_mk2",
+			expectedLanes: 1,
+		},
+		{
+			name:          "only allowed tiers",
+			resource:      "iron_ore",
+			rate:          3,
+			options:       CalculationOptions{TransportTiers: []string{"belt_mk1", "pipe"}},
+			expectedTier:  "belt_mk1",
+			expectedLanes: 3,
+		},
+		{
+			name:          "liquid resource",
+			resource:      "water",
+			rate:          6,
+			expectedTier:  "pipe",
+			expectedLanes: 2,
+		},
+		{
+			name:          "no allowed tier for liquid",
+			resource:      "water",
+			rate:          6,
+			options:       CalculationOptions{TransportTiers: []string{"belt_mk1"}},
+			expectedTier:  "",
+			expectedLanes: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tier, lanes := transportRecipeGraph().lanes(test.resource, test.rate, test.options)
+			if tier != test.expectedTier || lanes != test.expectedLanes {
+				t.Fatalf("expected %d lanes of '%s', got %d lanes of '%s'", test.expectedLanes, test.expectedTier, lanes, tier)
+			}
+		})
+	}
+}
+
+// newSplitTestTree returns tree of smelters producing 1.5 iron ingots and 0.75 slag per second from 3 iron ore
+// for constructors producing 1 iron plate per second.
+func newSplitTestTree() *ProductionTree {
+	graph := testRecipeGraph()
+	smelters := &ProductionTreeNode{
+		NodeId:                     0,
+		RecipeName:                 "iron_ingot",
+		MachineName:                "smelter",
+		RequiredResourcesPerSecond: map[string]float32{"iron_ore": 3},
+		ProducedResourcesPerSecond: map[string]float32{"iron_ingot": 1.5, "slag": 0.75},
+	}
+	smelters.setMachines(3, graph.machine("smelter"))
+	constructors := &ProductionTreeNode{
+		NodeId:                     1,
+		RecipeName:                 "iron_plate",
+		MachineName:                "constructor",
+		RequiredResourcesPerSecond: map[string]float32{"iron_ingot": 1.5},
+		ProducedResourcesPerSecond: map[string]float32{"iron_plate": 1},
+		SourceNodes:                []int{0},
+	}
+	constructors.setMachines(2, graph.machine("constructor"))
+	return &ProductionTree{
+		TreeNodes:                []*ProductionTreeNode{smelters, constructors},
+		TargetResource:           "iron_plate",
+		TargetResourceRate:       1,
+		TargetResourceSourceNode: 1,
+		Targets:                  []*ProductionTarget{{Resource: "iron_plate", Rate: 1, SourceNode: 1}},
+		ExcessResources:          []*ResourceSource{{NodeId: 0, ExcessResourceName: "slag", ExcessProducedResourcePerSecond: 0.75}},
+	}
+}
+
+func TestSplitNodes(t *testing.T) {
+	graph := transportRecipeGraph()
+	tree := newSplitTestTree()
+	tree.setEdges()
+	// belt_mk1 carries 1 resource per second, so iron ore needs 3 lanes and iron ingots need 2 lanes
+	tree.setLanes(graph, CalculationOptions{TransportTiers: []string{"belt_mk1"}})
+	tree.splitNodes(graph)
+
+	expectedEdges := []*ProductionEdge{
+		{SourceNode: -1, TargetNode: 0, Resource: "iron_ore", ResourcePerSecond: 1},
+		{SourceNode: -1, TargetNode: 1, Resource: "iron_ore", ResourcePerSecond: 1},
+		{SourceNode: -1, TargetNode: 2, Resource: "iron_ore", ResourcePerSecond: 1},
+		{SourceNode: 0, TargetNode: 3, Resource: "iron_ingot", ResourcePerSecond: 0.5},
+		{SourceNode: 1, TargetNode: 3, Resource: "iron_ingot", ResourcePerSecond: 0.25},
+		{SourceNode: 1, TargetNode: 4, Resource: "iron_ingot", ResourcePerSecond: 0.25},
+		{SourceNode: 2, TargetNode: 4, Resource: "iron_ingot", ResourcePerSecond: 0.5},
+		{SourceNode: 3, TargetNode: -1, Resource: "iron_plate", ResourcePerSecond: 0.5},
+		{SourceNode: 4, TargetNode: -1, Resource: "iron_plate", ResourcePerSecond: 0.5},
+	}
+	if len(tree.Edges) != len(expectedEdges) {
+		t.Fatalf("expected %d edges, got %d", len(expectedEdges), len(tree.Edges))
+	}
+	for i, edge := range tree.Edges {
+		if !reflect.DeepEqual(edge, expectedEdges[i]) {
+			t.Fatalf("expected edge %d to be %+v, got %+v", i, expectedEdges[i], edge)
+		}
+	}
+	expectedSourceNodes := [][]int{nil, nil, nil, {0, 1}, {1, 2}}
+	if len(tree.TreeNodes) != len(expectedSourceNodes) {
+		t.Fatalf("expected %d nodes, got %d", len(expectedSourceNodes), len(tree.TreeNodes))
+	}
+	for i, node := range tree.TreeNodes {
+		if node.NodeId != i {
+			t.Fatalf("expected node %d to have id %d, got %d", i, i, node.NodeId)
+		}
+		if !reflect.DeepEqual(node.SourceNodes, expectedSourceNodes[i]) {
+			t.Fatalf("expected source nodes of node %d to be %v, got %v", i, expectedSourceNodes[i], node.SourceNodes)
+		}
+		if node.MachineNumber != 1 || node.MachineCount != 1 || node.ClockPercentage != 100 || node.TotalPowerConsumedkW != 4000 {
+			t.Fatalf("expected node %d to run 1 machine at full clock, got %+v", i, node)
+		}
+	}
+	if rate := tree.TreeNodes[1].ProducedResourcesPerSecond["iron_ingot"]; rate != 0.5 {
+		t.Fatalf("expected copy of smelters to produce 0.5 iron ingots per second, got %f", rate)
+	}
+	if rate := tree.TreeNodes[4].RequiredResourcesPerSecond["iron_ingot"]; rate != 0.75 {
+		t.Fatalf("expected copy of constructors to require 0.75 iron ingots per second, got %f", rate)
+	}
+
+	expectedExcess := []*ResourceSource{
+		{NodeId: 0, ExcessResourceName: "slag", ExcessProducedResourcePerSecond: 0.25},
+		{NodeId: 1, ExcessResourceName: "slag", ExcessProducedResourcePerSecond: 0.25},
+		{NodeId: 2, ExcessResourceName: "slag", ExcessProducedResourcePerSecond: 0.25},
+	}
+	if len(tree.ExcessResources) != len(expectedExcess) {
+		t.Fatalf("expected %d excess resources, got %d", len(expectedExcess), len(tree.ExcessResources))
+	}
+	for i, excessResource := range tree.ExcessResources {
+		if !reflect.DeepEqual(excessResource, expectedExcess[i]) {
+			t.Fatalf("expected excess resource %d to be %+v, got %+v", i, expectedExcess[i], excessResource)
+		}
+	}
+	if tree.Targets[0].SourceNode != 3 || tree.TargetResourceSourceNode != 3 {
+		t.Fatalf("expected target to point to the first copy of constructors, got %d and %d", tree.Targets[0].SourceNode, tree.TargetResourceSourceNode)
+	}
+}
+
+func TestSplitNodesWithinSingleLane(t *testing.T) {
+	graph := transportRecipeGraph()
+	tree := newSplitTestTree()
+	tree.setEdges()
+	// pipe is the only allowed tier, so no solid flow is carried by any lanes
+	tree.setLanes(graph, CalculationOptions{TransportTiers: []string{"pipe"}})
+	expected := newSplitTestTree()
+	expected.setEdges()
+	expected.setLanes(graph, CalculationOptions{TransportTiers: []string{"pipe"}})
+	tree.splitNodes(graph)
+	if !reflect.DeepEqual(tree, expected) {
+		t.Fatalf("expected tree not to be split")
+	}
+}
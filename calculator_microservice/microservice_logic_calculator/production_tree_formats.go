@@ -0,0 +1,209 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatJSON    = "json"
+	FormatDot     = "dot"
+	FormatMermaid = "mermaid"
+)
+
+const (
+	diagramMachinesNode = iota
+	diagramInputNode
+	diagramTargetNode
+	diagramExcessNode
+)
+
+type diagramNode struct {
+	Id    string
+	Label []string
+	Kind  int
+}
+
+type diagramEdge struct {
+	From          string
+	To            string
+	Resource      string
+	Rate          float32
+	TransportTier string
+	Lanes         uint64
+}
+
+// EncodeProductionTree returns representation of production tree in one of formats: json, dot (Graphviz) or mermaid.
+func EncodeProductionTree(tree *ProductionTree, format string) ([]byte, error) {
+	switch format {
+	case "", FormatJSON:
+		byteJSONRepresentation, err := json.Marshal(tree)
+		if err != nil {
+			return nil, fmt.Errorf("could not generate json representation: %w", err)
+		}
+		return byteJSONRepresentation, nil
+	case FormatDot:
+		return []byte(tree.dot()), nil
+	case FormatMermaid:
+		return []byte(tree.mermaid()), nil
+	}
+	return nil, fmt.Errorf("unknown format '%s'", format)
+}
+
+// diagram converts production tree into graph with machine nodes, inputs not produced by the tree, targets
+// and excess resources. Edges carry resource flows of production tree edges and excess resources.
+func (t *ProductionTree) diagram() ([]diagramNode, []diagramEdge) {
+	nodes := []diagramNode{}
+	edges := []diagramEdge{}
+	for _, node := range t.TreeNodes {
+		label := []string{node.RecipeName, fmt.Sprintf("%s x%d @ %s%%", node.MachineName, node.MachineCount, formatNumber(node.ClockPercentage))}
+		if node.MachineName == "" {
+			label = []string{"sink " + node.SunkResource, "points " + t.rateLabel("", node.SinkPointsPerSecond)}
+		}
+		if node.PowerGeneratedkW > 0 {
+			label = append(label, fmt.Sprintf("generates %d kW", node.PowerGeneratedkW))
+		}
+		nodes = append(nodes, diagramNode{Id: machinesNodeId(node.NodeId), Label: label, Kind: diagramMachinesNode})
+	}
+	inputNodes := make(map[string]string)
+	for _, edge := range t.Edges {
+		if edge.TargetNode < 0 {
+			continue
+		}
+		from := machinesNodeId(edge.SourceNode)
+		if edge.SourceNode < 0 {
+			inputNodeId, exists := inputNodes[edge.Resource]
+			if !exists {
+				inputNodeId = fmt.Sprintf("input%d", len(inputNodes))
+				inputNodes[edge.Resource] = inputNodeId
+				nodes = append(nodes, diagramNode{Id: inputNodeId, Label: []string{edge.Resource}, Kind: diagramInputNode})
+			}
+			from = inputNodeId
+		}
+		edges = append(edges, diagramEdge{From: from, To: machinesNodeId(edge.TargetNode), Resource: edge.Resource, Rate: edge.ResourcePerSecond, TransportTier: edge.TransportTier, Lanes: edge.Lanes})
+	}
+	for i, target := range t.Targets {
+		targetNodeId := fmt.Sprintf("target%d", i)
+		nodes = append(nodes, diagramNode{Id: targetNodeId, Label: []string{target.Resource}, Kind: diagramTargetNode})
+		for _, edge := range t.Edges {
+			if edge.TargetNode < 0 && edge.SourceNode >= 0 && edge.Resource == target.Resource {
+				edges = append(edges, diagramEdge{From: machinesNodeId(edge.SourceNode), To: targetNodeId, Resource: edge.Resource, Rate: edge.ResourcePerSecond, TransportTier: edge.TransportTier, Lanes: edge.Lanes})
+			}
+		}
+	}
+	for i, excessResource := range t.ExcessResources {
+		excessNodeId := fmt.Sprintf("excess%d", i)
+		nodes = append(nodes, diagramNode{Id: excessNodeId, Label: []string{"excess " + excessResource.ExcessResourceName}, Kind: diagramExcessNode})
+		edges = append(edges, diagramEdge{From: machinesNodeId(excessResource.NodeId), To: excessNodeId, Resource: excessResource.ExcessResourceName, Rate: excessResource.ExcessProducedResourcePerSecond, TransportTier: excessResource.TransportTier, Lanes: excessResource.Lanes})
+	}
+	return nodes, edges
+}
+
+func (t *ProductionTree) dot() string {
+	nodes, edges := t.diagram()
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	shapes := map[int]string{diagramMachinesNode: "box", diagramInputNode: "invhouse", diagramTargetNode: "doubleoctagon", diagramExcessNode: "ellipse"}
+	var builder strings.Builder
+	builder.WriteString("digraph production_tree {\n\trankdir=LR;\n")
+	for _, node := range nodes {
+		label := []string{}
+		for _, line := range node.Label {
+			label = append(label, escape.Replace(line))
+		}
+		fmt.Fprintf(&builder, "\t%s [shape=%s, label=\"%s\"];\n", node.Id, shapes[node.Kind], strings.Join(label, `\n`))
+	}
+	for _, edge := range edges {
+		label := []string{}
+		for _, line := range t.edgeLabel(edge) {
+			label = append(label, escape.Replace(line))
+		}
+		fmt.Fprintf(&builder, "\t%s -> %s [label=\"%s\"];\n", edge.From, edge.To, strings.Join(label, `\n`))
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+func (t *ProductionTree) mermaid() string {
+	nodes, edges := t.diagram()
+	escape := strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;")
+	shapes := map[int][2]string{diagramMachinesNode: {"[", "]"}, diagramInputNode: {"[/", "\\]"}, diagramTargetNode: {"[[", "]]"}, diagramExcessNode: {"([", "])"}}
+	var builder strings.Builder
+	builder.WriteString("flowchart LR\n")
+	for _, node := range nodes {
+		label := []string{}
+		for _, line := range node.Label {
+			label = append(label, escape.Replace(line))
+		}
+		fmt.Fprintf(&builder, "\t%s%s\"%s\"%s\n", node.Id, shapes[node.Kind][0], strings.Join(label, "<br/>"), shapes[node.Kind][1])
+	}
+	for _, edge := range edges {
+		label := []string{}
+		for _, line := range t.edgeLabel(edge) {
+			label = append(label, escape.Replace(line))
+		}
+		fmt.Fprintf(&builder, "\t%s -->|\"%s\"| %s\n", edge.From, strings.Join(label, "<br/>"), edge.To)
+	}
+	return builder.String()
+}
+
+// edgeLabel returns lines describing edge: resource, its rate and lanes of transport tier carrying it, e.g. "2x conveyor_belt_mk2".
+func (t *ProductionTree) edgeLabel(edge diagramEdge) []string {
+	label := []string{edge.Resource, t.rateLabel(edge.Resource, edge.Rate)}
+	if edge.TransportTier != "" {
+		label = append(label, fmt.Sprintf("%dx %s", edge.Lanes, edge.TransportTier))
+	}
+	return label
+}
+
+// rateLabel formats per second rate converted to rate unit of the tree together with unit of resource and rate
+// unit, e.g. "30 items/min".
+func (t *ProductionTree) rateLabel(resourceName string, rate float32) string {
+	rateUnit := t.RateUnit
+	if rateUnit == "" {
+		rateUnit = RateUnitSecond
+	}
+	seconds, _ := RateUnitSeconds(rateUnit)
+	rate *= seconds
+	if resourceUnit := t.ResourceUnits[resourceName]; resourceUnit != "" {
+		return fmt.Sprintf("%s %s/%s", formatNumber(rate), resourceUnit, rateUnit)
+	}
+	return fmt.Sprintf("%s/%s", formatNumber(rate), rateUnit)
+}
+
+func machinesNodeId(nodeId int) string {
+	return fmt.Sprintf("node%d", nodeId)
+}
+
+// formatNumber rounds number to 4 decimal places and drops trailing zeros.
+func formatNumber(number float32) string {
+	return strconv.FormatFloat(math.Round(float64(number)*1e4)/1e4, 'f', -1, 64)
+}
+
+func sortedKeys[V any](resources map[string]V) []string {
+	keys := make([]string, 0, len(resources))
+	for key := range resources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,105 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func newFormatsTestTree() *ProductionTree {
+	return &ProductionTree{
+		TreeNodes: []*ProductionTreeNode{
+			{NodeId: 0, RecipeName: "iron_ingot", MachineName: "smelter", MachineCount: 2, ClockPercentage: 50},
+			{NodeId: 1, RecipeName: "iron_plate", MachineName: `constructor "mk1"`, MachineCount: 1, ClockPercentage: 66.66667},
+		},
+		Targets: []*ProductionTarget{{Resource: "iron_plate", Rate: 0.6666667, SourceNode: 1}},
+		Edges: []*ProductionEdge{
+			{SourceNode: -1, TargetNode: 0, Resource: "iron_ore", ResourcePerSecond: 1},
+			{SourceNode: 0, TargetNode: 1, Resource: "iron_ingot", ResourcePerSecond: 1, TransportTier: "conveyor_belt_mk1", Lanes: 1},
+			{SourceNode: 1, TargetNode: -1, Resource: "iron_plate", ResourcePerSecond: 0.6666667},
+		},
+		ExcessResources: []*ResourceSource{{NodeId: 0, ExcessResourceName: "slag", ExcessProducedResourcePerSecond: 0.25}},
+		RateUnit:        RateUnitMinute,
+		ResourceUnits:   map[string]string{"iron_ore": "items", "iron_ingot": "items", "iron_plate": "items", "slag": "m³"},
+	}
+}
+
+func TestEncodeProductionTree(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{
+			name:   "dot",
+			format: FormatDot,
+			expected: "digraph production_tree {\n\trankdir=LR;\n" +
+				"\tnode0 [shape=box, label=\"iron_ingot\\nsmelter x2 @ 50%\"];\n" +
+				"\tnode1 [shape=box, label=\"iron_plate\\nconstructor \\\"mk1\\\" x1 @ 66.6667%\"];\n" +
+				"\tinput0 [shape=invhouse, label=\"iron_ore\"];\n" +
+				"\ttarget0 [shape=doubleoctagon, label=\"iron_plate\"];\n" +
+				"\texcess0 [shape=ellipse, label=\"excess slag\"];\n" +
+				"\tinput0 -> node0 [label=\"iron_ore\\n60 items/min\"];\n" +
+				"\tnode0 -> node1 [label=\"iron_ingot\\n60 items/min\\n1x conveyor_belt_mk1\"];\n" +
+				"\tnode1 -> target0 [label=\"iron_plate\\n40 items/min\"];\n" +
+				"\tnode0 -> excess0 [label=\"slag\\n15 m³/min\"];\n" +
+				"}\n",
+		},
+		{
+			name:   "mermaid",
+			format: FormatMermaid,
+			expected: "flowchart LR\n" +
+				"\tnode0[\"iron_ingot<br/>smelter x2 @ 50%\"]\n" +
+				"\tnode1[\"iron_plate<br/>constructor #quot;mk1#quot; x1 @ 66.6667%\"]\n" +
+				"\tinput0[/\"iron_ore\"\\]\n" +
+				"\ttarget0[[\"iron_plate\"]]\n" +
+				"\texcess0([\"excess slag\"])\n" +
+				"\tinput0 -->|\"iron_ore<br/>60 items/min\"| node0\n" +
+				"\tnode0 -->|\"iron_ingot<br/>60 items/min<br/>1x conveyor_belt_mk1\"| node1\n" +
+				"\tnode1 -->|\"iron_plate<br/>40 items/min\"| target0\n" +
+				"\tnode0 -->|\"slag<br/>15 m³/min\"| excess0\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			encoded, err := EncodeProductionTree(newFormatsTestTree(), test.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(encoded) != test.expected {
+				t.Fatalf("expected:\n%s\ngot:\n%s", test.expected, encoded)
+			}
+		})
+	}
+}
+
+func TestEncodeProductionTreeJSON(t *testing.T) {
+	for _, format := range []string{"", FormatJSON} {
+		encoded, err := EncodeProductionTree(newFormatsTestTree(), format)
+		if err != nil {
+			t.Fatalf("unexpected error for format '%s': %v", format, err)
+		}
+		decoded := ProductionTree{}
+		if err := json.Unmarshal(encoded, &decoded); err != nil || len(decoded.TreeNodes) != 2 {
+			t.Fatalf("expected json representation of tree for format '%s', got %s", format, encoded)
+		}
+	}
+	if _, err := EncodeProductionTree(newFormatsTestTree(), "svg"); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
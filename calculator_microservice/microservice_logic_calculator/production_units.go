@@ -0,0 +1,139 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"fmt"
+)
+
+const (
+	RateUnitSecond = "s"
+	RateUnitMinute = "min"
+	RateUnitHour   = "h"
+)
+
+var rateUnitsSeconds = map[string]float32{RateUnitSecond: 1, RateUnitMinute: 60, RateUnitHour: 3600}
+
+// RateUnitSeconds returns length of rate unit in seconds. Empty unit is treated as per second rate.
+func RateUnitSeconds(unit string) (float32, bool) {
+	if unit == "" {
+		unit = RateUnitSecond
+	}
+	seconds, exists := rateUnitsSeconds[unit]
+	return seconds, exists
+}
+
+// ConvertRates expresses rates of production tree per unit. Fields named PerSecond and rates of targets always hold
+// per second rates, converted rates are set in fields named PerUnit when unit other than seconds is requested, so that
+// clients ignoring RateUnit do not misread them.
+func (t *ProductionTree) ConvertRates(unit string) error {
+	seconds, exists := RateUnitSeconds(unit)
+	if !exists {
+		return fmt.Errorf("unknown rate unit '%s'", unit)
+	}
+	perUnit := func(rate float32) float32 {
+		if seconds == 1 {
+			return 0
+		}
+		return rate * seconds
+	}
+	perUnitMap := func(rates map[string]float32) map[string]float32 {
+		if seconds == 1 || rates == nil {
+			return nil
+		}
+		converted := make(map[string]float32, len(rates))
+		for resourceName, rate := range rates {
+			converted[resourceName] = rate * seconds
+		}
+		return converted
+	}
+	t.TargetResourceRatePerUnit = perUnit(t.TargetResourceRate)
+	for _, target := range t.Targets {
+		target.RatePerUnit = perUnit(target.Rate)
+	}
+	for _, node := range t.TreeNodes {
+		node.RequiredResourcesPerUnit = perUnitMap(node.RequiredResourcesPerSecond)
+		node.ProducedResourcesPerUnit = perUnitMap(node.ProducedResourcesPerSecond)
+		node.SinkPointsPerUnit = perUnit(node.SinkPointsPerSecond)
+		if node.Explanation != nil {
+			for _, candidate := range node.Explanation.Candidates {
+				candidate.ResourcePerUnit = perUnit(candidate.ResourcePerSecond)
+			}
+		}
+	}
+	for _, excessResource := range t.ExcessResources {
+		excessResource.ExcessProducedResourcePerUnit = perUnit(excessResource.ExcessProducedResourcePerSecond)
+	}
+	for _, edge := range t.Edges {
+		edge.ResourcePerUnit = perUnit(edge.ResourcePerSecond)
+	}
+	t.SuppliedResourcesPerUnit = perUnitMap(t.SuppliedResourcesPerSecond)
+	if t.Summary != nil {
+		t.Summary.RawResourcesPerUnit = perUnitMap(t.Summary.RawResourcesPerSecond)
+		t.Summary.ByproductsPerUnit = perUnitMap(t.Summary.ByproductsPerSecond)
+		t.Summary.SunkResourcesPerUnit = perUnitMap(t.Summary.SunkResourcesPerSecond)
+		t.Summary.SinkPointsPerUnit = perUnit(t.Summary.SinkPointsPerSecond)
+	}
+	t.RateUnit = unit
+	if t.RateUnit == "" {
+		t.RateUnit = RateUnitSecond
+	}
+	return nil
+}
+
+// finish fills fields of production tree derived from its nodes and recipe graph. Nodes are split before
+// the summary is computed, so that the summary counts machines of all copies. Excess resources are sunk
+// before edges are derived, so that sink nodes are connected to nodes producing the excess.
+func (t *ProductionTree) finish(graph *recipeGraph, options CalculationOptions) {
+	if options.SinkExcess {
+		t.sinkExcess(graph, options)
+	}
+	t.setEdges()
+	t.setLanes(graph, options)
+	if options.SplitNodes {
+		t.splitNodes(graph)
+		t.setLanes(graph, options)
+	}
+	t.setSummary()
+	if options.BuildCost {
+		t.setBuildCost(graph)
+	}
+	t.RateUnit = RateUnitSecond
+	t.ResourceUnits = make(map[string]string)
+	for _, node := range t.TreeNodes {
+		for resourceName := range node.RequiredResourcesPerSecond {
+			t.ResourceUnits[resourceName] = graph.resourceUnit(resourceName)
+		}
+		for resourceName := range node.ProducedResourcesPerSecond {
+			t.ResourceUnits[resourceName] = graph.resourceUnit(resourceName)
+		}
+	}
+	t.setRejectedPairings(graph, options)
+}
+
+// resourceUnit returns unit in which amounts of resource are measured. Resources without unit are measured
+// in cubic meters if they are liquid and in items otherwise.
+func (g *recipeGraph) resourceUnit(resourceName string) string {
+	resource, exists := g.Resources[resourceName]
+	switch {
+	case exists && resource.Unit != "":
+		return resource.Unit
+	case exists && resource.Liquid:
+		return "m³"
+	}
+	return "items"
+}
@@ -0,0 +1,103 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import "testing"
+
+func newUnitsTestTree() *ProductionTree {
+	return &ProductionTree{
+		TreeNodes: []*ProductionTreeNode{{
+			NodeId:                     0,
+			RecipeName:                 "iron_ingot",
+			MachineName:                "smelter",
+			RequiredResourcesPerSecond: map[string]float32{"iron_ore": 1},
+			ProducedResourcesPerSecond: map[string]float32{"iron_ingot": 0.5},
+		}},
+		TargetResource:     "iron_ingot",
+		TargetResourceRate: 0.5,
+		Targets:            []*ProductionTarget{{Resource: "iron_ingot", Rate: 0.5}},
+		ExcessResources:    []*ResourceSource{{NodeId: 0, ExcessResourceName: "slag", ExcessProducedResourcePerSecond: 0.25}},
+		Edges:              []*ProductionEdge{{SourceNode: -1, TargetNode: 0, Resource: "iron_ore", ResourcePerSecond: 1}},
+		Summary: &ProductionSummary{
+			RawResourcesPerSecond: map[string]float32{"iron_ore": 1},
+			ByproductsPerSecond:   map[string]float32{"slag": 0.25},
+		},
+		RateUnit: RateUnitSecond,
+	}
+}
+
+func TestConvertRatesKeepsPerSecondFields(t *testing.T) {
+	tree := newUnitsTestTree()
+	if err := tree.ConvertRates(RateUnitMinute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tree.RateUnit != RateUnitMinute {
+		t.Errorf("expected rate unit %s, got %s", RateUnitMinute, tree.RateUnit)
+	}
+	node := tree.TreeNodes[0]
+	if node.RequiredResourcesPerSecond["iron_ore"] != 1 || node.ProducedResourcesPerSecond["iron_ingot"] != 0.5 {
+		t.Errorf("per second rates of node have been changed: %v %v", node.RequiredResourcesPerSecond, node.ProducedResourcesPerSecond)
+	}
+	if node.RequiredResourcesPerUnit["iron_ore"] != 60 || node.ProducedResourcesPerUnit["iron_ingot"] != 30 {
+		t.Errorf("unexpected per unit rates of node: %v %v", node.RequiredResourcesPerUnit, node.ProducedResourcesPerUnit)
+	}
+	if tree.Edges[0].ResourcePerSecond != 1 || tree.Edges[0].ResourcePerUnit != 60 {
+		t.Errorf("unexpected rates of edge: %g %g", tree.Edges[0].ResourcePerSecond, tree.Edges[0].ResourcePerUnit)
+	}
+	if tree.ExcessResources[0].ExcessProducedResourcePerSecond != 0.25 || tree.ExcessResources[0].ExcessProducedResourcePerUnit != 15 {
+		t.Errorf("unexpected rates of excess resource: %g %g", tree.ExcessResources[0].ExcessProducedResourcePerSecond, tree.ExcessResources[0].ExcessProducedResourcePerUnit)
+	}
+	if tree.Summary.RawResourcesPerSecond["iron_ore"] != 1 || tree.Summary.RawResourcesPerUnit["iron_ore"] != 60 {
+		t.Errorf("unexpected raw resources of summary: %v %v", tree.Summary.RawResourcesPerSecond, tree.Summary.RawResourcesPerUnit)
+	}
+	if tree.TargetResourceRate != 0.5 || tree.Targets[0].Rate != 0.5 {
+		t.Errorf("per second rates of targets have been changed: %g %g", tree.TargetResourceRate, tree.Targets[0].Rate)
+	}
+	if tree.TargetResourceRatePerUnit != 30 || tree.Targets[0].RatePerUnit != 30 {
+		t.Errorf("expected target rates per minute, got %g %g", tree.TargetResourceRatePerUnit, tree.Targets[0].RatePerUnit)
+	}
+	if err := tree.ConvertRates(RateUnitHour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tree.TargetResourceRatePerUnit != 1800 || node.RequiredResourcesPerUnit["iron_ore"] != 3600 || node.RequiredResourcesPerSecond["iron_ore"] != 1 {
+		t.Errorf("unexpected rates after converting to hours: %g %v %v", tree.TargetResourceRatePerUnit, node.RequiredResourcesPerUnit, node.RequiredResourcesPerSecond)
+	}
+}
+
+func TestConvertRatesToSecondsLeavesPerUnitFieldsEmpty(t *testing.T) {
+	tree := newUnitsTestTree()
+	if err := tree.ConvertRates(RateUnitSecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tree.TreeNodes[0].RequiredResourcesPerUnit != nil || tree.Summary.RawResourcesPerUnit != nil || tree.Edges[0].ResourcePerUnit != 0 || tree.Targets[0].RatePerUnit != 0 {
+		t.Errorf("per unit fields should not be filled for per second rates")
+	}
+	if err := tree.ConvertRates("day"); err == nil {
+		t.Errorf("expected error for unknown rate unit")
+	}
+}
+
+func TestProductionTreeRateLabel(t *testing.T) {
+	tree := newUnitsTestTree()
+	tree.ResourceUnits = map[string]string{"iron_ore": "items"}
+	if err := tree.ConvertRates(RateUnitMinute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if label := tree.rateLabel("iron_ore", tree.Edges[0].ResourcePerSecond); label != "60 items/min" {
+		t.Errorf("expected label '60 items/min', got '%s'", label)
+	}
+}
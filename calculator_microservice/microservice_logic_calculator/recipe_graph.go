@@ -0,0 +1,336 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type graphResource struct {
+	Id     uint
+	Name   string
+	Liquid bool
+	Unit   string
+	// SinkValue is the number of points received for every unit of resource put into a sink, 0 when it cannot be sunk
+	SinkValue float64
+}
+
+type graphMachine struct {
+	Id                 uint
+	Name               string
+	InputsSolid        uint
+	InputsLiquid       uint
+	OutputsSolid       uint
+	OutputsLiquid      uint
+	Speed              float64
+	PowerConsumptionKw uint64
+	PowerClockExponent float64
+	PowerGenerationKw  uint64
+	DefaultChoice      bool
+	UnlockTier         *graphUnlockTier
+	// BuildCost holds amounts of resources needed to construct a single machine
+	BuildCost map[string]uint64
+}
+
+type graphRecipe struct {
+	Id              uint
+	Name            string
+	ProductionTimeS float64
+	DefaultChoice   bool
+	Inputs          map[string]float64
+	Outputs         map[string]float64
+	Machines        []*graphMachine
+	UnlockTier      *graphUnlockTier
+}
+
+type graphTransportTier struct {
+	Id           uint
+	Name         string
+	Liquid       bool
+	CapacityPerS float64
+}
+
+// recipeCandidate is a recipe paired with one of the machines able to run it.
+type recipeCandidate struct {
+	Recipe  *graphRecipe
+	Machine *graphMachine
+}
+
+type recipeGraph struct {
+	Resources map[string]*graphResource
+	Recipes   []*graphRecipe
+	Producers map[string][]*graphRecipe
+	// TransportTiers are ordered by capacity, from the highest
+	TransportTiers []*graphTransportTier
+	// RejectedPairings are recipes and machines assigned to each other, whose slots do not fit
+	RejectedPairings []rejectedPairing
+	// LockedPairings are recipes and machines assigned to each other, which are not unlocked yet
+	LockedPairings []lockedPairing
+}
+
+func loadRecipeGraph(ctx context.Context, userId int, db *sql.DB) (*recipeGraph, error) {
+	graph := recipeGraph{Resources: make(map[string]*graphResource), Producers: make(map[string][]*graphRecipe)}
+	resourcesById := make(map[uint]*graphResource)
+	machinesById := make(map[uint]*graphMachine)
+	recipesById := make(map[uint]*graphRecipe)
+
+	rows, err := db.QueryContext(ctx, `SELECT id, name, liquid, COALESCE(resource_unit, ''), COALESCE(sink_value, 0) FROM resources WHERE users_id = ?;`, userId)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve resources: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var resource graphResource
+		err = rows.Scan(&resource.Id, &resource.Name, &resource.Liquid, &resource.Unit, &resource.SinkValue)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse resources: %w", err)
+		}
+		resourcesById[resource.Id] = &resource
+		graph.Resources[resource.Name] = &resource
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not retrieve resources: %w", err)
+	}
+
+	unlockTiers, currentTier, err := loadProgression(ctx, userId, db)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err = db.QueryContext(ctx, `SELECT id, name, inputs_solid, inputs_liquid, outputs_solid, outputs_liquid, speed, power_consumption_kw, COALESCE(power_clock_exponent, 1), COALESCE(power_generation_kw, 0), default_choice, COALESCE(unlock_tiers_id, 0) FROM machines WHERE users_id = ?;`, userId)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve machines: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		machine := graphMachine{BuildCost: make(map[string]uint64)}
+		var unlockTierId uint
+		err = rows.Scan(&machine.Id, &machine.Name, &machine.InputsSolid, &machine.InputsLiquid, &machine.OutputsSolid, &machine.OutputsLiquid, &machine.Speed, &machine.PowerConsumptionKw, &machine.PowerClockExponent, &machine.PowerGenerationKw, &machine.DefaultChoice, &unlockTierId)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse machines: %w", err)
+		}
+		machine.UnlockTier = unlockTiers[unlockTierId]
+		machinesById[machine.Id] = &machine
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not retrieve machines: %w", err)
+	}
+
+	rows, err = db.QueryContext(ctx, `SELECT id, name, production_time_s, default_choice, COALESCE(unlock_tiers_id, 0) FROM recipes WHERE users_id = ?;`, userId)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve recipes: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		recipe := graphRecipe{Inputs: make(map[string]float64), Outputs: make(map[string]float64)}
+		var unlockTierId uint
+		err = rows.Scan(&recipe.Id, &recipe.Name, &recipe.ProductionTimeS, &recipe.DefaultChoice, &unlockTierId)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse recipes: %w", err)
+		}
+		recipe.UnlockTier = unlockTiers[unlockTierId]
+		recipesById[recipe.Id] = &recipe
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not retrieve recipes: %w", err)
+	}
+
+	for _, table := range []string{"recipes_inputs", "recipes_outputs"} {
+		rows, err = db.QueryContext(ctx, `SELECT recipes_id, resources_id, amount FROM `+table+` WHERE users_id = ? AND recipes_id IS NOT NULL AND resources_id IS NOT NULL;`, userId)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve %s: %w", table, err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var recipeId, resourceId uint
+			var amount float64
+			err = rows.Scan(&recipeId, &resourceId, &amount)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse %s: %w", table, err)
+			}
+			recipe, recipeExists := recipesById[recipeId]
+			resource, resourceExists := resourcesById[resourceId]
+			if !recipeExists || !resourceExists {
+				continue
+			}
+			if table == "recipes_inputs" {
+				recipe.Inputs[resource.Name] += amount
+			} else {
+				recipe.Outputs[resource.Name] += amount
+			}
+		}
+		if err = rows.Err(); err != nil {
+			return nil, fmt.Errorf("could not retrieve %s: %w", table, err)
+		}
+	}
+
+	rows, err = db.QueryContext(ctx, `SELECT machines_id, resources_id, amount FROM machines_costs WHERE users_id = ? AND machines_id IS NOT NULL AND resources_id IS NOT NULL;`, userId)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve machines_costs: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var machineId, resourceId uint
+		var amount uint64
+		err = rows.Scan(&machineId, &resourceId, &amount)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse machines_costs: %w", err)
+		}
+		machine, machineExists := machinesById[machineId]
+		resource, resourceExists := resourcesById[resourceId]
+		if !machineExists || !resourceExists {
+			continue
+		}
+		machine.BuildCost[resource.Name] += amount
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not retrieve machines_costs: %w", err)
+	}
+
+	rows, err = db.QueryContext(ctx, `SELECT recipes_id, machines_id FROM machines_recipes WHERE users_id = ? AND recipes_id IS NOT NULL AND machines_id IS NOT NULL;`, userId)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve machines_recipes: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var recipeId, machineId uint
+		err = rows.Scan(&recipeId, &machineId)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse machines_recipes: %w", err)
+		}
+		recipe, recipeExists := recipesById[recipeId]
+		machine, machineExists := machinesById[machineId]
+		if !recipeExists || !machineExists {
+			continue
+		}
+		if violations := graph.slotsViolations(recipe, machine); len(violations) > 0 {
+			graph.RejectedPairings = append(graph.RejectedPairings, rejectedPairing{Recipe: recipe, Machine: machine, Reason: strings.Join(violations, " and ")})
+			continue
+		}
+		if unlocks := missingUnlocks(recipe, machine, currentTier); len(unlocks) > 0 {
+			graph.LockedPairings = append(graph.LockedPairings, lockedPairing{Recipe: recipe, Machine: machine, Unlocks: unlocks})
+			continue
+		}
+		recipe.Machines = append(recipe.Machines, machine)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not retrieve machines_recipes: %w", err)
+	}
+
+	rows, err = db.QueryContext(ctx, `SELECT id, name, liquid, capacity_per_s FROM transport_tiers WHERE users_id = ? AND capacity_per_s > 0;`, userId)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve transport tiers: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var transportTier graphTransportTier
+		err = rows.Scan(&transportTier.Id, &transportTier.Name, &transportTier.Liquid, &transportTier.CapacityPerS)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse transport tiers: %w", err)
+		}
+		graph.TransportTiers = append(graph.TransportTiers, &transportTier)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not retrieve transport tiers: %w", err)
+	}
+	sort.Slice(graph.TransportTiers, func(i, j int) bool {
+		if graph.TransportTiers[i].CapacityPerS != graph.TransportTiers[j].CapacityPerS {
+			return graph.TransportTiers[i].CapacityPerS > graph.TransportTiers[j].CapacityPerS
+		}
+		return graph.TransportTiers[i].Id < graph.TransportTiers[j].Id
+	})
+
+	for _, recipe := range recipesById {
+		if recipe.ProductionTimeS <= 0 {
+			continue
+		}
+		graph.Recipes = append(graph.Recipes, recipe)
+	}
+	sort.Slice(graph.Recipes, func(i, j int) bool { return graph.Recipes[i].Id < graph.Recipes[j].Id })
+	sort.Slice(graph.RejectedPairings, func(i, j int) bool {
+		if graph.RejectedPairings[i].Recipe.Id != graph.RejectedPairings[j].Recipe.Id {
+			return graph.RejectedPairings[i].Recipe.Id < graph.RejectedPairings[j].Recipe.Id
+		}
+		return graph.RejectedPairings[i].Machine.Id < graph.RejectedPairings[j].Machine.Id
+	})
+	sort.Slice(graph.LockedPairings, func(i, j int) bool {
+		if graph.LockedPairings[i].Recipe.Id != graph.LockedPairings[j].Recipe.Id {
+			return graph.LockedPairings[i].Recipe.Id < graph.LockedPairings[j].Recipe.Id
+		}
+		return graph.LockedPairings[i].Machine.Id < graph.LockedPairings[j].Machine.Id
+	})
+	for _, recipe := range graph.Recipes {
+		sort.Slice(recipe.Machines, func(i, j int) bool { return recipe.Machines[i].Id < recipe.Machines[j].Id })
+		for resourceName := range recipe.Outputs {
+			graph.Producers[resourceName] = append(graph.Producers[resourceName], recipe)
+		}
+	}
+	return &graph, nil
+}
+
+// candidates returns every recipe and machine pair allowed by options.
+func (g *recipeGraph) candidates(options CalculationOptions) []recipeCandidate {
+	result := []recipeCandidate{}
+	for _, recipe := range g.Recipes {
+		for _, machine := range recipe.Machines {
+			if options.allows(recipe, machine) {
+				result = append(result, recipeCandidate{Recipe: recipe, Machine: machine})
+			}
+		}
+	}
+	return result
+}
+
+// allows checks if recipe and machine are allowed by options. Default recipes and machines are always allowed.
+func (o CalculationOptions) allows(recipe *graphRecipe, machine *graphMachine) bool {
+	return (recipe.DefaultChoice || slices.Contains(o.RecipesNames, recipe.Name)) &&
+		(machine.DefaultChoice || slices.Contains(o.MachinesNames, machine.Name))
+}
+
+// machine returns machine with provided name, or nil if no recipe can be run on such machine.
+func (g *recipeGraph) machine(machineName string) *graphMachine {
+	for _, recipe := range g.Recipes {
+		for _, machine := range recipe.Machines {
+			if machine.Name == machineName {
+				return machine
+			}
+		}
+	}
+	return nil
+}
+
+func (c recipeCandidate) cyclesPerSecond() float64 {
+	return c.Machine.Speed / c.Recipe.ProductionTimeS
+}
+
+func (c recipeCandidate) inputRate(resourceName string) float64 {
+	return c.Recipe.Inputs[resourceName] * c.cyclesPerSecond()
+}
+
+func (c recipeCandidate) outputRate(resourceName string) float64 {
+	return c.Recipe.Outputs[resourceName] * c.cyclesPerSecond()
+}
+
+// netRate returns amount of resource produced minus amount consumed per second by a single machine.
+func (c recipeCandidate) netRate(resourceName string) float64 {
+	return c.outputRate(resourceName) - c.inputRate(resourceName)
+}
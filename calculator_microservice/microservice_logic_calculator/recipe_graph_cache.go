@@ -0,0 +1,82 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+)
+
+// RecipeGraphCache keeps recipe graphs of users in memory, so that calculations do not query the database.
+// Graphs expire after ttl and can be invalidated explicitly after user data changes.
+type RecipeGraphCache struct {
+	db      *sql.DB
+	ttl     time.Duration
+	mutex   sync.Mutex
+	entries map[int]*recipeGraphCacheEntry
+	// generations are increased on every invalidation, so that graphs loaded before invalidation are not stored
+	generations map[int]uint64
+}
+
+type recipeGraphCacheEntry struct {
+	graph    *recipeGraph
+	loadedAt time.Time
+}
+
+// NewRecipeGraphCache creates cache loading recipe graphs from db. Non positive ttl disables caching.
+func NewRecipeGraphCache(db *sql.DB, ttl time.Duration) *RecipeGraphCache {
+	return &RecipeGraphCache{db: db, ttl: ttl, entries: make(map[int]*recipeGraphCacheEntry), generations: make(map[int]uint64)}
+}
+
+// Invalidate removes cached recipe graph of user.
+func (c *RecipeGraphCache) Invalidate(userId int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.entries, userId)
+	c.generations[userId]++
+}
+
+func (c *RecipeGraphCache) graph(ctx context.Context, userId int) (*recipeGraph, error) {
+	c.mutex.Lock()
+	entry, exists := c.entries[userId]
+	generation := c.generations[userId]
+	c.mutex.Unlock()
+	if exists && time.Since(entry.loadedAt) < c.ttl {
+		return entry.graph, nil
+	}
+
+	graph, err := loadRecipeGraph(ctx, userId, c.db)
+	if err != nil {
+		return nil, err
+	}
+	if c.ttl <= 0 {
+		return graph, nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.generations[userId] == generation {
+		c.entries[userId] = &recipeGraphCacheEntry{graph: graph, loadedAt: time.Now()}
+	}
+	for cachedUserId, cachedEntry := range c.entries {
+		if time.Since(cachedEntry.loadedAt) >= c.ttl {
+			delete(c.entries, cachedUserId)
+		}
+	}
+	return graph, nil
+}
@@ -0,0 +1,107 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// graphUnlockTier is a step of progression of the user. Recipes and machines assigned to it can be used
+// once current tier of the user reaches TierNumber.
+type graphUnlockTier struct {
+	Id         uint
+	Name       string
+	TierNumber uint
+}
+
+// lockedPairing is a recipe and a machine assigned to each other, which cannot be used yet, because the recipe,
+// the machine or both belong to unlock tiers above current tier of the user.
+type lockedPairing struct {
+	Recipe  *graphRecipe
+	Machine *graphMachine
+	Unlocks []*graphUnlockTier
+}
+
+// loadProgression retrieves unlock tiers of the user by id together with current tier of the user.
+// Current tier is negative if the user has not set it, in which case everything is unlocked.
+func loadProgression(ctx context.Context, userId int, db *sql.DB) (map[uint]*graphUnlockTier, int64, error) {
+	unlockTiers := make(map[uint]*graphUnlockTier)
+	rows, err := db.QueryContext(ctx, `SELECT id, name, tier_number FROM unlock_tiers WHERE users_id = ?;`, userId)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not retrieve unlock tiers: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var unlockTier graphUnlockTier
+		err = rows.Scan(&unlockTier.Id, &unlockTier.Name, &unlockTier.TierNumber)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not parse unlock tiers: %w", err)
+		}
+		unlockTiers[unlockTier.Id] = &unlockTier
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("could not retrieve unlock tiers: %w", err)
+	}
+
+	var currentTier int64
+	err = db.QueryRowContext(ctx, `SELECT current_tier FROM progress WHERE users_id = ?;`, userId).Scan(&currentTier)
+	if errors.Is(err, sql.ErrNoRows) {
+		return unlockTiers, -1, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not retrieve progress: %w", err)
+	}
+	return unlockTiers, currentTier, nil
+}
+
+// missingUnlocks returns unlock tiers of recipe and machine which are above current tier.
+func missingUnlocks(recipe *graphRecipe, machine *graphMachine, currentTier int64) []*graphUnlockTier {
+	result := []*graphUnlockTier{}
+	if currentTier < 0 {
+		return result
+	}
+	for _, unlockTier := range []*graphUnlockTier{recipe.UnlockTier, machine.UnlockTier} {
+		if unlockTier != nil && int64(unlockTier.TierNumber) > currentTier && (len(result) == 0 || result[0] != unlockTier) {
+			result = append(result, unlockTier)
+		}
+	}
+	return result
+}
+
+// lockedProducers returns locked pairings of recipes producing resource, which would be allowed by options.
+func (g *recipeGraph) lockedProducers(resourceName string, options CalculationOptions) []lockedPairing {
+	result := []lockedPairing{}
+	for _, pairing := range g.LockedPairings {
+		if pairing.Recipe.Outputs[resourceName] > 0 && options.allows(pairing.Recipe, pairing.Machine) {
+			result = append(result, pairing)
+		}
+	}
+	return result
+}
+
+// describeUnlocks names unlock tiers missing to use a locked pairing, e.g. "requires unlocking 'tier_2' at tier 2".
+func (p lockedPairing) describeUnlocks() string {
+	descriptions := []string{}
+	for _, unlockTier := range p.Unlocks {
+		descriptions = append(descriptions, fmt.Sprintf("'%s' at tier %d", unlockTier.Name, unlockTier.TierNumber))
+	}
+	return "requires unlocking " + strings.Join(descriptions, " and ")
+}
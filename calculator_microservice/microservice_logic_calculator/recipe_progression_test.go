@@ -0,0 +1,179 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// progressionRecipeGraph returns slots recipe graph extended with steel made of iron ore on foundry, which
+// requires unlocking tier_2.
+func progressionRecipeGraph() *recipeGraph {
+	graph := slotsRecipeGraph()
+	tier := &graphUnlockTier{Id: 2, Name: "tier_2", TierNumber: 2}
+	foundry := &graphMachine{Id: 6, Name: "foundry", InputsSolid: 2, OutputsSolid: 1, Speed: 1, PowerConsumptionKw: 16000, DefaultChoice: true, UnlockTier: tier}
+	steel := &graphRecipe{Id: 10, Name: "foundry_steel", ProductionTimeS: 60, DefaultChoice: true, Inputs: map[string]float64{"iron_ore": 45}, Outputs: map[string]float64{"steel": 45}}
+	graph.Recipes = append(graph.Recipes, steel)
+	graph.Producers["steel"] = append(graph.Producers["steel"], steel)
+	graph.LockedPairings = append(graph.LockedPairings, lockedPairing{Recipe: steel, Machine: foundry, Unlocks: missingUnlocks(steel, foundry, 1)})
+	return graph
+}
+
+func TestMissingUnlocks(t *testing.T) {
+	firstTier := &graphUnlockTier{Id: 1, Name: "tier_1", TierNumber: 1}
+	secondTier := &graphUnlockTier{Id: 2, Name: "tier_2", TierNumber: 2}
+	tests := []struct {
+		name          string
+		recipeTier    *graphUnlockTier
+		machineTier   *graphUnlockTier
+		currentTier   int64
+		expected      []*graphUnlockTier
+		expectedLabel string
+	}{
+		{
+			name:        "progress not set",
+			recipeTier:  secondTier,
+			machineTier: secondTier,
+			currentTier: -1,
+			expected:    []*graphUnlockTier{},
+		},
+		{
+			name:        "no unlock tiers",
+			currentTier: 0,
+			expected:    []*graphUnlockTier{},
+		},
+		{
+			name:        "unlocked tiers",
+			recipeTier:  firstTier,
+			machineTier: secondTier,
+			currentTier: 2,
+			expected:    []*graphUnlockTier{},
+		},
+		{
+			name:          "locked recipe",
+			recipeTier:    secondTier,
+			machineTier:   firstTier,
+			currentTier:   1,
+			expected:      []*graphUnlockTier{secondTier},
+			expectedLabel: "requires unlocking 'tier_2' at tier 2",
+		},
+		{
+			name:          "recipe and machine locked by the same tier",
+			recipeTier:    secondTier,
+			machineTier:   secondTier,
+			currentTier:   0,
+			expected:      []*graphUnlockTier{secondTier},
+			expectedLabel: "requires unlocking 'tier_2' at tier 2",
+		},
+		{
+			name:          "recipe and machine locked by different tiers",
+			recipeTier:    firstTier,
+			machineTier:   secondTier,
+			currentTier:   0,
+			expected:      []*graphUnlockTier{firstTier, secondTier},
+			expectedLabel: "requires unlocking 'tier_1' at tier 1 and 'tier_2' at tier 2",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recipe := &graphRecipe{Name: "steel", UnlockTier: test.recipeTier}
+			machine := &graphMachine{Name: "foundry", UnlockTier: test.machineTier}
+			unlocks := missingUnlocks(recipe, machine, test.currentTier)
+			if !reflect.DeepEqual(unlocks, test.expected) {
+				t.Fatalf("expected %d missing unlock tiers, got %d", len(test.expected), len(unlocks))
+			}
+			if len(unlocks) == 0 {
+				return
+			}
+			label := lockedPairing{Recipe: recipe, Machine: machine, Unlocks: unlocks}.describeUnlocks()
+			if label != test.expectedLabel {
+				t.Fatalf("expected description %q, got %q", test.expectedLabel, label)
+			}
+		})
+	}
+}
+
+func TestNoRecipeErrorListsLockedPairings(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     CalculationOptions
+		expected    string
+		notExpected string
+	}{
+		{
+			name:     "locked pairing",
+			options:  CalculationOptions{},
+			expected: "could not find recipe for 'steel', locked pairings: 'foundry_steel' on 'foundry' (requires unlocking 'tier_2' at tier 2)",
+		},
+		{
+			name:     "rejected and locked pairings",
+			options:  CalculationOptions{RecipesNames: []string{"steel"}},
+			expected: "could not find recipe for 'steel', rejected pairings: 'steel' on 'smelter' (recipe needs 2 solid inputs, machine has 1); locked pairings: 'foundry_steel' on 'foundry' (requires unlocking 'tier_2' at tier 2)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := computeGreedyProductionTree(progressionRecipeGraph(), "steel", 1, test.options)
+			if err == nil {
+				t.Fatalf("expected error, got none")
+			}
+			if !strings.Contains(err.Error(), test.expected) {
+				t.Fatalf("expected error to contain %q, got %q", test.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestNoRecipeErrorSkipsLockedPairingsNotAllowed(t *testing.T) {
+	graph := progressionRecipeGraph()
+	graph.LockedPairings[0].Machine.DefaultChoice = false
+	_, err := computeGreedyProductionTree(graph, "steel", 1, CalculationOptions{})
+	if err == nil {
+		t.Fatalf("expected error, got none")
+	}
+	if strings.Contains(err.Error(), "locked pairings") {
+		t.Fatalf("expected error not to list pairings not allowed by options, got %q", err.Error())
+	}
+}
+
+func TestExplainLockedPairings(t *testing.T) {
+	graph := progressionRecipeGraph()
+	// unlocked recipe of steel lets the tree be computed, locked foundry steel remains a candidate for its node
+	smeltedSteel := &graphRecipe{Id: 11, Name: "smelted_steel", ProductionTimeS: 60, DefaultChoice: true, Inputs: map[string]float64{"iron_ore": 60}, Outputs: map[string]float64{"steel": 30}, Machines: []*graphMachine{graph.machine("smelter")}}
+	graph.Recipes = append(graph.Recipes, smeltedSteel)
+	graph.Producers["steel"] = append(graph.Producers["steel"], smeltedSteel)
+	tree, err := computeGreedyProductionTree(graph, "steel", 1, CalculationOptions{Explain: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, node := range tree.TreeNodes {
+		if node.Explanation.Resource != "steel" {
+			continue
+		}
+		for _, candidate := range node.Explanation.Candidates {
+			if candidate.RecipeName == "foundry_steel" {
+				if candidate.Rejection != "locked, requires unlocking 'tier_2' at tier 2" {
+					t.Fatalf("unexpected rejection of locked candidate %q", candidate.Rejection)
+				}
+				return
+			}
+		}
+	}
+	t.Fatalf("expected locked pairing among candidates for steel")
+}
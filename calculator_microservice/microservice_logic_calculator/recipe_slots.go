@@ -0,0 +1,132 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RejectedPairing is a recipe and a machine assigned to each other, which are not used together because
+// the machine does not have enough input or output slots for the recipe.
+type RejectedPairing struct {
+	RecipeName  string
+	MachineName string
+	Reason      string
+}
+
+type rejectedPairing struct {
+	Recipe  *graphRecipe
+	Machine *graphMachine
+	Reason  string
+}
+
+// slotsViolations describes every kind of slots of machine that cannot fit recipe. Each distinct solid or liquid
+// input and output of the recipe needs its own slot of the machine.
+func (g *recipeGraph) slotsViolations(recipe *graphRecipe, machine *graphMachine) []string {
+	var inputsSolid, inputsLiquid, outputsSolid, outputsLiquid uint
+	for resourceName := range recipe.Inputs {
+		if g.isLiquid(resourceName) {
+			inputsLiquid++
+		} else {
+			inputsSolid++
+		}
+	}
+	for resourceName := range recipe.Outputs {
+		if g.isLiquid(resourceName) {
+			outputsLiquid++
+		} else {
+			outputsSolid++
+		}
+	}
+	violations := []string{}
+	check := func(slots string, needed uint, available uint) {
+		if needed > available {
+			violations = append(violations, fmt.Sprintf("recipe needs %d %s, machine has %d", needed, slots, available))
+		}
+	}
+	check("solid inputs", inputsSolid, machine.InputsSolid)
+	check("liquid inputs", inputsLiquid, machine.InputsLiquid)
+	check("solid outputs", outputsSolid, machine.OutputsSolid)
+	check("liquid outputs", outputsLiquid, machine.OutputsLiquid)
+	return violations
+}
+
+func (g *recipeGraph) isLiquid(resourceName string) bool {
+	resource, exists := g.Resources[resourceName]
+	return exists && resource.Liquid
+}
+
+// rejectedProducers returns pairings rejected for recipes producing resource, which would be allowed by options.
+func (g *recipeGraph) rejectedProducers(resourceName string, options CalculationOptions) []rejectedPairing {
+	result := []rejectedPairing{}
+	for _, pairing := range g.RejectedPairings {
+		if pairing.Recipe.Outputs[resourceName] > 0 && options.allows(pairing.Recipe, pairing.Machine) {
+			result = append(result, pairing)
+		}
+	}
+	return result
+}
+
+// noRecipeError reports that no recipe can produce resource, listing pairings rejected for its recipes
+// and locked pairings together with unlock tiers they require.
+func (g *recipeGraph) noRecipeError(resourceName string, options CalculationOptions) error {
+	reasons := []string{}
+	rejected := g.rejectedProducers(resourceName, options)
+	if len(rejected) > 0 {
+		descriptions := []string{}
+		for _, pairing := range rejected {
+			descriptions = append(descriptions, fmt.Sprintf("'%s' on '%s' (%s)", pairing.Recipe.Name, pairing.Machine.Name, pairing.Reason))
+		}
+		reasons = append(reasons, "rejected pairings: "+strings.Join(descriptions, ", "))
+	}
+	locked := g.lockedProducers(resourceName, options)
+	if len(locked) > 0 {
+		descriptions := []string{}
+		for _, pairing := range locked {
+			descriptions = append(descriptions, fmt.Sprintf("'%s' on '%s' (%s)", pairing.Recipe.Name, pairing.Machine.Name, pairing.describeUnlocks()))
+		}
+		reasons = append(reasons, "locked pairings: "+strings.Join(descriptions, ", "))
+	}
+	if len(reasons) == 0 {
+		return fmt.Errorf("could not find recipe for '%s'", resourceName)
+	}
+	return fmt.Errorf("could not find recipe for '%s', %s", resourceName, strings.Join(reasons, "; "))
+}
+
+// setRejectedPairings lists pairings rejected for recipes producing resources of production tree.
+func (t *ProductionTree) setRejectedPairings(graph *recipeGraph, options CalculationOptions) {
+	t.RejectedPairings = []*RejectedPairing{}
+	resources := sortedKeys(t.ResourceUnits)
+	for _, target := range t.Targets {
+		if !slices.Contains(resources, target.Resource) {
+			resources = append(resources, target.Resource)
+		}
+	}
+	for _, pairing := range graph.RejectedPairings {
+		if !options.allows(pairing.Recipe, pairing.Machine) {
+			continue
+		}
+		for _, resourceName := range resources {
+			if pairing.Recipe.Outputs[resourceName] > 0 {
+				t.RejectedPairings = append(t.RejectedPairings, &RejectedPairing{RecipeName: pairing.Recipe.Name, MachineName: pairing.Machine.Name, Reason: pairing.Reason})
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// slotsRecipeGraph returns test recipe graph extended with recipes, whose pairings with their machines are rejected:
+// screws made of iron rods and water on constructor and alternative steel made of iron ore and coal on smelter.
+func slotsRecipeGraph() *recipeGraph {
+	graph := testRecipeGraph()
+	graph.Resources["water"] = &graphResource{Name: "water", Liquid: true}
+	graph.Resources["steel"] = &graphResource{Name: "steel"}
+	wetScrew := &graphRecipe{Id: 8, Name: "wet_screw", ProductionTimeS: 60, DefaultChoice: true, Inputs: map[string]float64{"iron_rod": 10, "water": 10}, Outputs: map[string]float64{"screw": 60}}
+	steel := &graphRecipe{Id: 9, Name: "steel", ProductionTimeS: 60, Inputs: map[string]float64{"iron_ore": 30, "coal": 30}, Outputs: map[string]float64{"steel": 30}}
+	graph.Recipes = append(graph.Recipes, wetScrew, steel)
+	graph.Producers["screw"] = append(graph.Producers["screw"], wetScrew)
+	graph.Producers["steel"] = []*graphRecipe{steel}
+	for _, pairing := range []rejectedPairing{{Recipe: wetScrew, Machine: graph.machine("constructor")}, {Recipe: steel, Machine: graph.machine("smelter")}} {
+		pairing.Reason = strings.Join(graph.slotsViolations(pairing.Recipe, pairing.Machine), " and ")
+		graph.RejectedPairings = append(graph.RejectedPairings, pairing)
+	}
+	return graph
+}
+
+func TestSlotsViolations(t *testing.T) {
+	tests := []struct {
+		name     string
+		inputs   map[string]float64
+		outputs  map[string]float64
+		machine  *graphMachine
+		expected []string
+	}{
+		{
+			name:     "fitting recipe",
+			inputs:   map[string]float64{"iron_ore": 30},
+			outputs:  map[string]float64{"iron_ingot": 30},
+			machine:  &graphMachine{InputsSolid: 1, OutputsSolid: 1},
+			expected: []string{},
+		},
+		{
+			name:     "too many solid inputs",
+			inputs:   map[string]float64{"iron_ore": 30, "coal": 30},
+			outputs:  map[string]float64{"steel": 30},
+			machine:  &graphMachine{InputsSolid: 1, OutputsSolid: 1},
+			expected: []string{"recipe needs 2 solid inputs, machine has 1"},
+		},
+		{
+			name:     "liquid input and output",
+			inputs:   map[string]float64{"iron_rod": 10, "water": 10},
+			outputs:  map[string]float64{"screw": 60, "water": 5},
+			machine:  &graphMachine{InputsSolid: 1, OutputsSolid: 1},
+			expected: []string{"recipe needs 1 liquid inputs, machine has 0", "recipe needs 1 liquid outputs, machine has 0"},
+		},
+		{
+			name:     "liquid slots of machine",
+			inputs:   map[string]float64{"water": 10},
+			outputs:  map[string]float64{"iron_plate": 20, "water": 5},
+			machine:  &graphMachine{InputsLiquid: 1, OutputsSolid: 1, OutputsLiquid: 1},
+			expected: []string{},
+		},
+	}
+	graph := slotsRecipeGraph()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recipe := &graphRecipe{Name: "tested", Inputs: test.inputs, Outputs: test.outputs}
+			violations := graph.slotsViolations(recipe, test.machine)
+			if !reflect.DeepEqual(violations, test.expected) {
+				t.Fatalf("expected violations %v, got %v", test.expected, violations)
+			}
+		})
+	}
+}
+
+func TestNoRecipeErrorListsRejectedPairings(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     CalculationOptions
+		expected    string
+		notExpected string
+	}{
+		{
+			name:     "allowed pairing",
+			options:  CalculationOptions{RecipesNames: []string{"steel"}},
+			expected: "could not find recipe for 'steel', rejected pairings: 'steel' on 'smelter' (recipe needs 2 solid inputs, machine has 1)",
+		},
+		{
+			name:        "pairing not allowed by options",
+			options:     CalculationOptions{},
+			expected:    "could not find recipe for 'steel'",
+			notExpected: "rejected pairings",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := computeGreedyProductionTree(slotsRecipeGraph(), "steel", 1, test.options)
+			if err == nil {
+				t.Fatalf("expected error, got none")
+			}
+			if !strings.Contains(err.Error(), test.expected) {
+				t.Fatalf("expected error to contain %q, got %q", test.expected, err.Error())
+			}
+			if test.notExpected != "" && strings.Contains(err.Error(), test.notExpected) {
+				t.Fatalf("expected error not to contain %q, got %q", test.notExpected, err.Error())
+			}
+		})
+	}
+}
+
+func TestSetRejectedPairings(t *testing.T) {
+	tree, err := computeGreedyProductionTree(slotsRecipeGraph(), "screw", 1, CalculationOptions{RecipesNames: []string{"steel"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, node := range tree.TreeNodes {
+		if node.RecipeName == "wet_screw" {
+			t.Fatalf("expected rejected recipe not to be used")
+		}
+	}
+	// steel is not produced in the tree, so its rejected pairing is not listed
+	expected := []*RejectedPairing{{RecipeName: "wet_screw", MachineName: "constructor", Reason: "recipe needs 1 liquid inputs, machine has 0"}}
+	if len(tree.RejectedPairings) != len(expected) {
+		t.Fatalf("expected %d rejected pairings, got %d", len(expected), len(tree.RejectedPairings))
+	}
+	for i, pairing := range tree.RejectedPairings {
+		if !reflect.DeepEqual(pairing, expected[i]) {
+			t.Fatalf("expected rejected pairing %d to be %+v, got %+v", i, expected[i], pairing)
+		}
+	}
+}
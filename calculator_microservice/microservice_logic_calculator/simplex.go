@@ -0,0 +1,234 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"errors"
+	"math"
+)
+
+const simplexEpsilon = 1e-9
+
+var errLinearProgramInfeasible = errors.New("linear program has no feasible solution")
+var errLinearProgramUnbounded = errors.New("linear program is unbounded")
+
+type constraintKind int
+
+const (
+	lessOrEqual constraintKind = iota
+	greaterOrEqual
+	equal
+)
+
+type linearConstraint struct {
+	Coefficients []float64
+	Kind         constraintKind
+	Bound        float64
+}
+
+type simplexTableau struct {
+	rows       [][]float64
+	basis      []int
+	rhs        int
+	artificial int
+}
+
+// solveLinearProgram minimizes objective*x subject to constraints and x >= 0 using two phase simplex method.
+// Bland's rule is used for choosing pivots, so degenerate programs do not cycle.
+func solveLinearProgram(objective []float64, constraints []linearConstraint) ([]float64, error) {
+	variables := len(objective)
+	slackColumns := 0
+	artificialColumns := 0
+	normalized := make([]linearConstraint, len(constraints))
+	for i, constraint := range constraints {
+		normalized[i] = linearConstraint{Coefficients: make([]float64, variables), Kind: constraint.Kind, Bound: constraint.Bound}
+		copy(normalized[i].Coefficients, constraint.Coefficients)
+		if normalized[i].Bound < 0 {
+			for j := range normalized[i].Coefficients {
+				normalized[i].Coefficients[j] = -normalized[i].Coefficients[j]
+			}
+			normalized[i].Bound = -normalized[i].Bound
+			switch normalized[i].Kind {
+			case lessOrEqual:
+				normalized[i].Kind = greaterOrEqual
+			case greaterOrEqual:
+				normalized[i].Kind = lessOrEqual
+			}
+		}
+		if normalized[i].Kind != equal {
+			slackColumns++
+		}
+		if normalized[i].Kind != lessOrEqual {
+			artificialColumns++
+		}
+	}
+
+	tableau := simplexTableau{
+		rows:       make([][]float64, len(normalized)),
+		basis:      make([]int, len(normalized)),
+		rhs:        variables + slackColumns + artificialColumns,
+		artificial: variables + slackColumns,
+	}
+	nextSlack := variables
+	nextArtificial := tableau.artificial
+	for i, constraint := range normalized {
+		row := make([]float64, tableau.rhs+1)
+		copy(row, constraint.Coefficients)
+		row[tableau.rhs] = constraint.Bound
+		switch constraint.Kind {
+		case lessOrEqual:
+			row[nextSlack] = 1
+			tableau.basis[i] = nextSlack
+			nextSlack++
+		case greaterOrEqual:
+			row[nextSlack] = -1
+			nextSlack++
+			row[nextArtificial] = 1
+			tableau.basis[i] = nextArtificial
+			nextArtificial++
+		case equal:
+			row[nextArtificial] = 1
+			tableau.basis[i] = nextArtificial
+			nextArtificial++
+		}
+		tableau.rows[i] = row
+	}
+
+	if artificialColumns > 0 {
+		phaseOneCosts := make([]float64, tableau.rhs)
+		for j := tableau.artificial; j < tableau.rhs; j++ {
+			phaseOneCosts[j] = 1
+		}
+		err := tableau.minimize(phaseOneCosts, tableau.rhs)
+		if err != nil {
+			return nil, err
+		}
+		if tableau.objectiveValue(phaseOneCosts) > simplexEpsilon*math.Max(1, tableau.largestBound()) {
+			return nil, errLinearProgramInfeasible
+		}
+		tableau.removeArtificialVariablesFromBasis()
+	}
+
+	phaseTwoCosts := make([]float64, tableau.rhs)
+	copy(phaseTwoCosts, objective)
+	err := tableau.minimize(phaseTwoCosts, tableau.artificial)
+	if err != nil {
+		return nil, err
+	}
+	solution := make([]float64, variables)
+	for i, column := range tableau.basis {
+		if column < variables {
+			solution[column] = math.Max(0, tableau.rows[i][tableau.rhs])
+		}
+	}
+	return solution, nil
+}
+
+// minimize pivots the tableau until no column below allowedColumns can lower the objective.
+func (t *simplexTableau) minimize(costs []float64, allowedColumns int) error {
+	for {
+		entering := -1
+		for j := 0; j < allowedColumns; j++ {
+			if t.isBasic(j) {
+				continue
+			}
+			reducedCost := costs[j]
+			for i, column := range t.basis {
+				reducedCost -= costs[column] * t.rows[i][j]
+			}
+			if reducedCost < -simplexEpsilon {
+				entering = j
+				break
+			}
+		}
+		if entering == -1 {
+			return nil
+		}
+		leaving := -1
+		bestRatio := math.Inf(1)
+		for i, row := range t.rows {
+			if row[entering] <= simplexEpsilon {
+				continue
+			}
+			ratio := row[t.rhs] / row[entering]
+			if ratio < bestRatio-simplexEpsilon || (math.Abs(ratio-bestRatio) <= simplexEpsilon && t.basis[i] < t.basis[leaving]) {
+				bestRatio = ratio
+				leaving = i
+			}
+		}
+		if leaving == -1 {
+			return errLinearProgramUnbounded
+		}
+		t.pivot(leaving, entering)
+	}
+}
+
+func (t *simplexTableau) pivot(pivotRow int, pivotColumn int) {
+	divisor := t.rows[pivotRow][pivotColumn]
+	for j := range t.rows[pivotRow] {
+		t.rows[pivotRow][j] /= divisor
+	}
+	for i, row := range t.rows {
+		if i == pivotRow || row[pivotColumn] == 0 {
+			continue
+		}
+		factor := row[pivotColumn]
+		for j := range row {
+			row[j] -= factor * t.rows[pivotRow][j]
+		}
+	}
+	t.basis[pivotRow] = pivotColumn
+}
+
+func (t *simplexTableau) removeArtificialVariablesFromBasis() {
+	for i, column := range t.basis {
+		if column < t.artificial {
+			continue
+		}
+		for j := 0; j < t.artificial; j++ {
+			if math.Abs(t.rows[i][j]) > simplexEpsilon {
+				t.pivot(i, j)
+				break
+			}
+		}
+	}
+}
+
+func (t *simplexTableau) isBasic(column int) bool {
+	for _, basic := range t.basis {
+		if basic == column {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *simplexTableau) objectiveValue(costs []float64) float64 {
+	value := 0.0
+	for i, column := range t.basis {
+		value += costs[column] * t.rows[i][t.rhs]
+	}
+	return value
+}
+
+func (t *simplexTableau) largestBound() float64 {
+	largest := 0.0
+	for _, row := range t.rows {
+		largest = math.Max(largest, math.Abs(row[t.rhs]))
+	}
+	return largest
+}
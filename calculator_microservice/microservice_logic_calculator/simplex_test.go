@@ -0,0 +1,216 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package microservicelogiccalculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSolveLinearProgram(t *testing.T) {
+	tests := []struct {
+		name        string
+		objective   []float64
+		constraints []linearConstraint
+		expected    []float64
+		expectedErr error
+	}{
+		{
+			name:      "feasible optimum",
+			objective: []float64{-3, -2},
+			constraints: []linearConstraint{
+				{Coefficients: []float64{1, 1}, Kind: lessOrEqual, Bound: 4},
+				{Coefficients: []float64{1, 3}, Kind: lessOrEqual, Bound: 6},
+			},
+			expected: []float64{4, 0},
+		},
+		{
+			name:      "greater or equal and equality constraints",
+			objective: []float64{1, 1},
+			constraints: []linearConstraint{
+				{Coefficients: []float64{1, 1}, Kind: greaterOrEqual, Bound: 2},
+				{Coefficients: []float64{1, -1}, Kind: equal, Bound: 1},
+			},
+			expected: []float64{1.5, 0.5},
+		},
+		{
+			name:      "negative bound",
+			objective: []float64{1, 1},
+			constraints: []linearConstraint{
+				{Coefficients: []float64{-1, -2}, Kind: lessOrEqual, Bound: -4},
+			},
+			expected: []float64{0, 2},
+		},
+		{
+			name:      "infeasible",
+			objective: []float64{1},
+			constraints: []linearConstraint{
+				{Coefficients: []float64{1}, Kind: lessOrEqual, Bound: 1},
+				{Coefficients: []float64{1}, Kind: greaterOrEqual, Bound: 2},
+			},
+			expectedErr: errLinearProgramInfeasible,
+		},
+		{
+			name:      "unbounded",
+			objective: []float64{-1, 0},
+			constraints: []linearConstraint{
+				{Coefficients: []float64{1, -1}, Kind: greaterOrEqual, Bound: 2},
+			},
+			expectedErr: errLinearProgramUnbounded,
+		},
+		{
+			// Beale's example cycles when the most negative reduced cost chooses entering variable
+			name:      "degenerate program prone to cycling",
+			objective: []float64{-0.75, 150, -0.02, 6},
+			constraints: []linearConstraint{
+				{Coefficients: []float64{0.25, -60, -0.04, 9}, Kind: lessOrEqual, Bound: 0},
+				{Coefficients: []float64{0.5, -90, -0.02, 3}, Kind: lessOrEqual, Bound: 0},
+				{Coefficients: []float64{0, 0, 1, 0}, Kind: lessOrEqual, Bound: 1},
+			},
+			expected: []float64{0.04, 0, 1, 0},
+		},
+		{
+			// second constraint is redundant, its artificial variable stays in basis at zero after phase one
+			name:      "redundant equality constraints",
+			objective: []float64{1, 2},
+			constraints: []linearConstraint{
+				{Coefficients: []float64{1, 1}, Kind: equal, Bound: 2},
+				{Coefficients: []float64{2, 2}, Kind: equal, Bound: 4},
+			},
+			expected: []float64{2, 0},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			solution, err := solveLinearProgram(test.objective, test.constraints)
+			if test.expectedErr != nil {
+				if !errors.Is(err, test.expectedErr) {
+					t.Fatalf("expected error %v, got %v", test.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(solution) != len(test.expected) {
+				t.Fatalf("expected solution %v, got %v", test.expected, solution)
+			}
+			for i := range solution {
+				if math.Abs(solution[i]-test.expected[i]) > 1e-6 {
+					t.Fatalf("expected solution %v, got %v", test.expected, solution)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveArtificialVariablesFromBasis(t *testing.T) {
+	// columns: x, y and two artificial variables; first artificial variable is basic at zero with nonzero
+	// coefficient of x, second belongs to a redundant row with zero coefficients of x and y
+	tableau := simplexTableau{
+		rows: [][]float64{
+			{1, 0, 1, 0, 0},
+			{0, 1, 0, 0, 2},
+			{0, 0, 0, 1, 0},
+		},
+		basis:      []int{2, 1, 3},
+		rhs:        4,
+		artificial: 2,
+	}
+	tableau.removeArtificialVariablesFromBasis()
+	if tableau.basis[0] != 0 {
+		t.Fatalf("expected artificial variable of first row to be replaced by column 0, basis is %v", tableau.basis)
+	}
+	if tableau.basis[2] != 3 {
+		t.Fatalf("expected artificial variable of redundant row to stay in basis, basis is %v", tableau.basis)
+	}
+}
+
+func testRecipeGraph() *recipeGraph {
+	graph := &recipeGraph{Resources: map[string]*graphResource{}, Producers: map[string][]*graphRecipe{}}
+	machines := map[string]*graphMachine{
+		"miner":       {Id: 1, Name: "miner", OutputsSolid: 1, Speed: 1, PowerConsumptionKw: 5000, DefaultChoice: true},
+		"smelter":     {Id: 2, Name: "smelter", InputsSolid: 1, OutputsSolid: 1, Speed: 1, PowerConsumptionKw: 4000, DefaultChoice: true},
+		"constructor": {Id: 3, Name: "constructor", InputsSolid: 1, OutputsSolid: 1, Speed: 1, PowerConsumptionKw: 4000, DefaultChoice: true},
+		"assembler":   {Id: 4, Name: "assembler", InputsSolid: 2, OutputsSolid: 1, Speed: 1, PowerConsumptionKw: 15000, DefaultChoice: true},
+	}
+	addRecipe := func(id uint, name string, defaultChoice bool, inputs map[string]float64, outputs map[string]float64, machine string) {
+		recipe := &graphRecipe{Id: id, Name: name, ProductionTimeS: 60, DefaultChoice: defaultChoice, Inputs: inputs, Outputs: outputs, Machines: []*graphMachine{machines[machine]}}
+		graph.Recipes = append(graph.Recipes, recipe)
+		for resource := range outputs {
+			graph.Producers[resource] = append(graph.Producers[resource], recipe)
+			graph.Resources[resource] = &graphResource{Name: resource}
+		}
+	}
+	addRecipe(1, "iron_ore", true, map[string]float64{}, map[string]float64{"iron_ore": 60}, "miner")
+	addRecipe(2, "iron_ingot", true, map[string]float64{"iron_ore": 30}, map[string]float64{"iron_ingot": 30}, "smelter")
+	addRecipe(3, "iron_plate", true, map[string]float64{"iron_ingot": 30}, map[string]float64{"iron_plate": 20}, "constructor")
+	addRecipe(4, "iron_rod", true, map[string]float64{"iron_ingot": 15}, map[string]float64{"iron_rod": 15}, "constructor")
+	addRecipe(5, "screw", true, map[string]float64{"iron_rod": 10}, map[string]float64{"screw": 40}, "constructor")
+	addRecipe(6, "reinforced_iron_plate", true, map[string]float64{"iron_plate": 30, "screw": 60}, map[string]float64{"reinforced_iron_plate": 5}, "assembler")
+	addRecipe(7, "cast_screw", false, map[string]float64{"iron_ingot": 12.5}, map[string]float64{"screw": 50}, "constructor")
+	return graph
+}
+
+func TestComputeOptimalProductionTree(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  CalculationOptions
+		expected map[string]uint64
+	}{
+		{
+			name:     "default recipes",
+			expected: map[string]uint64{"reinforced_iron_plate": 6, "iron_plate": 9, "screw": 9, "iron_rod": 6, "iron_ingot": 12, "iron_ore": 6},
+		},
+		{
+			name:     "allowed alternative recipe",
+			options:  CalculationOptions{RecipesNames: []string{"cast_screw"}},
+			expected: map[string]uint64{"reinforced_iron_plate": 6, "iron_plate": 9, "cast_screw": 8, "iron_ingot": 12, "iron_ore": 6},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tree, err := computeOptimalProductionTree(testRecipeGraph(), []*ProductionTarget{{Resource: "reinforced_iron_plate", Rate: 0.5}}, test.options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			machineCounts := make(map[string]uint64)
+			for _, node := range tree.TreeNodes {
+				machineCounts[node.RecipeName] += node.MachineCount
+			}
+			if len(machineCounts) != len(test.expected) {
+				t.Fatalf("expected machines %v, got %v", test.expected, machineCounts)
+			}
+			for recipe, count := range test.expected {
+				if machineCounts[recipe] != count {
+					t.Fatalf("expected machines %v, got %v", test.expected, machineCounts)
+				}
+			}
+			if len(tree.ExcessResources) != 0 {
+				t.Fatalf("expected no excess resources, got %d", len(tree.ExcessResources))
+			}
+		})
+	}
+}
+
+func TestComputeOptimalProductionTreeMissingRecipe(t *testing.T) {
+	_, err := computeOptimalProductionTree(testRecipeGraph(), []*ProductionTarget{{Resource: "copper_ore", Rate: 1}}, CalculationOptions{})
+	if err == nil {
+		t.Fatal("expected error for resource without recipe")
+	}
+}
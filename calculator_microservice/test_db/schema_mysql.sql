@@ -1,4 +1,8 @@
 DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS progress;
+DROP TABLE IF EXISTS machines_costs;
+DROP TABLE IF EXISTS unlock_tiers;
+DROP TABLE IF EXISTS transport_tiers;
 DROP TABLE IF EXISTS machines_recipes;
 DROP TABLE IF EXISTS recipes_outputs;
 DROP TABLE IF EXISTS recipes_inputs;
@@ -16,7 +20,10 @@ CREATE TABLE machines(
     outputs_liquid integer,
     speed          real,
     power_consumption_kw integer,
-    default_choice integer
+    power_clock_exponent real NOT NULL DEFAULT 1,
+    power_generation_kw integer,
+    default_choice integer,
+    unlock_tiers_id integer
 );
 
 CREATE TABLE resources(
@@ -24,7 +31,8 @@ CREATE TABLE resources(
     name            text,
     users_id        integer,
     liquid          integer,
-    resource_unit   text
+    resource_unit   text,
+    sink_value      real
 );
 
 CREATE TABLE recipes(
@@ -32,7 +40,8 @@ CREATE TABLE recipes(
     name                  text,
     users_id              integer,
     production_time_s     integer,
-    default_choice        integer
+    default_choice        integer,
+    unlock_tiers_id       integer
 );
 
 CREATE TABLE recipes_inputs(
@@ -68,4 +77,36 @@ CREATE TABLE machines_recipes(
     ON UPDATE CASCADE ON DELETE SET NULL,
     FOREIGN KEY(machines_id) REFERENCES machines(id)
     ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE TABLE transport_tiers(
+    id                    integer PRIMARY KEY AUTO_INCREMENT,
+    name                  text,
+    users_id              integer,
+    liquid                integer,
+    capacity_per_s        real
+);
+
+CREATE TABLE unlock_tiers(
+    id                    integer PRIMARY KEY AUTO_INCREMENT,
+    name                  text,
+    users_id              integer,
+    tier_number           integer
+);
+
+CREATE TABLE machines_costs(
+    id                    integer PRIMARY KEY AUTO_INCREMENT,
+    users_id              integer,
+    machines_id           integer,
+    resources_id          integer,
+    amount                integer,
+    FOREIGN KEY(machines_id) REFERENCES machines(id)
+    ON UPDATE CASCADE ON DELETE SET NULL,
+    FOREIGN KEY(resources_id) REFERENCES resources(id)
+    ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE TABLE progress(
+    users_id              integer PRIMARY KEY,
+    current_tier          integer
 );
\ No newline at end of file
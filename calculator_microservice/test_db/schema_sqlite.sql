@@ -5,6 +5,10 @@ DROP TABLE IF EXISTS recipes;
 DROP TABLE IF EXISTS recipes_inputs;
 DROP TABLE IF EXISTS recipes_outputs;
 DROP TABLE IF EXISTS machines_recipes;
+DROP TABLE IF EXISTS transport_tiers;
+DROP TABLE IF EXISTS unlock_tiers;
+DROP TABLE IF EXISTS progress;
+DROP TABLE IF EXISTS machines_costs;
 
 CREATE TABLE users(
     id            integer PRIMARY KEY AUTOINCREMENT,
@@ -23,7 +27,10 @@ CREATE TABLE machines(
     outputs_liquid integer,
     speed          real,
     power_consumption_kw integer,
+    power_clock_exponent real NOT NULL DEFAULT 1,
+    power_generation_kw integer,
     default_choice integer,
+    unlock_tiers_id integer,
     FOREIGN KEY(users_id) REFERENCES users(id)
 );
 
@@ -33,6 +40,7 @@ CREATE TABLE resources(
     users_id        integer,
     liquid          integer,
     resource_unit   text,
+    sink_value      real,
     FOREIGN KEY(users_id) REFERENCES users(id)
 );
 
@@ -42,6 +50,7 @@ CREATE TABLE recipes(
     users_id              integer,
     production_time_s     integer,
     default_choice        integer,
+    unlock_tiers_id       integer,
     FOREIGN KEY(users_id) REFERENCES users(id)
 );
 
@@ -75,4 +84,38 @@ CREATE TABLE machines_recipes(
     FOREIGN KEY(users_id) REFERENCES users(id),
     FOREIGN KEY(recipes_id) REFERENCES recipes(id),
     FOREIGN KEY(machines_id) REFERENCES machines(id)
+);
+
+CREATE TABLE transport_tiers(
+    id                    integer PRIMARY KEY AUTOINCREMENT,
+    name                  text,
+    users_id              integer,
+    liquid                integer,
+    capacity_per_s        real,
+    FOREIGN KEY(users_id) REFERENCES users(id)
+);
+
+CREATE TABLE unlock_tiers(
+    id                    integer PRIMARY KEY AUTOINCREMENT,
+    name                  text,
+    users_id              integer,
+    tier_number           integer,
+    FOREIGN KEY(users_id) REFERENCES users(id)
+);
+
+CREATE TABLE progress(
+    users_id              integer PRIMARY KEY,
+    current_tier          integer,
+    FOREIGN KEY(users_id) REFERENCES users(id)
+);
+
+CREATE TABLE machines_costs(
+    id                    integer PRIMARY KEY AUTOINCREMENT,
+    users_id              integer,
+    machines_id           integer,
+    resources_id          integer,
+    amount                integer,
+    FOREIGN KEY(users_id) REFERENCES users(id),
+    FOREIGN KEY(machines_id) REFERENCES machines(id),
+    FOREIGN KEY(resources_id) REFERENCES resources(id)
 );
\ No newline at end of file
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 )
 
 type Config struct {
@@ -32,19 +33,22 @@ type Config struct {
 	DumpStats         bool
 	TrackerCapacity   uint64
 	TrackerTimePeriod int64
+	// calculator microservices caching recipe data, informed whenever the data changes
+	CalculatorMicroservicesAddresses []string
 }
 
 func LoadConfig() Config {
 	cfg := Config{
-		DbAddress:         "127.0.0.1:3306",
-		ServerPort:        3000,
-		ServerSecretPath:  "crud_microservice_secret.pem",
-		ServerCertPath:    "crud_microservice_cert.crt",
-		Host:              "localhost",
-		TrackerCapacity:   1440,
-		TrackerTimePeriod: 60000,
-		ApiStatsFile:      "",
-		DumpStats:         true,
+		DbAddress:                        "127.0.0.1:3306",
+		ServerPort:                       3000,
+		ServerSecretPath:                 "crud_microservice_secret.pem",
+		ServerCertPath:                   "crud_microservice_cert.crt",
+		Host:                             "localhost",
+		TrackerCapacity:                  1440,
+		TrackerTimePeriod:                60000,
+		ApiStatsFile:                     "",
+		DumpStats:                        true,
+		CalculatorMicroservicesAddresses: []string{"127.0.0.1:8080"},
 	}
 	if dbAddr, exists := os.LookupEnv("MYSQL_ADDR"); exists {
 		cfg.DbAddress = dbAddr
@@ -92,5 +96,9 @@ func LoadConfig() Config {
 			}
 		}
 	}
+	if calculatorMicroservicesAddresses, exists := os.LookupEnv("CALCULATOR"); exists {
+		cfg.CalculatorMicroservicesAddresses = strings.Split(calculatorMicroservicesAddresses, ",")
+		fmt.Println("Found Calculator microservice URL list:", cfg.CalculatorMicroservicesAddresses)
+	}
 	return cfg
 }
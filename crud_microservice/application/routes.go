@@ -19,17 +19,23 @@ package application
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	"github.com/marban004/factory_games_organizer/handler"
 	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/machine"
+	machinecost "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/machine_cost"
 	machinerecipe "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/machine_recipe"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/plan"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/progress"
 	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/recipe"
 	recipeinput "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/recipe_input"
 	recipeoutput "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/recipe_output"
 	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/resource"
+	transporttier "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/transport_tier"
+	unlocktier "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/unlock_tier"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
@@ -41,8 +47,19 @@ func (a *AppCrud) loadRoutes() {
 		RecipeinputRepo:   &recipeinput.MySQLRepo{DB: a.db},
 		RecipeoutputRepo:  &recipeoutput.MySQLRepo{DB: a.db},
 		MachineRecipeRepo: &machinerecipe.MySQLRepo{DB: a.db},
+		TransportTierRepo: &transporttier.MySQLRepo{DB: a.db},
+		UnlockTierRepo:    &unlocktier.MySQLRepo{DB: a.db},
+		MachineCostRepo:   &machinecost.MySQLRepo{DB: a.db},
+		PlanRepo:          &plan.MySQLRepo{DB: a.db},
+		ProgressRepo:      &progress.MySQLRepo{DB: a.db},
 		Secret:            a.secret,
 		StatTracker:       a.statTracker,
+		CalculatorNotifier: &handler.CalculatorNotifier{
+			CalculatorMicroservicesAddresses: a.config.CalculatorMicroservicesAddresses,
+			Client: &http.Client{
+				Timeout: 5 * time.Second,
+			},
+		},
 	}
 	router := chi.NewRouter()
 
@@ -69,6 +86,17 @@ func (a *AppCrud) loadRoutes() {
 	router.Put("/", crudHandler.Update)
 	router.Delete("/", crudHandler.Delete)
 	router.Delete("/user", crudHandler.DeleteByUser)
+	router.Get("/plans", crudHandler.SelectPlans)
+	router.Post("/plans", crudHandler.InsertPlans)
+	router.Put("/plans", crudHandler.UpdatePlans)
+	router.Delete("/plans", crudHandler.DeletePlans)
+	router.Post("/plans/recompute", crudHandler.RecomputePlans)
+	router.Get("/progress", crudHandler.SelectProgress)
+	router.Put("/progress", crudHandler.UpdateProgress)
+	router.Post("/import/satisfactory", crudHandler.ImportSatisfactory)
+	router.Post("/import/factorio", crudHandler.ImportFactorio)
+	router.Get("/export", crudHandler.Export)
+	router.Post("/import", crudHandler.Import)
 	router.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL(fmt.Sprintf("https://%s:%d/swagger/doc.json", a.config.Host, a.config.ServerPort)), //The url pointing to API definition
 	))
@@ -29,7 +29,7 @@ const docTemplate = `{
                         "apiTokenAuth": []
                     }
                 ],
-                "description": "Updates data in database. Updates the records based on \"id\" field of an element in the array sent in request body. If a record with a particular id does not belong to the user who presented authentication token, then that record is not updated.",
+                "description": "Updates data in database. Updates the records based on \"id\" field of an element in the array sent in request body. If a record with a particular id does not belong to the user who presented authentication token, then that record is not updated.\nAll data is updated in one transaction, nothing is updated if any record cannot be updated.",
                 "consumes": [
                     "application/json"
                 ],
@@ -37,6 +37,12 @@ const docTemplate = `{
                     "CRUD Authorization required"
                 ],
                 "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "If true, changes are validated and numbers of affected records are reported, but changes are rolled back",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
                     {
                         "description": "Data to be updated in the database",
                         "name": "update",
@@ -80,7 +86,7 @@ const docTemplate = `{
                         "apiTokenAuth": []
                     }
                 ],
-                "description": "Insert data into database. The user to whom the ownership of records is assigned is the user who presented the authentication token.",
+                "description": "Insert data into database. The user to whom the ownership of records is assigned is the user who presented the authentication token.\nRecipes inputs, recipes outputs, machines recipes and machines costs can reference recipes, resources and machines by RecipesRef, ResourcesRef and MachinesRef fields instead of ids. A reference is resolved to the record with matching TempId inserted in the same request, otherwise to the record with matching name inserted in the same request, otherwise to the existing record of the user with matching name. All data is inserted in one transaction, nothing is inserted if any record cannot be inserted or any reference cannot be resolved.",
                 "consumes": [
                     "application/json"
                 ],
@@ -88,6 +94,12 @@ const docTemplate = `{
                     "CRUD Authorization required"
                 ],
                 "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "If true, changes are validated and numbers of affected records are reported, but changes are rolled back",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
                     {
                         "description": "Data to be inserted into database",
                         "name": "insert",
@@ -131,7 +143,7 @@ const docTemplate = `{
                         "apiTokenAuth": []
                     }
                 ],
-                "description": "Deletes data in database. Each table has it's own id list to be deleted. If a record with a particular id does not belong to the user who presented authentication token, then that record is not deleted.",
+                "description": "Deletes data in database. Each table has it's own id list to be deleted. If a record with a particular id does not belong to the user who presented authentication token, then that record is not deleted.\nRecords are deleted in one transaction, nothing is deleted if any record cannot be deleted. Recipes inputs, recipes outputs, machines recipes and machines costs are deleted before machines, resources and recipes, so that records can be deleted together with records referencing them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -139,6 +151,12 @@ const docTemplate = `{
                     "CRUD Authorization required"
                 ],
                 "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "If true, changes are validated and numbers of affected records are reported, but changes are rolled back",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
                     {
                         "description": "Data to be deleted in the database",
                         "name": "delete",
@@ -177,6 +195,45 @@ const docTemplate = `{
                 }
             }
         },
+        "/export": {
+            "get": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Return unlock tiers, transport tiers, resources, machines, recipes, production plans and current tier of the user that provided authentication token as a self-contained bundle. Records of the bundle reference each other by names instead of database ids, build costs of machines and inputs, outputs and machines of recipes are nested in records they belong to. Bundle can be imported into any account with import endpoint.",
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bundle.Bundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/health": {
             "get": {
                 "description": "Return the status of microservice and it's database. Default working state is signified by status \"up\".",
@@ -199,96 +256,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/select": {
-            "get": {
+        "/import": {
+            "post": {
                 "security": [
                     {
                         "apiTokenAuth": []
                     }
                 ],
-                "description": "Return the records from database specified by id range. Start of range and it's size is specified for each table separately. Size describes number of records to be returned. Ranges include the starting id. Records are only returned for the user that presented authentication token. If start of the range is missing for particular table, then it is assumed to be 1. If size is ommitted, then all records are retreived. If the start of a range is a record belonging to another user, then next record belonging to the user that presented a token is retreived instead.",
+                "description": "Import bundle produced by export endpoint into account of the user that provided authentication token. Bundle must be of supported version, names must be unique among records of the same kind and every referenced name must belong to a record of the bundle. Strategy decides what happens to records with names already used by the user: 'merge' updates them with data of the bundle and replaces their build costs, inputs, outputs and machines, 'replace' deletes all data of the user before import and 'skip_existing' leaves them untouched. All data is imported in one transaction, response lists numbers of inserted, updated and skipped records.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
                     "CRUD Authorization required"
                 ],
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Id of first record to be retreived from machines table",
-                        "name": "machines_id_start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of rows to be returned from machines table",
-                        "name": "machines_rows",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Id of first record to be retreived from resources table",
-                        "name": "resources_id_start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of rows to be returned from resources table",
-                        "name": "resources_rows",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Id of first record to be retreived from recipes table",
-                        "name": "recipes_id_start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of rows to be returned from recipes table",
-                        "name": "recipes_rows",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Id of first record to be retreived from recipes_inputs table",
-                        "name": "recipes_inputs_id_start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of rows to be returned from recipes_inputs table",
-                        "name": "recipes_inputs_rows",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Id of first record to be retreived from recipes_outputs table",
-                        "name": "recipes_outputs_id_start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of rows to be returned from recipes_outputs table",
-                        "name": "recipes_outputs_rows",
+                        "type": "string",
+                        "description": "Conflict strategy, one of 'merge', 'replace' or 'skip_existing', skip_existing by default",
+                        "name": "strategy",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Id of first record to be retreived from machines_recipes table",
-                        "name": "machines_recipes_id_start",
+                        "type": "boolean",
+                        "description": "If true, bundle is validated and numbers of affected records are reported, but changes are rolled back",
+                        "name": "dry_run",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of rows to be returned from machines_recipes table",
-                        "name": "machines_recipes_rows",
-                        "in": "query"
+                        "description": "Bundle to import",
+                        "name": "bundle",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bundle.Bundle"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.JSONData"
+                            "$ref": "#/definitions/handler.BundleImportResponse"
                         }
                     },
                     "400": {
@@ -312,60 +321,95 @@ const docTemplate = `{
                 }
             }
         },
-        "/selectbyid": {
-            "get": {
+        "/import/factorio": {
+            "post": {
                 "security": [
                     {
                         "apiTokenAuth": []
                     }
                 ],
-                "description": "Return the records from database specified by id. Id(s) is specified for each table in the database. If an id parameter for a particular table is omitted, the records are not retreived from that table. Each parameter can be present multiple times, in which case all records from a particular table, with those ids will be retreived and returned in an array. Data is returned for the user that provided authentication token.",
+                "description": "Import recipes, items, fluids and machines from data.raw of Factorio, as dumped by the game with --dump-data option, and insert them as recipes, resources and machines of the user that provided authentication token. Assembling machines, furnaces and rocket silos run recipes of their crafting categories, mining drills and offshore pumps get recipes extracting resources. Liquid slots of machines are derived from their fluid boxes. Recipes not crafted in any imported machine are skipped. Amounts and durations are scaled by the smallest factor making all of them whole numbers. Machines and resources with names already used by the user are not inserted, existing records are used instead. Recipes with names already used by the user are skipped. All data is inserted in one transaction, response lists numbers of inserted records and skipped entries.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
                     "CRUD Authorization required"
                 ],
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Id of machines to be retreived from database",
-                        "name": "machines_id",
+                        "type": "string",
+                        "description": "Difficulty of recipes, either 'normal' or 'expensive', normal by default",
+                        "name": "difficulty",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Id of resources to be retreived from database",
-                        "name": "resources_id",
-                        "in": "query"
+                        "description": "Contents of data.raw dump",
+                        "name": "dataRaw",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ImportResponse"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "description": "Id of recipes to be retreived from database",
-                        "name": "recipes_id",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "description": "Id of recipes inputs to be retreived from database",
-                        "name": "recipes_inputs_id",
-                        "in": "query"
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/import/satisfactory": {
+            "post": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "Id of recipes outputs to be retreived from database",
-                        "name": "recipes_outputs_id",
-                        "in": "query"
-                    },
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Import buildings, items, fluids and recipes from Docs.json file distributed with Satisfactory and insert them as machines, resources and recipes of the user that provided authentication token. Manufacturers, extractors and fuel generators are imported, recipes not produced in any imported machine are skipped. Amounts and durations are scaled by the smallest factor making all of them whole numbers. Machines and resources with names already used by the user are not inserted, existing records are used instead. Recipes with names already used by the user are skipped. All data is inserted in one transaction, response lists numbers of inserted records and skipped entries.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Id of machines recipes to be retreived from database",
-                        "name": "machines_recipes_id",
-                        "in": "query"
+                        "description": "Contents of Docs.json, UTF-16 and UTF-8 encodings are accepted",
+                        "name": "docs",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.JSONData"
+                            "$ref": "#/definitions/handler.ImportResponse"
                         }
                     },
                     "400": {
@@ -389,17 +433,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/stats": {
+        "/plans": {
             "get": {
-                "description": "Return the usage stats of microservice.",
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Return production plans saved by the user that provided authentication token, together with their targets, alternative recipes, alternative machines and production trees calculated when plans were saved or last recomputed. If plans_id parameter is present, only plans with those ids are returned, otherwise plans are returned from id range, specified in the same way as in select endpoint.",
                 "tags": [
-                    "CRUD"
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Id of plans to be retreived from database. Can be present multiple times",
+                        "name": "plans_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from plans table",
+                        "name": "plans_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from plans table",
+                        "name": "plans_rows",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.StatsResponse"
+                            "$ref": "#/definitions/handler.PlansData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -409,24 +490,581 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/user": {
-            "delete": {
+            },
+            "put": {
                 "security": [
                     {
                         "apiTokenAuth": []
                     }
                 ],
-                "description": "Deletes all data in the database that belongs to user who presented the authentication token.",
+                "description": "Update names, targets, alternative recipes and alternative machines of saved production plans, based on \"id\" field of every plan sent in request body. Production tree of every plan is calculated again with updated data. If a plan with a particular id does not belong to the user who presented authentication token, then that plan is not updated.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
                     "CRUD Authorization required"
                 ],
+                "parameters": [
+                    {
+                        "description": "Plans to be updated",
+                        "name": "plans",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PlansData"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.DeleteResponse"
+                            "$ref": "#/definitions/handler.PlansUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Save named production plans of the user that provided authentication token. For every plan, production tree producing its targets with its alternative recipes and alternative machines is calculated by calculator microservice using current data of the user and saved with the plan. Ids, users ids and production trees sent in request body are ignored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "description": "Plans to be saved",
+                        "name": "plans",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PlansData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PlansInsertResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Delete saved production plans with provided ids. If a plan with a particular id does not belong to the user who presented authentication token, then that plan is not deleted.",
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Id of plan to be deleted. Can be present multiple times",
+                        "name": "plans_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PlansDeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/plans/recompute": {
+            "post": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Calculate production trees of saved production plans again, using current recipes, machines and resources of the user that provided authentication token. If plans_id parameter is present, only plans with those ids are recomputed, otherwise all plans of the user are. Changed is true for plans whose production tree differs from the saved one, new production trees of such plans are saved.",
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Id of plan to be recomputed. Can be present multiple times",
+                        "name": "plans_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PlansRecomputeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/progress": {
+            "get": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Return current progression tier of the user that provided authentication token. Recipes and machines assigned to unlock tiers with tier number higher than current tier are not used by calculator microservice. If current tier has not been set, all recipes and machines are unlocked and status 404 is returned.",
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProgressInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Current tier has not been set",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Set current progression tier of the user that provided authentication token. Recipes and machines are unlocked when tier number of their unlock tier is not higher than current tier, recipes and machines without unlock tier are always unlocked. Users id sent in request body is ignored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "description": "Current tier of the user",
+                        "name": "progress",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProgressInfo"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ProgressUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/select": {
+            "get": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Return the records from database specified by id range. Start of range and it's size is specified for each table separately. Size describes number of records to be returned. Ranges include the starting id. Records are only returned for the user that presented authentication token. If start of the range is missing for particular table, then it is assumed to be 1. If size is ommitted, then all records are retreived. If the start of a range is a record belonging to another user, then next record belonging to the user that presented a token is retreived instead.",
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from machines table",
+                        "name": "machines_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from machines table",
+                        "name": "machines_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from resources table",
+                        "name": "resources_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from resources table",
+                        "name": "resources_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from recipes table",
+                        "name": "recipes_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from recipes table",
+                        "name": "recipes_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from recipes_inputs table",
+                        "name": "recipes_inputs_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from recipes_inputs table",
+                        "name": "recipes_inputs_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from recipes_outputs table",
+                        "name": "recipes_outputs_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from recipes_outputs table",
+                        "name": "recipes_outputs_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from machines_recipes table",
+                        "name": "machines_recipes_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from machines_recipes table",
+                        "name": "machines_recipes_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from transport_tiers table",
+                        "name": "transport_tiers_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from transport_tiers table",
+                        "name": "transport_tiers_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from unlock_tiers table",
+                        "name": "unlock_tiers_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from unlock_tiers table",
+                        "name": "unlock_tiers_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from machines_costs table",
+                        "name": "machines_costs_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from machines_costs table",
+                        "name": "machines_costs_rows",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.JSONData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/selectbyid": {
+            "get": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Return the records from database specified by id. Id(s) is specified for each table in the database. If an id parameter for a particular table is omitted, the records are not retreived from that table. Each parameter can be present multiple times, in which case all records from a particular table, with those ids will be retreived and returned in an array. Data is returned for the user that provided authentication token.",
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Id of machines to be retreived from database",
+                        "name": "machines_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of resources to be retreived from database",
+                        "name": "resources_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of recipes to be retreived from database",
+                        "name": "recipes_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of recipes inputs to be retreived from database",
+                        "name": "recipes_inputs_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of recipes outputs to be retreived from database",
+                        "name": "recipes_outputs_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of machines recipes to be retreived from database",
+                        "name": "machines_recipes_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of transport tiers to be retreived from database",
+                        "name": "transport_tiers_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of unlock tiers to be retreived from database",
+                        "name": "unlock_tiers_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of machines costs to be retreived from database",
+                        "name": "machines_costs_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.JSONData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Return the usage stats of microservice.",
+                "tags": [
+                    "CRUD"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.StatsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "delete": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Deletes all data in the database that belongs to user who presented the authentication token.",
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.DeleteResponse"
                         }
                     },
                     "400": {
@@ -452,9 +1090,275 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "bundle.Amount": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "resource": {
+                    "type": "string"
+                }
+            }
+        },
+        "bundle.Bundle": {
+            "type": "object",
+            "properties": {
+                "currentTier": {
+                    "description": "CurrentTier is current progression tier of the user, null if it has not been set",
+                    "type": "integer"
+                },
+                "machines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bundle.Machine"
+                    }
+                },
+                "plans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bundle.Plan"
+                    }
+                },
+                "recipes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bundle.Recipe"
+                    }
+                },
+                "resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bundle.Resource"
+                    }
+                },
+                "transportTiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bundle.TransportTier"
+                    }
+                },
+                "unlockTiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bundle.UnlockTier"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "bundle.Machine": {
+            "type": "object",
+            "properties": {
+                "buildCost": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bundle.Amount"
+                    }
+                },
+                "defaultChoice": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "inputsLiquid": {
+                    "type": "integer"
+                },
+                "inputsSolid": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "outputsLiquid": {
+                    "type": "integer"
+                },
+                "outputsSolid": {
+                    "type": "integer"
+                },
+                "powerClockExponent": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "powerConsumptionKw": {
+                    "type": "integer"
+                },
+                "powerGenerationKw": {
+                    "type": "integer"
+                },
+                "speed": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "unlockTier": {
+                    "description": "UnlockTier is name of unlock tier of machine, empty if machine is always unlocked",
+                    "type": "string"
+                }
+            }
+        },
+        "bundle.Plan": {
+            "type": "object",
+            "properties": {
+                "altMachines": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "altRecipes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "productionTree": {
+                    "type": "object"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PlanTarget"
+                    }
+                }
+            }
+        },
+        "bundle.Recipe": {
+            "type": "object",
+            "properties": {
+                "defaultChoice": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "inputs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bundle.Amount"
+                    }
+                },
+                "machines": {
+                    "description": "Machines are names of machines recipe can be produced in",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "outputs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bundle.Amount"
+                    }
+                },
+                "productionTimeS": {
+                    "type": "integer"
+                },
+                "unlockTier": {
+                    "description": "UnlockTier is name of unlock tier of recipe, empty if recipe is always unlocked",
+                    "type": "string"
+                }
+            }
+        },
+        "bundle.Resource": {
+            "type": "object",
+            "properties": {
+                "liquid": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "resourceUnit": {
+                    "type": "string"
+                },
+                "sinkValue": {
+                    "type": "number",
+                    "format": "float32"
+                }
+            }
+        },
+        "bundle.TransportTier": {
+            "type": "object",
+            "properties": {
+                "capacityPerS": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "liquid": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "bundle.UnlockTier": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "tierNumber": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.BundleImportCounts": {
+            "type": "object",
+            "properties": {
+                "inserted": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.BundleImportResponse": {
+            "type": "object",
+            "properties": {
+                "machines": {
+                    "$ref": "#/definitions/handler.BundleImportCounts"
+                },
+                "plans": {
+                    "$ref": "#/definitions/handler.BundleImportCounts"
+                },
+                "progress": {
+                    "$ref": "#/definitions/handler.BundleImportCounts"
+                },
+                "recipes": {
+                    "$ref": "#/definitions/handler.BundleImportCounts"
+                },
+                "resources": {
+                    "$ref": "#/definitions/handler.BundleImportCounts"
+                },
+                "transportTiers": {
+                    "$ref": "#/definitions/handler.BundleImportCounts"
+                },
+                "unlockTiers": {
+                    "$ref": "#/definitions/handler.BundleImportCounts"
+                }
+            }
+        },
         "handler.DeleteInput": {
             "type": "object",
             "properties": {
+                "machinesCostsIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "machinesIds": {
                     "type": "array",
                     "items": {
@@ -479,13 +1383,25 @@ const docTemplate = `{
                         "type": "integer"
                     }
                 },
-                "recipesOutputsIds": {
+                "recipesOutputsIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "resourcesIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "transportTiersIds": {
                     "type": "array",
                     "items": {
                         "type": "integer"
                     }
                 },
-                "resourcesIds": {
+                "unlockTiersIds": {
                     "type": "array",
                     "items": {
                         "type": "integer"
@@ -496,12 +1412,21 @@ const docTemplate = `{
         "handler.DeleteResponse": {
             "type": "object",
             "properties": {
+                "machinesCostsDeleted": {
+                    "type": "integer"
+                },
                 "machinesDeleted": {
                     "type": "integer"
                 },
                 "machinesRecipesDeleted": {
                     "type": "integer"
                 },
+                "plansDeleted": {
+                    "type": "integer"
+                },
+                "progressDeleted": {
+                    "type": "integer"
+                },
                 "recipesDeleted": {
                     "type": "integer"
                 },
@@ -513,6 +1438,12 @@ const docTemplate = `{
                 },
                 "resourcesDeleted": {
                     "type": "integer"
+                },
+                "transportTiersDeleted": {
+                    "type": "integer"
+                },
+                "unlockTiersDeleted": {
+                    "type": "integer"
                 }
             }
         },
@@ -527,9 +1458,41 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "machinesInserted": {
+                    "type": "integer"
+                },
+                "machinesRecipesInserted": {
+                    "type": "integer"
+                },
+                "recipesInputsInserted": {
+                    "type": "integer"
+                },
+                "recipesInserted": {
+                    "type": "integer"
+                },
+                "recipesOutputsInserted": {
+                    "type": "integer"
+                },
+                "resourcesInserted": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/importer.SkippedEntry"
+                    }
+                }
+            }
+        },
         "handler.InsertResponse": {
             "type": "object",
             "properties": {
+                "machinesCostsInserted": {
+                    "type": "integer"
+                },
                 "machinesInserted": {
                     "type": "integer"
                 },
@@ -547,12 +1510,24 @@ const docTemplate = `{
                 },
                 "resourcesInserted": {
                     "type": "integer"
+                },
+                "transportTiersInserted": {
+                    "type": "integer"
+                },
+                "unlockTiersInserted": {
+                    "type": "integer"
                 }
             }
         },
         "handler.JSONData": {
             "type": "object",
             "properties": {
+                "machinesCostsList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MachineCostInfo"
+                    }
+                },
                 "machinesList": {
                     "type": "array",
                     "items": {
@@ -588,6 +1563,89 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/model.ResourceInfo"
                     }
+                },
+                "transportTiersList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TransportTierInfo"
+                    }
+                },
+                "unlockTiersList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UnlockTierInfo"
+                    }
+                }
+            }
+        },
+        "handler.PlansData": {
+            "type": "object",
+            "properties": {
+                "plansList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PlanInfo"
+                    }
+                }
+            }
+        },
+        "handler.PlansDeleteResponse": {
+            "type": "object",
+            "properties": {
+                "plansDeleted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.PlansInsertResponse": {
+            "type": "object",
+            "properties": {
+                "plansInserted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.PlansRecomputeResponse": {
+            "type": "object",
+            "properties": {
+                "plansList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.RecomputedPlan"
+                    }
+                }
+            }
+        },
+        "handler.PlansUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "plansUpdated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ProgressUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "progressUpdated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.RecomputedPlan": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "productionTree": {
+                    "type": "object"
                 }
             }
         },
@@ -610,6 +1668,9 @@ const docTemplate = `{
         "handler.UpdateResponse": {
             "type": "object",
             "properties": {
+                "machinesCostsUpdated": {
+                    "type": "integer"
+                },
                 "machinesRecipesUpdated": {
                     "type": "integer"
                 },
@@ -627,6 +1688,53 @@ const docTemplate = `{
                 },
                 "resourcesUpdated": {
                     "type": "integer"
+                },
+                "transportTiersUpdated": {
+                    "type": "integer"
+                },
+                "unlockTiersUpdated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "importer.SkippedEntry": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.MachineCostInfo": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "machinesId": {
+                    "type": "integer"
+                },
+                "machinesRef": {
+                    "description": "MachinesRef and ResourcesRef reference machine and resource by temporary id or name instead of id",
+                    "type": "string"
+                },
+                "resourcesId": {
+                    "type": "integer"
+                },
+                "resourcesRef": {
+                    "type": "string"
+                },
+                "usersId": {
+                    "type": "integer"
                 }
             }
         },
@@ -655,13 +1763,28 @@ const docTemplate = `{
                 "outputsSolid": {
                     "type": "integer"
                 },
+                "powerClockExponent": {
+                    "description": "PowerClockExponent is the exponent of clock speed in power consumption, 0 is stored as 1",
+                    "type": "number",
+                    "format": "float32"
+                },
                 "powerConsumptionKw": {
                     "type": "integer"
                 },
+                "powerGenerationKw": {
+                    "type": "integer"
+                },
                 "speed": {
                     "type": "number",
                     "format": "float32"
                 },
+                "tempId": {
+                    "description": "TempId is a temporary id of machine inserted in the same request as records referencing it",
+                    "type": "string"
+                },
+                "unlockTiersId": {
+                    "type": "integer"
+                },
                 "usersId": {
                     "type": "integer"
                 }
@@ -676,9 +1799,74 @@ const docTemplate = `{
                 "machinesId": {
                     "type": "integer"
                 },
+                "machinesRef": {
+                    "type": "string"
+                },
                 "recipesId": {
                     "type": "integer"
                 },
+                "recipesRef": {
+                    "description": "RecipesRef and MachinesRef reference recipe and machine by temporary id or name instead of id",
+                    "type": "string"
+                },
+                "usersId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PlanInfo": {
+            "type": "object",
+            "properties": {
+                "altMachines": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "altRecipes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "productionTree": {
+                    "type": "object"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PlanTarget"
+                    }
+                },
+                "usersId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PlanTarget": {
+            "type": "object",
+            "properties": {
+                "rate": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "resource": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProgressInfo": {
+            "type": "object",
+            "properties": {
+                "currentTier": {
+                    "type": "integer"
+                },
                 "usersId": {
                     "type": "integer"
                 }
@@ -700,6 +1888,13 @@ const docTemplate = `{
                 "productionTimeS": {
                     "type": "integer"
                 },
+                "tempId": {
+                    "description": "TempId is a temporary id of recipe inserted in the same request as records referencing it",
+                    "type": "string"
+                },
+                "unlockTiersId": {
+                    "type": "integer"
+                },
                 "usersId": {
                     "type": "integer"
                 }
@@ -717,9 +1912,16 @@ const docTemplate = `{
                 "recipesId": {
                     "type": "integer"
                 },
+                "recipesRef": {
+                    "description": "RecipesRef and ResourcesRef reference recipe and resource by temporary id or name instead of id",
+                    "type": "string"
+                },
                 "resourcesId": {
                     "type": "integer"
                 },
+                "resourcesRef": {
+                    "type": "string"
+                },
                 "usersId": {
                     "type": "integer"
                 }
@@ -741,6 +1943,53 @@ const docTemplate = `{
                 "resourceUnit": {
                     "type": "string"
                 },
+                "sinkValue": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "tempId": {
+                    "description": "TempId is a temporary id of resource inserted in the same request as records referencing it",
+                    "type": "string"
+                },
+                "usersId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TransportTierInfo": {
+            "type": "object",
+            "properties": {
+                "capacityPerS": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "liquid": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "usersId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.UnlockTierInfo": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tierNumber": {
+                    "type": "integer"
+                },
                 "usersId": {
                     "type": "integer"
                 }
@@ -0,0 +1,604 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/bundle"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+)
+
+const (
+	// StrategyMerge updates records with names already used by the user with data of the bundle
+	StrategyMerge = "merge"
+	// StrategyReplace deletes all data of the user before importing the bundle
+	StrategyReplace = "replace"
+	// StrategySkipExisting leaves records with names already used by the user untouched
+	StrategySkipExisting = "skip_existing"
+)
+
+type BundleImportCounts struct {
+	Inserted uint
+	Updated  uint
+	Skipped  uint
+}
+
+type BundleImportResponse struct {
+	UnlockTiers    BundleImportCounts
+	TransportTiers BundleImportCounts
+	Resources      BundleImportCounts
+	Machines       BundleImportCounts
+	Recipes        BundleImportCounts
+	Plans          BundleImportCounts
+	Progress       BundleImportCounts
+}
+
+// Export return all data of the user as a bundle
+//
+//	@Description	Return unlock tiers, transport tiers, resources, machines, recipes, production plans and current tier of the user that provided authentication token as a self-contained bundle. Records of the bundle reference each other by names instead of database ids, build costs of machines and inputs, outputs and machines of recipes are nested in records they belong to. Bundle can be imported into any account with import endpoint.
+//	@Tags			CRUD Authorization required
+//	@Success		200	{object}	bundle.Bundle
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/export [get]
+//
+//	@Security		apiTokenAuth
+func (h *CRUD) Export(w http.ResponseWriter, r *http.Request) {
+	//parameters that are not mentioned in swagger directly:
+	//jwt = token with dispatcher server secret key, id of user who received the token and issue date of the token, not optional
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, userId := h.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	ctx := r.Context()
+	transaction, err := h.MachineRepo.DB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not start a transaction, reason: %w", err).Error()))
+		return
+	}
+	dataset, err := h.selectDataset(ctx, transaction, userId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not retrieve data, reason: %w", rollback(transaction, err)).Error()))
+		return
+	}
+	err = transaction.Commit()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not finish transaction, reason: %w", err).Error()))
+		return
+	}
+	byteJSONRepresentation, err := json.Marshal(bundle.FromDataset(dataset))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not generate json representation of data, reason: %w", err).Error()))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(byteJSONRepresentation)
+}
+
+// Import load bundle into account of the user
+//
+//	@Description	Import bundle produced by export endpoint into account of the user that provided authentication token. Bundle must be of supported version, names must be unique among records of the same kind and every referenced name must belong to a record of the bundle. Strategy decides what happens to records with names already used by the user: 'merge' updates them with data of the bundle and replaces their build costs, inputs, outputs and machines, 'replace' deletes all data of the user before import and 'skip_existing' leaves them untouched. All data is imported in one transaction, response lists numbers of inserted, updated and skipped records.
+//	@Param			strategy	query	string			false	"Conflict strategy, one of 'merge', 'replace' or 'skip_existing', skip_existing by default"
+//	@Param			dry_run		query	bool			false	"If true, bundle is validated and numbers of affected records are reported, but changes are rolled back"
+//	@Param			bundle		body	bundle.Bundle	true	"Bundle to import"
+//	@Tags			CRUD Authorization required
+//
+//	@Accept			json
+//
+//	@Success		200	{object}	handler.BundleImportResponse
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/import [post]
+//
+//	@Security		apiTokenAuth
+func (h *CRUD) Import(w http.ResponseWriter, r *http.Request) {
+	//parameters that are not mentioned in swagger directly:
+	//jwt = token with dispatcher server secret key, id of user who received the token and issue date of the token, not optional
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, userId := h.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	strategy := r.URL.Query().Get("strategy")
+	if strategy == "" {
+		strategy = StrategySkipExisting
+	}
+	if strategy != StrategyMerge && strategy != StrategyReplace && strategy != StrategySkipExisting {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("strategy should be one of 'merge', 'replace' or 'skip_existing'"))
+		return
+	}
+	dryRun := false
+	if dryRunParam := r.URL.Query().Get("dry_run"); dryRunParam != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunParam)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("dry_run should be either true or false"))
+			return
+		}
+	}
+	inputBundle := bundle.Bundle{}
+	err := json.NewDecoder(r.Body).Decode(&inputBundle)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Errorf("could not parse received body, reason: %w", err).Error()))
+		return
+	}
+	err = inputBundle.Validate()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Errorf("received bundle is not valid, reason: %w", err).Error()))
+		return
+	}
+	response, err := h.importBundle(r.Context(), inputBundle, userId, strategy, dryRun)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not import bundle, reason: %w", err).Error()))
+		return
+	}
+	if !dryRun {
+		h.CalculatorNotifier.InvalidateRecipeData(r.Context(), userId)
+	}
+	byteJSONRepresentation, err := json.Marshal(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("bundle has been imported, but could not generate json representation of response, reason: %w", err).Error()))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(byteJSONRepresentation)
+}
+
+// selectDataset retrieves all records of the user as a part of transaction, so that records of all kinds are read
+// from the same state of the database.
+func (h *CRUD) selectDataset(ctx context.Context, transaction *sql.Tx, userId int) (bundle.Dataset, error) {
+	dataset := bundle.Dataset{}
+	var err error
+	dataset.UnlockTiers, err = h.UnlockTierRepo.SelectUnlockTiersWithTransaction(ctx, transaction, userId)
+	if err != nil {
+		return dataset, fmt.Errorf("could not retrieve unlock tiers, reason: %w", err)
+	}
+	dataset.TransportTiers, err = h.TransportTierRepo.SelectTransportTiersWithTransaction(ctx, transaction, userId)
+	if err != nil {
+		return dataset, fmt.Errorf("could not retrieve transport tiers, reason: %w", err)
+	}
+	dataset.Resources, err = h.ResourceRepo.SelectResourcesWithTransaction(ctx, transaction, userId)
+	if err != nil {
+		return dataset, fmt.Errorf("could not retrieve resources, reason: %w", err)
+	}
+	dataset.Machines, err = h.MachineRepo.SelectMachinesWithTransaction(ctx, transaction, userId)
+	if err != nil {
+		return dataset, fmt.Errorf("could not retrieve machines, reason: %w", err)
+	}
+	dataset.Recipes, err = h.RecipeRepo.SelectRecipesWithTransaction(ctx, transaction, userId)
+	if err != nil {
+		return dataset, fmt.Errorf("could not retrieve recipes, reason: %w", err)
+	}
+	dataset.RecipesInputs, err = h.RecipeinputRepo.SelectRecipesInputsWithTransaction(ctx, transaction, userId)
+	if err != nil {
+		return dataset, fmt.Errorf("could not retrieve recipes inputs, reason: %w", err)
+	}
+	dataset.RecipesOutputs, err = h.RecipeoutputRepo.SelectRecipesOutputsWithTransaction(ctx, transaction, userId)
+	if err != nil {
+		return dataset, fmt.Errorf("could not retrieve recipes outputs, reason: %w", err)
+	}
+	dataset.MachinesRecipes, err = h.MachineRecipeRepo.SelectMachinesRecipesWithTransaction(ctx, transaction, userId)
+	if err != nil {
+		return dataset, fmt.Errorf("could not retrieve machines recipes, reason: %w", err)
+	}
+	dataset.MachinesCosts, err = h.MachineCostRepo.SelectMachinesCostsWithTransaction(ctx, transaction, userId)
+	if err != nil {
+		return dataset, fmt.Errorf("could not retrieve machines costs, reason: %w", err)
+	}
+	dataset.Plans, err = h.PlanRepo.SelectPlansWithTransaction(ctx, transaction, userId)
+	if err != nil {
+		return dataset, fmt.Errorf("could not retrieve plans, reason: %w", err)
+	}
+	progress, exists, err := h.ProgressRepo.SelectProgressWithTransaction(ctx, transaction, userId)
+	if err != nil {
+		return dataset, fmt.Errorf("could not retrieve progress, reason: %w", err)
+	}
+	if exists {
+		dataset.Progress = &progress
+	}
+	return dataset, nil
+}
+
+// deleteDataset deletes all records of the user as a part of transaction.
+func (h *CRUD) deleteDataset(ctx context.Context, transaction *sql.Tx, userId int) error {
+	deletions := []struct {
+		kind   string
+		delete func(context.Context, *sql.Tx, int) (sql.Result, error)
+	}{
+		{"recipes inputs", h.RecipeinputRepo.DeleteRecipesInputsByUserId},
+		{"recipes outputs", h.RecipeoutputRepo.DeleteRecipesOutputsByUserId},
+		{"machines recipes", h.MachineRecipeRepo.DeleteMachinesRecipesByUserId},
+		{"machines costs", h.MachineCostRepo.DeleteMachinesCostsByUserId},
+		{"plans", h.PlanRepo.DeletePlansByUserId},
+		{"machines", h.MachineRepo.DeleteMachinesByUserId},
+		{"recipes", h.RecipeRepo.DeleteRecipesByUserId},
+		{"resources", h.ResourceRepo.DeleteResourcesByUserId},
+		{"transport tiers", h.TransportTierRepo.DeleteTransportTiersByUserId},
+		{"unlock tiers", h.UnlockTierRepo.DeleteUnlockTiersByUserId},
+		{"progress", h.ProgressRepo.DeleteProgressByUserId},
+	}
+	for _, deletion := range deletions {
+		_, err := deletion.delete(ctx, transaction, userId)
+		if err != nil {
+			return fmt.Errorf("could not delete %s, reason: %w", deletion.kind, err)
+		}
+	}
+	return nil
+}
+
+// importBundle imports bundle for the user in one transaction, resolving names of records to ids of existing or
+// inserted records. Records with names already used by the user are handled according to strategy.
+func (h *CRUD) importBundle(ctx context.Context, data bundle.Bundle, userId int, strategy string, dryRun bool) (BundleImportResponse, error) {
+	response := BundleImportResponse{}
+	transaction, err := h.MachineRepo.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return response, fmt.Errorf("could not start a transaction, reason: %w", err)
+	}
+	existing, err := h.selectDataset(ctx, transaction, userId)
+	if err != nil {
+		return response, rollback(transaction, err)
+	}
+	if strategy == StrategyReplace {
+		err = h.deleteDataset(ctx, transaction, userId)
+		if err != nil {
+			return response, rollback(transaction, err)
+		}
+		existing = bundle.Dataset{}
+	}
+	merge := strategy == StrategyMerge
+
+	unlockTierIds := make(map[string]uint)
+	for _, unlockTier := range existing.UnlockTiers {
+		unlockTierIds[unlockTier.Name] = unlockTier.Id
+	}
+	for _, unlockTier := range data.UnlockTiers {
+		entry := model.UnlockTierInfo{Name: unlockTier.Name, TierNumber: unlockTier.TierNumber}
+		id, exists := unlockTierIds[unlockTier.Name]
+		if exists && !merge {
+			response.UnlockTiers.Skipped++
+			continue
+		}
+		if exists {
+			entry.Id = id
+			_, err = h.UnlockTierRepo.UpdateUnlockTiersWithTransaction(ctx, transaction, []model.UnlockTierInfo{entry}, uint(userId))
+			if err != nil {
+				return response, rollback(transaction, fmt.Errorf("could not update unlock tier '%s', reason: %w", unlockTier.Name, err))
+			}
+			response.UnlockTiers.Updated++
+			continue
+		}
+		insertedId, err := h.UnlockTierRepo.InsertUnlockTierWithTransaction(ctx, transaction, entry, uint(userId))
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert unlock tier '%s', reason: %w", unlockTier.Name, err))
+		}
+		unlockTierIds[unlockTier.Name] = uint(insertedId)
+		response.UnlockTiers.Inserted++
+	}
+
+	transportTierIds := make(map[string]uint)
+	for _, transportTier := range existing.TransportTiers {
+		transportTierIds[transportTier.Name] = transportTier.Id
+	}
+	transportTiersToInsert := []model.TransportTierInfo{}
+	transportTiersToUpdate := []model.TransportTierInfo{}
+	for _, transportTier := range data.TransportTiers {
+		entry := model.TransportTierInfo{Name: transportTier.Name, Liquid: transportTier.Liquid, CapacityPerS: transportTier.CapacityPerS}
+		id, exists := transportTierIds[transportTier.Name]
+		switch {
+		case !exists:
+			transportTiersToInsert = append(transportTiersToInsert, entry)
+		case merge:
+			entry.Id = id
+			transportTiersToUpdate = append(transportTiersToUpdate, entry)
+		default:
+			response.TransportTiers.Skipped++
+		}
+	}
+	if len(transportTiersToInsert) > 0 {
+		_, err = h.TransportTierRepo.InsertTransportTiersWithTransaction(ctx, transaction, transportTiersToInsert, uint(userId))
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert transport tiers, reason: %w", err))
+		}
+		response.TransportTiers.Inserted = uint(len(transportTiersToInsert))
+	}
+	if len(transportTiersToUpdate) > 0 {
+		_, err = h.TransportTierRepo.UpdateTransportTiersWithTransaction(ctx, transaction, transportTiersToUpdate, uint(userId))
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not update transport tiers, reason: %w", err))
+		}
+		response.TransportTiers.Updated = uint(len(transportTiersToUpdate))
+	}
+
+	resourceIds := make(map[string]uint)
+	for _, resource := range existing.Resources {
+		resourceIds[resource.Name] = resource.Id
+	}
+	for _, resource := range data.Resources {
+		entry := model.ResourceInfo{Name: resource.Name, Liquid: resource.Liquid, ResourceUnit: resource.ResourceUnit, SinkValue: resource.SinkValue}
+		id, exists := resourceIds[resource.Name]
+		if exists && !merge {
+			response.Resources.Skipped++
+			continue
+		}
+		if exists {
+			entry.Id = id
+			_, err = h.ResourceRepo.UpdateResourcesWithTransaction(ctx, transaction, []model.ResourceInfo{entry}, uint(userId))
+			if err != nil {
+				return response, rollback(transaction, fmt.Errorf("could not update resource '%s', reason: %w", resource.Name, err))
+			}
+			response.Resources.Updated++
+			continue
+		}
+		insertedId, err := h.ResourceRepo.InsertResourceWithTransaction(ctx, transaction, entry, uint(userId))
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert resource '%s', reason: %w", resource.Name, err))
+		}
+		resourceIds[resource.Name] = uint(insertedId)
+		response.Resources.Inserted++
+	}
+
+	machineIds := make(map[string]uint)
+	for _, machine := range existing.Machines {
+		machineIds[machine.Name] = machine.Id
+	}
+	mergedMachines := make(map[uint]bool)
+	costs := []model.MachineCostInfo{}
+	for _, machine := range data.Machines {
+		entry := model.MachineInfo{
+			Name:               machine.Name,
+			InputsSolid:        machine.InputsSolid,
+			InputsLiquid:       machine.InputsLiquid,
+			OutputsSolid:       machine.OutputsSolid,
+			OutputsLiquid:      machine.OutputsLiquid,
+			Speed:              machine.Speed,
+			PowerConsumptionKw: machine.PowerConsumptionKw,
+			PowerClockExponent: machine.PowerClockExponent,
+			PowerGenerationKw:  machine.PowerGenerationKw,
+			DefaultChoice:      machine.DefaultChoice,
+			UnlockTiersId:      unlockTierIds[machine.UnlockTier],
+		}
+		id, exists := machineIds[machine.Name]
+		if exists && !merge {
+			response.Machines.Skipped++
+			continue
+		}
+		if exists {
+			entry.Id = id
+			_, err = h.MachineRepo.UpdateMachinesWithTransaction(ctx, transaction, []model.MachineInfo{entry}, uint(userId))
+			if err != nil {
+				return response, rollback(transaction, fmt.Errorf("could not update machine '%s', reason: %w", machine.Name, err))
+			}
+			mergedMachines[id] = true
+			response.Machines.Updated++
+		} else {
+			insertedId, err := h.MachineRepo.InsertMachineWithTransaction(ctx, transaction, entry, uint(userId))
+			if err != nil {
+				return response, rollback(transaction, fmt.Errorf("could not insert machine '%s', reason: %w", machine.Name, err))
+			}
+			id = uint(insertedId)
+			machineIds[machine.Name] = id
+			response.Machines.Inserted++
+		}
+		for _, cost := range machine.BuildCost {
+			costs = append(costs, model.MachineCostInfo{MachinesId: id, ResourcesId: resourceIds[cost.Resource], Amount: cost.Amount})
+		}
+	}
+	mergedCosts := []int{}
+	for _, cost := range existing.MachinesCosts {
+		if mergedMachines[cost.MachinesId] {
+			mergedCosts = append(mergedCosts, int(cost.Id))
+		}
+	}
+	if len(mergedCosts) > 0 {
+		_, err = h.MachineCostRepo.DeleteMachinesCostsWithTransaction(ctx, transaction, mergedCosts, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not delete build costs of merged machines, reason: %w", err))
+		}
+	}
+	if len(costs) > 0 {
+		_, err = h.MachineCostRepo.InsertMachinesCostsWithTransaction(ctx, transaction, costs, uint(userId))
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert build costs of machines, reason: %w", err))
+		}
+	}
+
+	recipeIds := make(map[string]uint)
+	for _, recipe := range existing.Recipes {
+		recipeIds[recipe.Name] = recipe.Id
+	}
+	mergedRecipes := make(map[uint]bool)
+	inputs := []model.RecipeInputOutputInfo{}
+	outputs := []model.RecipeInputOutputInfo{}
+	machinesRecipes := []model.MachinesRecipesInfo{}
+	for _, recipe := range data.Recipes {
+		entry := model.RecipeInfo{Name: recipe.Name, ProductionTimeS: recipe.ProductionTimeS, DefaultChoice: recipe.DefaultChoice, UnlockTiersId: unlockTierIds[recipe.UnlockTier]}
+		id, exists := recipeIds[recipe.Name]
+		if exists && !merge {
+			response.Recipes.Skipped++
+			continue
+		}
+		if exists {
+			entry.Id = id
+			_, err = h.RecipeRepo.UpdateRecipesWithTransaction(ctx, transaction, []model.RecipeInfo{entry}, uint(userId))
+			if err != nil {
+				return response, rollback(transaction, fmt.Errorf("could not update recipe '%s', reason: %w", recipe.Name, err))
+			}
+			mergedRecipes[id] = true
+			response.Recipes.Updated++
+		} else {
+			insertedId, err := h.RecipeRepo.InsertRecipeWithTransaction(ctx, transaction, entry, uint(userId))
+			if err != nil {
+				return response, rollback(transaction, fmt.Errorf("could not insert recipe '%s', reason: %w", recipe.Name, err))
+			}
+			id = uint(insertedId)
+			recipeIds[recipe.Name] = id
+			response.Recipes.Inserted++
+		}
+		for _, input := range recipe.Inputs {
+			inputs = append(inputs, model.RecipeInputOutputInfo{RecipesId: id, ResourcesId: resourceIds[input.Resource], Amount: input.Amount})
+		}
+		for _, output := range recipe.Outputs {
+			outputs = append(outputs, model.RecipeInputOutputInfo{RecipesId: id, ResourcesId: resourceIds[output.Resource], Amount: output.Amount})
+		}
+		for _, machineName := range recipe.Machines {
+			machinesRecipes = append(machinesRecipes, model.MachinesRecipesInfo{RecipesId: id, MachinesId: machineIds[machineName]})
+		}
+	}
+	mergedInputs := []int{}
+	for _, input := range existing.RecipesInputs {
+		if mergedRecipes[input.RecipesId] {
+			mergedInputs = append(mergedInputs, int(input.Id))
+		}
+	}
+	if len(mergedInputs) > 0 {
+		_, err = h.RecipeinputRepo.DeleteRecipesInputsWithTransaction(ctx, transaction, mergedInputs, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not delete inputs of merged recipes, reason: %w", err))
+		}
+	}
+	mergedOutputs := []int{}
+	for _, output := range existing.RecipesOutputs {
+		if mergedRecipes[output.RecipesId] {
+			mergedOutputs = append(mergedOutputs, int(output.Id))
+		}
+	}
+	if len(mergedOutputs) > 0 {
+		_, err = h.RecipeoutputRepo.DeleteRecipesOutputsWithTransaction(ctx, transaction, mergedOutputs, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not delete outputs of merged recipes, reason: %w", err))
+		}
+	}
+	mergedMachinesRecipes := []int{}
+	for _, machineRecipe := range existing.MachinesRecipes {
+		if mergedRecipes[machineRecipe.RecipesId] {
+			mergedMachinesRecipes = append(mergedMachinesRecipes, int(machineRecipe.Id))
+		}
+	}
+	if len(mergedMachinesRecipes) > 0 {
+		_, err = h.MachineRecipeRepo.DeleteMachinesRecipesWithTransaction(ctx, transaction, mergedMachinesRecipes, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not delete machines of merged recipes, reason: %w", err))
+		}
+	}
+	if len(inputs) > 0 {
+		_, err = h.RecipeinputRepo.InsertRecipesInputsWithTransaction(ctx, transaction, inputs, uint(userId))
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert inputs of recipes, reason: %w", err))
+		}
+	}
+	if len(outputs) > 0 {
+		_, err = h.RecipeoutputRepo.InsertRecipesOutputsWithTransaction(ctx, transaction, outputs, uint(userId))
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert outputs of recipes, reason: %w", err))
+		}
+	}
+	if len(machinesRecipes) > 0 {
+		_, err = h.MachineRecipeRepo.InsertMachinesRecipesWithTransaction(ctx, transaction, machinesRecipes, uint(userId))
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert machines of recipes, reason: %w", err))
+		}
+	}
+
+	planIds := make(map[string]uint)
+	for _, plan := range existing.Plans {
+		planIds[plan.Name] = plan.Id
+	}
+	plansToInsert := []model.PlanInfo{}
+	plansToUpdate := []model.PlanInfo{}
+	for _, plan := range data.Plans {
+		entry := model.PlanInfo{Name: plan.Name, Targets: plan.Targets, AltRecipes: plan.AltRecipes, AltMachines: plan.AltMachines, ProductionTree: plan.ProductionTree}
+		id, exists := planIds[plan.Name]
+		switch {
+		case !exists:
+			plansToInsert = append(plansToInsert, entry)
+		case merge:
+			entry.Id = id
+			plansToUpdate = append(plansToUpdate, entry)
+		default:
+			response.Plans.Skipped++
+		}
+	}
+	if len(plansToInsert) > 0 {
+		_, err = h.PlanRepo.InsertPlansWithTransaction(ctx, transaction, plansToInsert, uint(userId))
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert plans, reason: %w", err))
+		}
+		response.Plans.Inserted = uint(len(plansToInsert))
+	}
+	if len(plansToUpdate) > 0 {
+		_, err = h.PlanRepo.UpdatePlansWithTransaction(ctx, transaction, plansToUpdate, uint(userId))
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not update plans, reason: %w", err))
+		}
+		response.Plans.Updated = uint(len(plansToUpdate))
+	}
+
+	if data.CurrentTier != nil {
+		if existing.Progress != nil && !merge {
+			response.Progress.Skipped++
+		} else {
+			_, err = h.ProgressRepo.UpdateProgressWithTransaction(ctx, transaction, model.ProgressInfo{CurrentTier: *data.CurrentTier}, uint(userId))
+			if err != nil {
+				return response, rollback(transaction, fmt.Errorf("could not update progress, reason: %w", err))
+			}
+			if existing.Progress != nil {
+				response.Progress.Updated++
+			} else {
+				response.Progress.Inserted++
+			}
+		}
+	}
+
+	err = finishTransaction(transaction, dryRun)
+	if err != nil {
+		return response, err
+	}
+	return response, nil
+}
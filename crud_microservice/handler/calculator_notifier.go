@@ -0,0 +1,109 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+)
+
+// CalculatorNotifier informs calculator microservices that recipe data of a user has changed,
+// so that they do not use cached copies of outdated data, and asks them to calculate production trees of plans
+type CalculatorNotifier struct {
+	CalculatorMicroservicesAddresses []string
+	Client                           *http.Client
+}
+
+// InvalidateRecipeData asks every calculator microservice to drop cached data of user. Requests are sent in the
+// background, so that unreachable microservices do not delay the response. Failures are only logged, as cached data
+// also expires on its own.
+func (n *CalculatorNotifier) InvalidateRecipeData(ctx context.Context, userId int) {
+	if n == nil {
+		return
+	}
+	go n.invalidateRecipeData(context.WithoutCancel(ctx), userId)
+}
+
+func (n *CalculatorNotifier) invalidateRecipeData(ctx context.Context, userId int) {
+	for _, address := range n.CalculatorMicroservicesAddresses {
+		request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("https://%s/invalidate?userid=%d", address, userId), nil)
+		if err != nil {
+			fmt.Println("could not create request to calculator microservice:", err)
+			continue
+		}
+		response, err := n.Client.Do(request)
+		if err != nil {
+			fmt.Println("could not invalidate cached data in calculator microservice:", err)
+			continue
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			fmt.Println("could not invalidate cached data in calculator microservice, response status:", response.Status)
+		}
+	}
+}
+
+// CalculatePlan asks calculator microservices, in order, to calculate production tree producing targets of plan
+// with its alternative recipes and machines. Next microservice is only asked if previous one could not be reached.
+// Production tree is returned as json document received from calculator microservice.
+func (n *CalculatorNotifier) CalculatePlan(ctx context.Context, userId int, plan model.PlanInfo) (json.RawMessage, error) {
+	if n == nil || len(n.CalculatorMicroservicesAddresses) == 0 {
+		return nil, fmt.Errorf("no calculator microservice is configured")
+	}
+	params := url.Values{}
+	params.Set("userid", fmt.Sprint(userId))
+	for _, recipeName := range plan.AltRecipes {
+		params.Add("alt_recipe", recipeName)
+	}
+	for _, machineName := range plan.AltMachines {
+		params.Add("alt_machine", machineName)
+	}
+	body, err := json.Marshal(struct{ Targets []model.PlanTarget }{Targets: plan.Targets})
+	if err != nil {
+		return nil, fmt.Errorf("could not generate json representation of targets: %w", err)
+	}
+	var lastErr error
+	for _, address := range n.CalculatorMicroservicesAddresses {
+		request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("https://%s/calculate?%s", address, params.Encode()), bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("could not create request to calculator microservice: %w", err)
+		}
+		response, err := n.Client.Do(request)
+		if err != nil {
+			lastErr = fmt.Errorf("could not communicate with calculator microservice: %w", err)
+			continue
+		}
+		responseBody, err := io.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("could not read response of calculator microservice: %w", err)
+			continue
+		}
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("calculator microservice responded with status %s: %s", response.Status, responseBody)
+		}
+		return json.RawMessage(responseBody), nil
+	}
+	return nil, lastErr
+}
@@ -17,6 +17,7 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -28,11 +29,16 @@ import (
 	custommiddleware "github.com/marban004/factory_games_organizer/custom_middleware"
 	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
 	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/machine"
+	machinecost "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/machine_cost"
 	machinerecipe "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/machine_recipe"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/plan"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/progress"
 	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/recipe"
 	recipeinput "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/recipe_input"
 	recipeoutput "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/recipe_output"
 	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/resource"
+	transporttier "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/transport_tier"
+	unlocktier "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/unlock_tier"
 	orderedmap "github.com/wk8/go-ordered-map/v2"
 )
 
@@ -43,6 +49,9 @@ type JSONData struct {
 	RecipesInputsList   []model.RecipeInputOutputInfo
 	RecipesOutputsList  []model.RecipeInputOutputInfo
 	MachinesRecipesList []model.MachinesRecipesInfo
+	TransportTiersList  []model.TransportTierInfo
+	UnlockTiersList     []model.UnlockTierInfo
+	MachinesCostsList   []model.MachineCostInfo
 }
 
 type InsertResponse struct {
@@ -52,6 +61,9 @@ type InsertResponse struct {
 	RecipesInputsInserted   uint
 	RecipesOutputsInserted  uint
 	MachinesRecipesInserted uint
+	TransportTiersInserted  uint
+	UnlockTiersInserted     uint
+	MachinesCostsInserted   uint
 }
 
 type UpdateResponse struct {
@@ -61,6 +73,9 @@ type UpdateResponse struct {
 	RecipesInputsUpdated   uint
 	RecipesOutputsUpdated  uint
 	MachinesRecipesUpdated uint
+	TransportTiersUpdated  uint
+	UnlockTiersUpdated     uint
+	MachinesCostsUpdated   uint
 }
 
 type DeleteInput struct {
@@ -70,6 +85,9 @@ type DeleteInput struct {
 	RecipesInputsIds   []int
 	RecipesOutputsIds  []int
 	MachinesRecipesIds []int
+	TransportTiersIds  []int
+	UnlockTiersIds     []int
+	MachinesCostsIds   []int
 }
 
 type DeleteResponse struct {
@@ -79,17 +97,28 @@ type DeleteResponse struct {
 	RecipesInputsDeleted   uint
 	RecipesOutputsDeleted  uint
 	MachinesRecipesDeleted uint
+	TransportTiersDeleted  uint
+	UnlockTiersDeleted     uint
+	MachinesCostsDeleted   uint
+	PlansDeleted           uint
+	ProgressDeleted        uint
 }
 
 type CRUD struct {
-	MachineRepo       *machine.MySQLRepo
-	ResourceRepo      *resource.MySQLRepo
-	RecipeRepo        *recipe.MySQLRepo
-	RecipeinputRepo   *recipeinput.MySQLRepo
-	RecipeoutputRepo  *recipeoutput.MySQLRepo
-	MachineRecipeRepo *machinerecipe.MySQLRepo
-	Secret            []byte
-	StatTracker       *custommiddleware.DefaultApiStatTracker
+	MachineRepo        *machine.MySQLRepo
+	ResourceRepo       *resource.MySQLRepo
+	RecipeRepo         *recipe.MySQLRepo
+	RecipeinputRepo    *recipeinput.MySQLRepo
+	RecipeoutputRepo   *recipeoutput.MySQLRepo
+	MachineRecipeRepo  *machinerecipe.MySQLRepo
+	TransportTierRepo  *transporttier.MySQLRepo
+	UnlockTierRepo     *unlocktier.MySQLRepo
+	MachineCostRepo    *machinecost.MySQLRepo
+	PlanRepo           *plan.MySQLRepo
+	ProgressRepo       *progress.MySQLRepo
+	Secret             []byte
+	StatTracker        *custommiddleware.DefaultApiStatTracker
+	CalculatorNotifier *CalculatorNotifier
 }
 
 type HealthResponse struct {
@@ -158,6 +187,9 @@ func (h *CRUD) Stats(w http.ResponseWriter, r *http.Request) {
 //	@Param			recipes_inputs_id	query	integer	false	"Id of recipes inputs to be retreived from database"
 //	@Param			recipes_outputs_id	query	integer	false	"Id of recipes outputs to be retreived from database"
 //	@Param			machines_recipes_id	query	integer	false	"Id of machines recipes to be retreived from database"
+//	@Param			transport_tiers_id	query	integer	false	"Id of transport tiers to be retreived from database"
+//	@Param			unlock_tiers_id		query	integer	false	"Id of unlock tiers to be retreived from database"
+//	@Param			machines_costs_id	query	integer	false	"Id of machines costs to be retreived from database"
 //	@Tags			CRUD Authorization required
 //	@Success		200	{object}	handler.JSONData
 //	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
@@ -188,6 +220,9 @@ func (h *CRUD) SelectByID(w http.ResponseWriter, r *http.Request) {
 	recipesInputsIds := r.URL.Query()["recipes_inputs_id"]
 	recipesOutputsIds := r.URL.Query()["recipes_outputs_id"]
 	machinesRecipesIds := r.URL.Query()["machines_recipes_id"]
+	transportTiersIds := r.URL.Query()["transport_tiers_id"]
+	unlockTiersIds := r.URL.Query()["unlock_tiers_id"]
+	machinesCostsIds := r.URL.Query()["machines_costs_id"]
 	if machinesIds != nil {
 		result, err := h.MachineRepo.SelectMachinesById(r.Context(), h.convertArrToInt(machinesIds), userId)
 		if err != nil {
@@ -242,6 +277,33 @@ func (h *CRUD) SelectByID(w http.ResponseWriter, r *http.Request) {
 		}
 		returnData.MachinesRecipesList = result
 	}
+	if transportTiersIds != nil {
+		result, err := h.TransportTierRepo.SelectTransportTiersById(r.Context(), h.convertArrToInt(transportTiersIds), userId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Errorf("could not retrieve data from database, reason: %w", err).Error()))
+			return
+		}
+		returnData.TransportTiersList = result
+	}
+	if unlockTiersIds != nil {
+		result, err := h.UnlockTierRepo.SelectUnlockTiersById(r.Context(), h.convertArrToInt(unlockTiersIds), userId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Errorf("could not retrieve data from database, reason: %w", err).Error()))
+			return
+		}
+		returnData.UnlockTiersList = result
+	}
+	if machinesCostsIds != nil {
+		result, err := h.MachineCostRepo.SelectMachinesCostsById(r.Context(), h.convertArrToInt(machinesCostsIds), userId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Errorf("could not retrieve data from database, reason: %w", err).Error()))
+			return
+		}
+		returnData.MachinesCostsList = result
+	}
 	byteJSONRepresentation, err := json.Marshal(returnData)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -250,7 +312,7 @@ func (h *CRUD) SelectByID(w http.ResponseWriter, r *http.Request) {
 	}
 	w.WriteHeader(http.StatusOK)
 	w.Write(byteJSONRepresentation)
-	//test url 127.0.0.1:3000/selectbyid?jwt=l&machines_id=1&machines_id=2&resources_id=1&resources_id=2&recipes_id=1&recipes_id=2&recipes_inputs_id=1&recipes_inputs_id=2&recipes_outputs_id=1&recipes_outputs_id=2&machines_recipes_id=1&machines_recipes_id=2
+	//test url 127.0.0.1:3000/selectbyid?jwt=l&machines_id=1&machines_id=2&resources_id=1&resources_id=2&recipes_id=1&recipes_id=2&recipes_inputs_id=1&recipes_inputs_id=2&recipes_outputs_id=1&recipes_outputs_id=2&machines_recipes_id=1&machines_recipes_id=2&transport_tiers_id=1&transport_tiers_id=2
 }
 
 // Select return the record(s) from database
@@ -268,6 +330,12 @@ func (h *CRUD) SelectByID(w http.ResponseWriter, r *http.Request) {
 //	@Param			recipes_outputs_rows		query	integer	false	"Number of rows to be returned from recipes_outputs table"
 //	@Param			machines_recipes_id_start	query	integer	false	"Id of first record to be retreived from machines_recipes table"
 //	@Param			machines_recipes_rows		query	integer	false	"Number of rows to be returned from machines_recipes table"
+//	@Param			transport_tiers_id_start	query	integer	false	"Id of first record to be retreived from transport_tiers table"
+//	@Param			transport_tiers_rows		query	integer	false	"Number of rows to be returned from transport_tiers table"
+//	@Param			unlock_tiers_id_start		query	integer	false	"Id of first record to be retreived from unlock_tiers table"
+//	@Param			unlock_tiers_rows			query	integer	false	"Number of rows to be returned from unlock_tiers table"
+//	@Param			machines_costs_id_start		query	integer	false	"Id of first record to be retreived from machines_costs table"
+//	@Param			machines_costs_rows			query	integer	false	"Number of rows to be returned from machines_costs table"
 //	@Tags			CRUD Authorization required
 //	@Success		200	{object}	handler.JSONData
 //	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
@@ -425,6 +493,70 @@ func (h *CRUD) Select(w http.ResponseWriter, r *http.Request) {
 	}
 	returnData.MachinesRecipesList = machinesRecipesResult
 
+	transportTiersIdStart, err := strconv.Atoi(r.URL.Query().Get("transport_tiers_id_start"))
+	if (err != nil && !errors.Is(err, strconv.ErrSyntax)) || transportTiersIdStart < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("transport_tiers_id_start should be a positive integer"))
+		return
+	}
+	transportTiersIdStart = 0
+	transportTiersRows, err := strconv.Atoi(r.URL.Query().Get("transport_tiers_rows"))
+	if (err != nil && !errors.Is(err, strconv.ErrSyntax)) || transportTiersRows < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("transport_tiers_rows should be a positive integer"))
+		return
+	}
+	transportTiersRows = 0
+	transportTiersResult, err := h.TransportTierRepo.SelectTransportTiers(r.Context(), transportTiersIdStart, transportTiersRows, userId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not retrieve data from database, reason: %w", err).Error()))
+		return
+	}
+	returnData.TransportTiersList = transportTiersResult
+	unlockTiersIdStart, err := strconv.Atoi(r.URL.Query().Get("unlock_tiers_id_start"))
+	if (err != nil && !errors.Is(err, strconv.ErrSyntax)) || unlockTiersIdStart < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("unlock_tiers_id_start should be a positive integer"))
+		return
+	}
+	unlockTiersIdStart = 0
+	unlockTiersRows, err := strconv.Atoi(r.URL.Query().Get("unlock_tiers_rows"))
+	if (err != nil && !errors.Is(err, strconv.ErrSyntax)) || unlockTiersRows < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("unlock_tiers_rows should be a positive integer"))
+		return
+	}
+	unlockTiersRows = 0
+	unlockTiersResult, err := h.UnlockTierRepo.SelectUnlockTiers(r.Context(), unlockTiersIdStart, unlockTiersRows, userId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not retrieve data from database, reason: %w", err).Error()))
+		return
+	}
+	returnData.UnlockTiersList = unlockTiersResult
+	machinesCostsIdStart, err := strconv.Atoi(r.URL.Query().Get("machines_costs_id_start"))
+	if (err != nil && !errors.Is(err, strconv.ErrSyntax)) || machinesCostsIdStart < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("machines_costs_id_start should be a positive integer"))
+		return
+	}
+	machinesCostsIdStart = 0
+	machinesCostsRows, err := strconv.Atoi(r.URL.Query().Get("machines_costs_rows"))
+	if (err != nil && !errors.Is(err, strconv.ErrSyntax)) || machinesCostsRows < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("machines_costs_rows should be a positive integer"))
+		return
+	}
+	machinesCostsRows = 0
+	machinesCostsResult, err := h.MachineCostRepo.SelectMachinesCosts(r.Context(), machinesCostsIdStart, machinesCostsRows, userId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not retrieve data from database, reason: %w", err).Error()))
+		return
+	}
+	returnData.MachinesCostsList = machinesCostsResult
+
 	byteJSONRepresentation, err := json.Marshal(returnData)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -439,6 +571,8 @@ func (h *CRUD) Select(w http.ResponseWriter, r *http.Request) {
 // Insert insert record(s) into the database
 //
 //	@Description	Insert data into database. The user to whom the ownership of records is assigned is the user who presented the authentication token.
+//	@Description	Recipes inputs, recipes outputs, machines recipes and machines costs can reference recipes, resources and machines by RecipesRef, ResourcesRef and MachinesRef fields instead of ids. A reference is resolved to the record with matching TempId inserted in the same request, otherwise to the record with matching name inserted in the same request, otherwise to the existing record of the user with matching name. All data is inserted in one transaction, nothing is inserted if any record cannot be inserted or any reference cannot be resolved.
+//	@Param			dry_run	query	bool				false	"If true, changes are validated and numbers of affected records are reported, but changes are rolled back"
 //	@Param			insert	body	handler.JSONData	true	"Data to be inserted into database"
 //	@Tags			CRUD Authorization required
 //
@@ -466,6 +600,16 @@ func (h *CRUD) Insert(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("provided jwt is invalid"))
 		return
 	}
+	dryRun := false
+	if dryRunParam := r.URL.Query().Get("dry_run"); dryRunParam != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunParam)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("dry_run should be either true or false"))
+			return
+		}
+	}
 	inputData := JSONData{}
 	err := json.NewDecoder(r.Body).Decode(&inputData)
 	if err != nil {
@@ -473,103 +617,19 @@ func (h *CRUD) Insert(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(fmt.Errorf("could not parse received body, reason: %w", err).Error()))
 		return
 	}
-	response := InsertResponse{}
-	response.MachinesInserted = 0
-	response.ResourcesInserted = 0
-	response.RecipesInserted = 0
-	response.RecipesInputsInserted = 0
-	response.RecipesOutputsInserted = 0
-	response.MachinesRecipesInserted = 0
-	skipRows := false
-	if inputData.MachinesList != nil {
-		result, err := h.MachineRepo.InsertMachines(r.Context(), inputData.MachinesList, uint(userId))
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not insert requested machines data, reason: %w", err).Error()))
-		}
-		if !skipRows {
-			noRows, err := result.RowsAffected()
-			if err != nil {
-				w.Write([]byte("database driver does not support returning numbers of rows affected"))
-				skipRows = true
-			}
-			response.MachinesInserted = uint(noRows)
-		}
-	}
-	if inputData.ResourcesList != nil {
-		result, err := h.ResourceRepo.InsertResources(r.Context(), inputData.ResourcesList, uint(userId))
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not insert requested resources data, reason: %w", err).Error()))
-		}
-		if !skipRows {
-			noRows, err := result.RowsAffected()
-			if err != nil {
-				w.Write([]byte("database driver does not support returning numbers of rows affected"))
-				skipRows = true
-			}
-			response.ResourcesInserted = uint(noRows)
-		}
-	}
-	if inputData.RecipesList != nil {
-		result, err := h.RecipeRepo.InsertRecipes(r.Context(), inputData.RecipesList, uint(userId))
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not insert requested recipes data, reason: %w", err).Error()))
-		}
-		if !skipRows {
-			noRows, err := result.RowsAffected()
-			if err != nil {
-				w.Write([]byte("database driver does not support returning numbers of rows affected"))
-				skipRows = true
-			}
-			response.RecipesInserted = uint(noRows)
-		}
-	}
-	if inputData.RecipesInputsList != nil {
-		result, err := h.RecipeinputRepo.InsertRecipesInputs(r.Context(), inputData.RecipesInputsList, uint(userId))
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not insert requested recipes_inputs data, reason: %w", err).Error()))
-		}
-		if !skipRows {
-			noRows, err := result.RowsAffected()
-			if err != nil {
-				w.Write([]byte("database driver does not support returning numbers of rows affected"))
-				skipRows = true
-			}
-			response.RecipesInputsInserted = uint(noRows)
-		}
+	response, err := h.insertData(r.Context(), inputData, uint(userId), dryRun)
+	if errors.Is(err, errUnresolvedReference) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Errorf("could not resolve references, reason: %w", err).Error()))
+		return
 	}
-	if inputData.RecipesOutputsList != nil {
-		result, err := h.RecipeoutputRepo.InsertRecipesOutputs(r.Context(), inputData.RecipesOutputsList, uint(userId))
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not insert requested recipes_outputs data, reason: %w", err).Error()))
-		}
-		if !skipRows {
-			noRows, err := result.RowsAffected()
-			if err != nil {
-				w.Write([]byte("database driver does not support returning numbers of rows affected"))
-				skipRows = true
-			}
-			response.RecipesOutputsInserted = uint(noRows)
-		}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not insert requested data, reason: %w", err).Error()))
+		return
 	}
-	if inputData.MachinesRecipesList != nil {
-		result, err := h.MachineRecipeRepo.InsertMachinesRecipes(r.Context(), inputData.MachinesRecipesList, uint(userId))
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not insert requested machines_recipes data, reason: %w", err).Error()))
-		}
-		if !skipRows {
-			noRows, err := result.RowsAffected()
-			if err != nil {
-				w.Write([]byte("database driver does not support returning numbers of rows affected"))
-				skipRows = true
-			}
-			response.MachinesRecipesInserted = uint(noRows)
-		}
+	if !dryRun {
+		h.CalculatorNotifier.InvalidateRecipeData(r.Context(), userId)
 	}
 	byteJSONRepresentation, err := json.Marshal(response)
 	if err != nil {
@@ -577,13 +637,19 @@ func (h *CRUD) Insert(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(fmt.Errorf("data has been inserted, but could not generate json representation of response, reason: %w", err).Error()))
 		return
 	}
-	w.WriteHeader(http.StatusCreated)
+	if dryRun {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
 	w.Write(byteJSONRepresentation)
 }
 
 // Update update record(s) in the database
 //
 //	@Description	Updates data in database. Updates the records based on "id" field of an element in the array sent in request body. If a record with a particular id does not belong to the user who presented authentication token, then that record is not updated.
+//	@Description	All data is updated in one transaction, nothing is updated if any record cannot be updated.
+//	@Param			dry_run	query	bool				false	"If true, changes are validated and numbers of affected records are reported, but changes are rolled back"
 //	@Param			update	body	handler.JSONData	true	"Data to be updated in the database"
 //	@Tags			CRUD Authorization required
 //
@@ -611,6 +677,16 @@ func (h *CRUD) Update(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("provided jwt is invalid"))
 		return
 	}
+	dryRun := false
+	if dryRunParam := r.URL.Query().Get("dry_run"); dryRunParam != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunParam)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("dry_run should be either true or false"))
+			return
+		}
+	}
 	inputData := JSONData{}
 	err := json.NewDecoder(r.Body).Decode(&inputData)
 	if err != nil {
@@ -625,114 +701,59 @@ func (h *CRUD) Update(w http.ResponseWriter, r *http.Request) {
 	response.RecipesInputsUpdated = 0
 	response.RecipesOutputsUpdated = 0
 	response.MachinesRecipesUpdated = 0
-	skipRows := false
-	if inputData.MachinesList != nil {
-		result, err := h.MachineRepo.UpdateMachines(r.Context(), inputData.MachinesList, uint(userId))
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not update requested machines data, reason: %w", err).Error()))
-			return
-		}
-		if !skipRows {
-			for _, row := range result {
-				noRows, err := row.RowsAffected()
-				if err != nil {
-					w.Write([]byte("database driver does not support returning numbers of rows affected"))
-					skipRows = true
-				}
-				response.MachinesUpdated += uint(noRows)
-			}
-		}
-	}
-	if inputData.ResourcesList != nil {
-		result, err := h.ResourceRepo.UpdateResources(r.Context(), inputData.ResourcesList, uint(userId))
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not update requested resources data, reason: %w", err).Error()))
-			return
-		}
-		if !skipRows {
-			for _, row := range result {
-				noRows, err := row.RowsAffected()
-				if err != nil {
-					w.Write([]byte("database driver does not support returning numbers of rows affected"))
-					skipRows = true
-				}
-				response.ResourcesUpdated += uint(noRows)
-			}
-		}
-	}
-	if inputData.RecipesList != nil {
-		result, err := h.RecipeRepo.UpdateRecipes(r.Context(), inputData.RecipesList, uint(userId))
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not update requested recipes data, reason: %w", err).Error()))
-			return
-		}
-		if !skipRows {
-			for _, row := range result {
-				noRows, err := row.RowsAffected()
-				if err != nil {
-					w.Write([]byte("database driver does not support returning numbers of rows affected"))
-					skipRows = true
-				}
-				response.RecipesUpdated += uint(noRows)
-			}
-		}
+	response.TransportTiersUpdated = 0
+	response.UnlockTiersUpdated = 0
+	response.MachinesCostsUpdated = 0
+	ctx := r.Context()
+	transaction, err := h.MachineRepo.DB.BeginTx(ctx, nil)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not start a transaction, reason: %w", err).Error()))
+		return
 	}
-	if inputData.RecipesInputsList != nil {
-		result, err := h.RecipeinputRepo.UpdateRecipesInputs(r.Context(), inputData.RecipesInputsList, uint(userId))
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not update requested recipes_inputs data, reason: %w", err).Error()))
-			return
-		}
-		if !skipRows {
-			for _, row := range result {
-				noRows, err := row.RowsAffected()
-				if err != nil {
-					w.Write([]byte("database driver does not support returning numbers of rows affected"))
-					skipRows = true
-				}
-				response.RecipesInputsUpdated += uint(noRows)
-			}
-		}
+	updates := []transactionStep{
+		{"machines", inputData.MachinesList == nil, func() ([]sql.Result, error) {
+			return h.MachineRepo.UpdateMachinesWithTransaction(ctx, transaction, inputData.MachinesList, uint(userId))
+		}, &response.MachinesUpdated},
+		{"resources", inputData.ResourcesList == nil, func() ([]sql.Result, error) {
+			return h.ResourceRepo.UpdateResourcesWithTransaction(ctx, transaction, inputData.ResourcesList, uint(userId))
+		}, &response.ResourcesUpdated},
+		{"recipes", inputData.RecipesList == nil, func() ([]sql.Result, error) {
+			return h.RecipeRepo.UpdateRecipesWithTransaction(ctx, transaction, inputData.RecipesList, uint(userId))
+		}, &response.RecipesUpdated},
+		{"recipes_inputs", inputData.RecipesInputsList == nil, func() ([]sql.Result, error) {
+			return h.RecipeinputRepo.UpdateRecipesInputsWithTransaction(ctx, transaction, inputData.RecipesInputsList, uint(userId))
+		}, &response.RecipesInputsUpdated},
+		{"recipes_outputs", inputData.RecipesOutputsList == nil, func() ([]sql.Result, error) {
+			return h.RecipeoutputRepo.UpdateRecipesOutputsWithTransaction(ctx, transaction, inputData.RecipesOutputsList, uint(userId))
+		}, &response.RecipesOutputsUpdated},
+		{"machines_recipes", inputData.MachinesRecipesList == nil, func() ([]sql.Result, error) {
+			return h.MachineRecipeRepo.UpdateMachinesRecipesWithTransaction(ctx, transaction, inputData.MachinesRecipesList, uint(userId))
+		}, &response.MachinesRecipesUpdated},
+		{"transport_tiers", inputData.TransportTiersList == nil, func() ([]sql.Result, error) {
+			return h.TransportTierRepo.UpdateTransportTiersWithTransaction(ctx, transaction, inputData.TransportTiersList, uint(userId))
+		}, &response.TransportTiersUpdated},
+		{"unlock_tiers", inputData.UnlockTiersList == nil, func() ([]sql.Result, error) {
+			return h.UnlockTierRepo.UpdateUnlockTiersWithTransaction(ctx, transaction, inputData.UnlockTiersList, uint(userId))
+		}, &response.UnlockTiersUpdated},
+		{"machines_costs", inputData.MachinesCostsList == nil, func() ([]sql.Result, error) {
+			return h.MachineCostRepo.UpdateMachinesCostsWithTransaction(ctx, transaction, inputData.MachinesCostsList, uint(userId))
+		}, &response.MachinesCostsUpdated},
+	}
+	err = executeSteps(transaction, "update", updates)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
 	}
-	if inputData.RecipesOutputsList != nil {
-		result, err := h.RecipeoutputRepo.UpdateRecipesOutputs(r.Context(), inputData.RecipesOutputsList, uint(userId))
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not update requested recipes_outputs data, reason: %w", err).Error()))
-			return
-		}
-		if !skipRows {
-			for _, row := range result {
-				noRows, err := row.RowsAffected()
-				if err != nil {
-					w.Write([]byte("database driver does not support returning numbers of rows affected"))
-					skipRows = true
-				}
-				response.RecipesOutputsUpdated += uint(noRows)
-			}
-		}
+	err = finishTransaction(transaction, dryRun)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not finish transaction, reason: %w", err).Error()))
+		return
 	}
-	if inputData.MachinesRecipesList != nil {
-		result, err := h.MachineRecipeRepo.UpdateMachinesRecipes(r.Context(), inputData.MachinesRecipesList, uint(userId))
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not update requested machines_recipes data, reason: %w", err).Error()))
-			return
-		}
-		if !skipRows {
-			for _, row := range result {
-				noRows, err := row.RowsAffected()
-				if err != nil {
-					w.Write([]byte("database driver does not support returning numbers of rows affected"))
-					skipRows = true
-				}
-				response.MachinesRecipesUpdated += uint(noRows)
-			}
-		}
+	if !dryRun {
+		h.CalculatorNotifier.InvalidateRecipeData(r.Context(), userId)
 	}
 	byteJSONRepresentation, err := json.Marshal(response)
 	if err != nil {
@@ -747,6 +768,8 @@ func (h *CRUD) Update(w http.ResponseWriter, r *http.Request) {
 // Delete delete record(s) in the database
 //
 //	@Description	Deletes data in database. Each table has it's own id list to be deleted. If a record with a particular id does not belong to the user who presented authentication token, then that record is not deleted.
+//	@Description	Records are deleted in one transaction, nothing is deleted if any record cannot be deleted. Recipes inputs, recipes outputs, machines recipes and machines costs are deleted before machines, resources and recipes, so that records can be deleted together with records referencing them.
+//	@Param			dry_run	query	bool				false	"If true, changes are validated and numbers of affected records are reported, but changes are rolled back"
 //	@Param			delete	body	handler.DeleteInput	true	"Data to be deleted in the database"
 //	@Tags			CRUD Authorization required
 //
@@ -774,6 +797,16 @@ func (h *CRUD) Delete(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("provided jwt is invalid"))
 		return
 	}
+	dryRun := false
+	if dryRunParam := r.URL.Query().Get("dry_run"); dryRunParam != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunParam)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("dry_run should be either true or false"))
+			return
+		}
+	}
 	inputData := DeleteInput{}
 	response := DeleteResponse{}
 	response.MachinesDeleted = 0
@@ -782,102 +815,65 @@ func (h *CRUD) Delete(w http.ResponseWriter, r *http.Request) {
 	response.RecipesInputsDeleted = 0
 	response.RecipesOutputsDeleted = 0
 	response.MachinesRecipesDeleted = 0
-	skipRows := false
+	response.TransportTiersDeleted = 0
+	response.UnlockTiersDeleted = 0
+	response.MachinesCostsDeleted = 0
 	err := json.NewDecoder(r.Body).Decode(&inputData)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		w.Write([]byte(fmt.Errorf("could not parse received body, reason: %w", err).Error()))
 		return
 	}
-	if inputData.MachinesIds != nil {
-		result, err := h.MachineRepo.DeleteMachines(r.Context(), inputData.MachinesIds, userId)
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not delete requested machines data, reason: %w", err).Error()))
-		}
-		if !skipRows {
-			noRows, err := result.RowsAffected()
-			if err != nil {
-				w.Write([]byte("database driver does not support returning numbers of rows affected"))
-				skipRows = true
-			}
-			response.MachinesDeleted = uint(noRows)
-		}
-	}
-	if inputData.ResourcesIds != nil {
-		result, err := h.ResourceRepo.DeleteResources(r.Context(), inputData.ResourcesIds, userId)
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not delete requested resources data, reason: %w", err).Error()))
-		}
-		if !skipRows {
-			noRows, err := result.RowsAffected()
-			if err != nil {
-				w.Write([]byte("database driver does not support returning numbers of rows affected"))
-				skipRows = true
-			}
-			response.ResourcesDeleted = uint(noRows)
-		}
-	}
-	if inputData.RecipesIds != nil {
-		result, err := h.RecipeRepo.DeleteRecipes(r.Context(), inputData.RecipesIds, userId)
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not delete requested recipes data, reason: %w", err).Error()))
-		}
-		if !skipRows {
-			noRows, err := result.RowsAffected()
-			if err != nil {
-				w.Write([]byte("database driver does not support returning numbers of rows affected"))
-				skipRows = true
-			}
-			response.RecipesDeleted = uint(noRows)
-		}
+	ctx := r.Context()
+	transaction, err := h.MachineRepo.DB.BeginTx(ctx, nil)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not start a transaction, reason: %w", err).Error()))
+		return
 	}
-	if inputData.RecipesInputsIds != nil {
-		result, err := h.RecipeinputRepo.DeleteRecipesInputs(r.Context(), inputData.RecipesInputsIds, userId)
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not delete requested recipes_inputs data, reason: %w", err).Error()))
-		}
-		if !skipRows {
-			noRows, err := result.RowsAffected()
-			if err != nil {
-				w.Write([]byte("database driver does not support returning numbers of rows affected"))
-				skipRows = true
-			}
-			response.RecipesInputsDeleted = uint(noRows)
-		}
+	deletions := []transactionStep{
+		{"recipes_inputs", inputData.RecipesInputsIds == nil, func() ([]sql.Result, error) {
+			return singleResult(h.RecipeinputRepo.DeleteRecipesInputsWithTransaction(ctx, transaction, inputData.RecipesInputsIds, userId))
+		}, &response.RecipesInputsDeleted},
+		{"recipes_outputs", inputData.RecipesOutputsIds == nil, func() ([]sql.Result, error) {
+			return singleResult(h.RecipeoutputRepo.DeleteRecipesOutputsWithTransaction(ctx, transaction, inputData.RecipesOutputsIds, userId))
+		}, &response.RecipesOutputsDeleted},
+		{"machines_recipes", inputData.MachinesRecipesIds == nil, func() ([]sql.Result, error) {
+			return singleResult(h.MachineRecipeRepo.DeleteMachinesRecipesWithTransaction(ctx, transaction, inputData.MachinesRecipesIds, userId))
+		}, &response.MachinesRecipesDeleted},
+		{"machines_costs", inputData.MachinesCostsIds == nil, func() ([]sql.Result, error) {
+			return singleResult(h.MachineCostRepo.DeleteMachinesCostsWithTransaction(ctx, transaction, inputData.MachinesCostsIds, userId))
+		}, &response.MachinesCostsDeleted},
+		{"machines", inputData.MachinesIds == nil, func() ([]sql.Result, error) {
+			return singleResult(h.MachineRepo.DeleteMachinesWithTransaction(ctx, transaction, inputData.MachinesIds, userId))
+		}, &response.MachinesDeleted},
+		{"resources", inputData.ResourcesIds == nil, func() ([]sql.Result, error) {
+			return singleResult(h.ResourceRepo.DeleteResourcesWithTransaction(ctx, transaction, inputData.ResourcesIds, userId))
+		}, &response.ResourcesDeleted},
+		{"recipes", inputData.RecipesIds == nil, func() ([]sql.Result, error) {
+			return singleResult(h.RecipeRepo.DeleteRecipesWithTransaction(ctx, transaction, inputData.RecipesIds, userId))
+		}, &response.RecipesDeleted},
+		{"transport_tiers", inputData.TransportTiersIds == nil, func() ([]sql.Result, error) {
+			return singleResult(h.TransportTierRepo.DeleteTransportTiersWithTransaction(ctx, transaction, inputData.TransportTiersIds, userId))
+		}, &response.TransportTiersDeleted},
+		{"unlock_tiers", inputData.UnlockTiersIds == nil, func() ([]sql.Result, error) {
+			return singleResult(h.UnlockTierRepo.DeleteUnlockTiersWithTransaction(ctx, transaction, inputData.UnlockTiersIds, userId))
+		}, &response.UnlockTiersDeleted},
+	}
+	err = executeSteps(transaction, "delete", deletions)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
 	}
-	if inputData.RecipesOutputsIds != nil {
-		result, err := h.RecipeoutputRepo.DeleteRecipesOutputs(r.Context(), inputData.RecipesOutputsIds, userId)
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not delete requested recipes_outputs data, reason: %w", err).Error()))
-		}
-		if !skipRows {
-			noRows, err := result.RowsAffected()
-			if err != nil {
-				w.Write([]byte("database driver does not support returning numbers of rows affected"))
-				skipRows = true
-			}
-			response.RecipesOutputsDeleted = uint(noRows)
-		}
+	err = finishTransaction(transaction, dryRun)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not finish transaction, reason: %w", err).Error()))
+		return
 	}
-	if inputData.MachinesRecipesIds != nil {
-		result, err := h.MachineRecipeRepo.DeleteMachinesRecipes(r.Context(), inputData.MachinesRecipesIds, userId)
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("could not delete requested recipes_outputs data, reason: %w", err).Error()))
-		}
-		if !skipRows {
-			noRows, err := result.RowsAffected()
-			if err != nil {
-				w.Write([]byte("database driver does not support returning numbers of rows affected"))
-				skipRows = true
-			}
-			response.MachinesRecipesDeleted = uint(noRows)
-		}
+	if !dryRun {
+		h.CalculatorNotifier.InvalidateRecipeData(r.Context(), userId)
 	}
 	byteJSONRepresentation, err := json.Marshal(response)
 	if err != nil {
@@ -923,6 +919,10 @@ func (h *CRUD) DeleteByUser(w http.ResponseWriter, r *http.Request) {
 	response.RecipesInputsDeleted = 0
 	response.RecipesOutputsDeleted = 0
 	response.MachinesRecipesDeleted = 0
+	response.TransportTiersDeleted = 0
+	response.UnlockTiersDeleted = 0
+	response.MachinesCostsDeleted = 0
+	response.PlansDeleted = 0
 	ctx := r.Context()
 	transaction, err := h.MachineRepo.DB.BeginTx(ctx, nil)
 	if err != nil {
@@ -930,113 +930,125 @@ func (h *CRUD) DeleteByUser(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(fmt.Errorf("could not start a transaction, reason: %w", err).Error()))
 		return
 	}
-	skipRows := false
-
-	result, err := h.MachineRepo.DeleteMachinesByUserId(ctx, transaction, userId)
+	deletions := []transactionStep{
+		{"machines", false, func() ([]sql.Result, error) {
+			return singleResult(h.MachineRepo.DeleteMachinesByUserId(ctx, transaction, userId))
+		}, &response.MachinesDeleted},
+		{"resources", false, func() ([]sql.Result, error) {
+			return singleResult(h.ResourceRepo.DeleteResourcesByUserId(ctx, transaction, userId))
+		}, &response.ResourcesDeleted},
+		{"recipes", false, func() ([]sql.Result, error) {
+			return singleResult(h.RecipeRepo.DeleteRecipesByUserId(ctx, transaction, userId))
+		}, &response.RecipesDeleted},
+		{"recipes_inputs", false, func() ([]sql.Result, error) {
+			return singleResult(h.RecipeinputRepo.DeleteRecipesInputsByUserId(ctx, transaction, userId))
+		}, &response.RecipesInputsDeleted},
+		{"recipes_outputs", false, func() ([]sql.Result, error) {
+			return singleResult(h.RecipeoutputRepo.DeleteRecipesOutputsByUserId(ctx, transaction, userId))
+		}, &response.RecipesOutputsDeleted},
+		{"machines_recipes", false, func() ([]sql.Result, error) {
+			return singleResult(h.MachineRecipeRepo.DeleteMachinesRecipesByUserId(ctx, transaction, userId))
+		}, &response.MachinesRecipesDeleted},
+		{"transport_tiers", false, func() ([]sql.Result, error) {
+			return singleResult(h.TransportTierRepo.DeleteTransportTiersByUserId(ctx, transaction, userId))
+		}, &response.TransportTiersDeleted},
+		{"unlock_tiers", false, func() ([]sql.Result, error) {
+			return singleResult(h.UnlockTierRepo.DeleteUnlockTiersByUserId(ctx, transaction, userId))
+		}, &response.UnlockTiersDeleted},
+		{"machines_costs", false, func() ([]sql.Result, error) {
+			return singleResult(h.MachineCostRepo.DeleteMachinesCostsByUserId(ctx, transaction, userId))
+		}, &response.MachinesCostsDeleted},
+		{"plans", false, func() ([]sql.Result, error) {
+			return singleResult(h.PlanRepo.DeletePlansByUserId(ctx, transaction, userId))
+		}, &response.PlansDeleted},
+		{"progress", false, func() ([]sql.Result, error) {
+			return singleResult(h.ProgressRepo.DeleteProgressByUserId(ctx, transaction, userId))
+		}, &response.ProgressDeleted},
+	}
+	err = executeSteps(transaction, "delete", deletions)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(fmt.Errorf("could not delete requested machines data, reason: %w", err).Error()))
+		w.Write([]byte(err.Error()))
 		return
 	}
-	if !skipRows {
-		noRows, err := result.RowsAffected()
-		if err != nil {
-			w.Write([]byte("database driver does not support returning numbers of rows affected"))
-			skipRows = true
-		}
-		response.MachinesDeleted = uint(noRows)
-	}
-	result, err = h.ResourceRepo.DeleteResourcesByUserId(ctx, transaction, userId)
+	err = finishTransaction(transaction, false)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(fmt.Errorf("could not delete requested resources data, reason: %w", err).Error()))
+		w.Write([]byte(fmt.Errorf("could not finish transaction, reason: %w", err).Error()))
 		return
 	}
-	if !skipRows {
-		noRows, err := result.RowsAffected()
-		if err != nil {
-			w.Write([]byte("database driver does not support returning numbers of rows affected"))
-			skipRows = true
-		}
-		response.ResourcesDeleted = uint(noRows)
-	}
-	result, err = h.RecipeRepo.DeleteRecipesByUserId(ctx, transaction, userId)
+	h.CalculatorNotifier.InvalidateRecipeData(r.Context(), userId)
+	byteJSONRepresentation, err := json.Marshal(response)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(fmt.Errorf("could not delete requested recipes data, reason: %w", err).Error()))
+		w.Write([]byte(fmt.Errorf("data has been deleted, but could not generate json representation of response, reason: %w", err).Error()))
 		return
 	}
-	if !skipRows {
-		noRows, err := result.RowsAffected()
-		if err != nil {
-			w.Write([]byte("database driver does not support returning numbers of rows affected"))
-			skipRows = true
-		}
-		response.RecipesDeleted = uint(noRows)
-	}
-	result, err = h.RecipeinputRepo.DeleteRecipesInputsByUserId(ctx, transaction, userId)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(fmt.Errorf("could not delete requested recipes_inputs data, reason: %w", err).Error()))
-		return
+	w.WriteHeader(http.StatusOK)
+	w.Write(byteJSONRepresentation)
+}
+
+// finishTransaction commits transaction, or rolls it back if only a dry run has been requested.
+func finishTransaction(transaction *sql.Tx, dryRun bool) error {
+	if dryRun {
+		return transaction.Rollback()
 	}
-	if !skipRows {
-		noRows, err := result.RowsAffected()
-		if err != nil {
-			w.Write([]byte("database driver does not support returning numbers of rows affected"))
-			skipRows = true
+	return transaction.Commit()
+}
+
+// transactionStep is an operation of a repository executed as a part of transaction. Rows affected by the operation
+// are counted into count, the operation is not executed if skip is true.
+type transactionStep struct {
+	kind    string
+	skip    bool
+	execute func() ([]sql.Result, error)
+	count   *uint
+}
+
+// executeSteps executes steps in order as a part of transaction. If any step fails or its affected rows cannot be
+// counted, transaction is rolled back, so that changes are never applied without being counted.
+func executeSteps(transaction *sql.Tx, action string, steps []transactionStep) error {
+	for _, step := range steps {
+		if step.skip {
+			continue
+		}
+		results, err := step.execute()
+		if err == nil {
+			*step.count, err = rowsAffected(results...)
 		}
-		response.RecipesInputsDeleted = uint(noRows)
-	}
-	result, err = h.RecipeoutputRepo.DeleteRecipesOutputsByUserId(ctx, transaction, userId)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(fmt.Errorf("could not delete requested recipes_outputs data, reason: %w", err).Error()))
-		return
-	}
-	if !skipRows {
-		noRows, err := result.RowsAffected()
 		if err != nil {
-			w.Write([]byte("database driver does not support returning numbers of rows affected"))
-			skipRows = true
+			return rollback(transaction, fmt.Errorf("could not %s requested %s data, reason: %w", action, step.kind, err))
 		}
-		response.RecipesOutputsDeleted = uint(noRows)
 	}
-	result, err = h.MachineRecipeRepo.DeleteMachinesRecipesByUserId(ctx, transaction, userId)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(fmt.Errorf("could not delete requested machines_inputs data, reason: %w", err).Error()))
-		return
-	}
-	if !skipRows {
+	return nil
+}
+
+// singleResult returns result of an operation executing a single statement as results of transactionStep
+func singleResult(result sql.Result, err error) ([]sql.Result, error) {
+	return []sql.Result{result}, err
+}
+
+// rowsAffected sums numbers of rows affected by results of statements
+func rowsAffected(results ...sql.Result) (uint, error) {
+	var total uint
+	for _, result := range results {
 		noRows, err := result.RowsAffected()
 		if err != nil {
-			w.Write([]byte("database driver does not support returning numbers of rows affected"))
-			skipRows = true
-		}
-		response.MachinesRecipesDeleted = uint(noRows)
-	}
-	err = transaction.Commit()
-	if err != nil {
-		rollbackErr := transaction.Rollback()
-		if rollbackErr != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("an error occurred, could not rollback transaction, reason: %w", rollbackErr).Error()))
-			return
-		} else {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Errorf("an error occurred, transaction has been rolled back, reason: %w", err).Error()))
-			return
+			return 0, fmt.Errorf("database driver does not support returning numbers of rows affected: %w", err)
 		}
+		total += uint(noRows)
 	}
-	byteJSONRepresentation, err := json.Marshal(response)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(fmt.Errorf("data has been deleted, but could not generate json representation of response, reason: %w", err).Error()))
-		return
-	}
-	w.WriteHeader(http.StatusOK)
-	w.Write(byteJSONRepresentation)
+	return total, nil
+}
+
+// rollback rolls transaction back after err occurred and returns err wrapped with the outcome of the rollback.
+// Transactions already rolled back by repositories are not treated as failed rollbacks.
+func rollback(transaction *sql.Tx, err error) error {
+	rollbackErr := transaction.Rollback()
+	if rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+		return fmt.Errorf("an error occurred, could not rollback transaction, reason: %w", rollbackErr)
+	}
+	return fmt.Errorf("an error occurred, transaction has been rolled back, reason: %w", err)
 }
 
 // todo: implement verification of jwt
@@ -0,0 +1,267 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/importer"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+)
+
+type ImportResponse struct {
+	MachinesInserted        uint
+	ResourcesInserted       uint
+	RecipesInserted         uint
+	RecipesInputsInserted   uint
+	RecipesOutputsInserted  uint
+	MachinesRecipesInserted uint
+	Skipped                 []importer.SkippedEntry
+}
+
+// ImportSatisfactory import game data from Satisfactory Docs.json
+//
+//	@Description	Import buildings, items, fluids and recipes from Docs.json file distributed with Satisfactory and insert them as machines, resources and recipes of the user that provided authentication token. Manufacturers, extractors and fuel generators are imported, recipes not produced in any imported machine are skipped. Amounts and durations are scaled by the smallest factor making all of them whole numbers. Machines and resources with names already used by the user are not inserted, existing records are used instead. Recipes with names already used by the user are skipped. All data is inserted in one transaction, response lists numbers of inserted records and skipped entries.
+//	@Param			docs	body	object	true	"Contents of Docs.json, UTF-16 and UTF-8 encodings are accepted"
+//	@Tags			CRUD Authorization required
+//
+//	@Accept			json
+//
+//	@Success		200	{object}	handler.ImportResponse
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/import/satisfactory [post]
+//
+//	@Security		apiTokenAuth
+func (h *CRUD) ImportSatisfactory(w http.ResponseWriter, r *http.Request) {
+	//parameters for request are:
+	//jwt = token with dispatcher server secret key, id of user who received the token and issue date of the token, not optional
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, userId := h.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Errorf("could not read received body, reason: %w", err).Error()))
+		return
+	}
+	data, err := importer.ParseSatisfactoryDocs(body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Errorf("could not parse received body, reason: %w", err).Error()))
+		return
+	}
+	h.writeImportResponse(w, r.Context(), data, userId)
+}
+
+// ImportFactorio import game data from Factorio data.raw
+//
+//	@Description	Import recipes, items, fluids and machines from data.raw of Factorio, as dumped by the game with --dump-data option, and insert them as recipes, resources and machines of the user that provided authentication token. Assembling machines, furnaces and rocket silos run recipes of their crafting categories, mining drills and offshore pumps get recipes extracting resources. Liquid slots of machines are derived from their fluid boxes. Recipes not crafted in any imported machine are skipped. Amounts and durations are scaled by the smallest factor making all of them whole numbers. Machines and resources with names already used by the user are not inserted, existing records are used instead. Recipes with names already used by the user are skipped. All data is inserted in one transaction, response lists numbers of inserted records and skipped entries.
+//	@Param			difficulty	query	string	false	"Difficulty of recipes, either 'normal' or 'expensive', normal by default"
+//	@Param			dataRaw		body	object	true	"Contents of data.raw dump"
+//	@Tags			CRUD Authorization required
+//
+//	@Accept			json
+//
+//	@Success		200	{object}	handler.ImportResponse
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/import/factorio [post]
+//
+//	@Security		apiTokenAuth
+func (h *CRUD) ImportFactorio(w http.ResponseWriter, r *http.Request) {
+	//parameters for request are:
+	//jwt = token with dispatcher server secret key, id of user who received the token and issue date of the token, not optional
+	//difficulty = difficulty of recipes, optional
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, userId := h.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	difficulty := r.URL.Query().Get("difficulty")
+	if difficulty == "" {
+		difficulty = importer.FactorioNormal
+	}
+	if difficulty != importer.FactorioNormal && difficulty != importer.FactorioExpensive {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("difficulty should be either 'normal' or 'expensive'"))
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Errorf("could not read received body, reason: %w", err).Error()))
+		return
+	}
+	data, err := importer.ParseFactorioDataRaw(body, difficulty)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Errorf("could not parse received body, reason: %w", err).Error()))
+		return
+	}
+	h.writeImportResponse(w, r.Context(), data, userId)
+}
+
+// writeImportResponse inserts game data for the user and writes the report of the import as response.
+func (h *CRUD) writeImportResponse(w http.ResponseWriter, ctx context.Context, data *importer.GameData, userId int) {
+	response, err := h.insertGameData(ctx, data, uint(userId))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not import game data, reason: %w", err).Error()))
+		return
+	}
+	h.CalculatorNotifier.InvalidateRecipeData(ctx, userId)
+	byteJSONRepresentation, err := json.Marshal(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("data has been imported, but could not generate json representation of response, reason: %w", err).Error()))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(byteJSONRepresentation)
+}
+
+// insertGameData inserts machines, resources and recipes of game data for the user in one transaction. Machines and
+// resources with names already used by the user are not inserted, recipes reference existing records instead.
+// Recipes with names already used by the user are skipped.
+func (h *CRUD) insertGameData(ctx context.Context, data *importer.GameData, userId uint) (ImportResponse, error) {
+	response := ImportResponse{Skipped: append([]importer.SkippedEntry{}, data.Skipped...)}
+	transaction, err := h.MachineRepo.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return response, fmt.Errorf("could not start a transaction, reason: %w", err)
+	}
+	existingResources, err := h.ResourceRepo.SelectResourcesWithTransaction(ctx, transaction, int(userId))
+	if err != nil {
+		return response, rollback(transaction, fmt.Errorf("could not retrieve resources, reason: %w", err))
+	}
+	existingMachines, err := h.MachineRepo.SelectMachinesWithTransaction(ctx, transaction, int(userId))
+	if err != nil {
+		return response, rollback(transaction, fmt.Errorf("could not retrieve machines, reason: %w", err))
+	}
+	existingRecipes, err := h.RecipeRepo.SelectRecipesWithTransaction(ctx, transaction, int(userId))
+	if err != nil {
+		return response, rollback(transaction, fmt.Errorf("could not retrieve recipes, reason: %w", err))
+	}
+	resourceIds := make(map[string]uint)
+	for _, resource := range existingResources {
+		resourceIds[resource.Name] = resource.Id
+	}
+	machineIds := make(map[string]uint)
+	for _, machine := range existingMachines {
+		machineIds[machine.Name] = machine.Id
+	}
+	recipeNames := make(map[string]bool)
+	for _, recipe := range existingRecipes {
+		recipeNames[recipe.Name] = true
+	}
+	for _, resource := range data.Resources {
+		if _, exists := resourceIds[resource.Name]; exists {
+			response.Skipped = append(response.Skipped, importer.SkippedEntry{Kind: importer.SkippedResource, Name: resource.Name, Reason: "resource already exists, existing resource is used"})
+			continue
+		}
+		id, err := h.ResourceRepo.InsertResourceWithTransaction(ctx, transaction, resource, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert resource '%s', reason: %w", resource.Name, err))
+		}
+		resourceIds[resource.Name] = uint(id)
+		response.ResourcesInserted++
+	}
+	for _, machine := range data.Machines {
+		if _, exists := machineIds[machine.Name]; exists {
+			response.Skipped = append(response.Skipped, importer.SkippedEntry{Kind: importer.SkippedMachine, Name: machine.Name, Reason: "machine already exists, existing machine is used"})
+			continue
+		}
+		id, err := h.MachineRepo.InsertMachineWithTransaction(ctx, transaction, machine, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert machine '%s', reason: %w", machine.Name, err))
+		}
+		machineIds[machine.Name] = uint(id)
+		response.MachinesInserted++
+	}
+	inputs := []model.RecipeInputOutputInfo{}
+	outputs := []model.RecipeInputOutputInfo{}
+	machinesRecipes := []model.MachinesRecipesInfo{}
+	for _, recipe := range data.Recipes {
+		if recipeNames[recipe.Recipe.Name] {
+			response.Skipped = append(response.Skipped, importer.SkippedEntry{Kind: importer.SkippedRecipe, Name: recipe.Recipe.Name, Reason: "recipe already exists"})
+			continue
+		}
+		id, err := h.RecipeRepo.InsertRecipeWithTransaction(ctx, transaction, recipe.Recipe, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert recipe '%s', reason: %w", recipe.Recipe.Name, err))
+		}
+		recipeNames[recipe.Recipe.Name] = true
+		response.RecipesInserted++
+		for _, input := range recipe.Inputs {
+			inputs = append(inputs, model.RecipeInputOutputInfo{RecipesId: uint(id), ResourcesId: resourceIds[input.Resource], Amount: input.Amount})
+		}
+		for _, output := range recipe.Outputs {
+			outputs = append(outputs, model.RecipeInputOutputInfo{RecipesId: uint(id), ResourcesId: resourceIds[output.Resource], Amount: output.Amount})
+		}
+		for _, machineName := range recipe.Machines {
+			machinesRecipes = append(machinesRecipes, model.MachinesRecipesInfo{RecipesId: uint(id), MachinesId: machineIds[machineName]})
+		}
+	}
+	if len(inputs) > 0 {
+		_, err = h.RecipeinputRepo.InsertRecipesInputsWithTransaction(ctx, transaction, inputs, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert recipes inputs, reason: %w", err))
+		}
+		response.RecipesInputsInserted = uint(len(inputs))
+	}
+	if len(outputs) > 0 {
+		_, err = h.RecipeoutputRepo.InsertRecipesOutputsWithTransaction(ctx, transaction, outputs, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert recipes outputs, reason: %w", err))
+		}
+		response.RecipesOutputsInserted = uint(len(outputs))
+	}
+	if len(machinesRecipes) > 0 {
+		_, err = h.MachineRecipeRepo.InsertMachinesRecipesWithTransaction(ctx, transaction, machinesRecipes, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert machines recipes, reason: %w", err))
+		}
+		response.MachinesRecipesInserted = uint(len(machinesRecipes))
+	}
+	err = finishTransaction(transaction, false)
+	if err != nil {
+		return response, err
+	}
+	return response, nil
+}
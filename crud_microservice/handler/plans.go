@@ -0,0 +1,438 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+)
+
+type PlansData struct {
+	PlansList []model.PlanInfo
+}
+
+type PlansInsertResponse struct {
+	PlansInserted uint
+}
+
+type PlansUpdateResponse struct {
+	PlansUpdated uint
+}
+
+type PlansDeleteResponse struct {
+	PlansDeleted uint
+}
+
+type RecomputedPlan struct {
+	Id             uint
+	Name           string
+	Changed        bool
+	ProductionTree json.RawMessage `swaggertype:"object"`
+}
+
+type PlansRecomputeResponse struct {
+	PlansList []RecomputedPlan
+}
+
+// SelectPlans return saved production plans
+//
+//	@Description	Return production plans saved by the user that provided authentication token, together with their targets, alternative recipes, alternative machines and production trees calculated when plans were saved or last recomputed. If plans_id parameter is present, only plans with those ids are returned, otherwise plans are returned from id range, specified in the same way as in select endpoint.
+//	@Param			plans_id		query	integer	false	"Id of plans to be retreived from database. Can be present multiple times"
+//	@Param			plans_id_start	query	integer	false	"Id of first record to be retreived from plans table"
+//	@Param			plans_rows		query	integer	false	"Number of rows to be returned from plans table"
+//	@Tags			CRUD Authorization required
+//	@Success		200	{object}	handler.PlansData
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/plans [get]
+//
+//	@Security		apiTokenAuth
+func (h *CRUD) SelectPlans(w http.ResponseWriter, r *http.Request) {
+	//parameters that are not mentioned in swagger directly:
+	//jwt = token with dispatcher server secret key, id of user who received the token and issue date of the token, not optional
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, userId := h.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	returnData := PlansData{}
+	plansIds := r.URL.Query()["plans_id"]
+	if plansIds != nil {
+		result, err := h.PlanRepo.SelectPlansById(r.Context(), h.convertArrToInt(plansIds), userId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Errorf("could not retrieve data from database, reason: %w", err).Error()))
+			return
+		}
+		returnData.PlansList = result
+	} else {
+		plansIdStart, err := strconv.Atoi(r.URL.Query().Get("plans_id_start"))
+		if (err != nil && !errors.Is(err, strconv.ErrSyntax)) || plansIdStart < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("plans_id_start should be a positive integer"))
+			return
+		}
+		plansRows, err := strconv.Atoi(r.URL.Query().Get("plans_rows"))
+		if (err != nil && !errors.Is(err, strconv.ErrSyntax)) || plansRows < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("plans_rows should be a positive integer"))
+			return
+		}
+		result, err := h.PlanRepo.SelectPlans(r.Context(), plansIdStart, plansRows, userId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Errorf("could not retrieve data from database, reason: %w", err).Error()))
+			return
+		}
+		returnData.PlansList = result
+	}
+	byteJSONRepresentation, err := json.Marshal(returnData)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not generate json representation of data, reason: %w", err).Error()))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(byteJSONRepresentation)
+}
+
+// InsertPlans save production plans
+//
+//	@Description	Save named production plans of the user that provided authentication token. For every plan, production tree producing its targets with its alternative recipes and alternative machines is calculated by calculator microservice using current data of the user and saved with the plan. Ids, users ids and production trees sent in request body are ignored.
+//	@Param			plans	body	handler.PlansData	true	"Plans to be saved"
+//	@Tags			CRUD Authorization required
+//
+//	@Accept			json
+//
+//	@Success		201	{object}	handler.PlansInsertResponse
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/plans [post]
+//
+//	@Security		apiTokenAuth
+func (h *CRUD) InsertPlans(w http.ResponseWriter, r *http.Request) {
+	//parameters for request are:
+	//jwt = token with dispatcher server secret key, id of user who received the token and issue date of the token, not optional
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, userId := h.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	inputData := PlansData{}
+	err := json.NewDecoder(r.Body).Decode(&inputData)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Errorf("could not parse received body, reason: %w", err).Error()))
+		return
+	}
+	if len(inputData.PlansList) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("at least one plan has to be provided"))
+		return
+	}
+	for i := range inputData.PlansList {
+		err = h.calculatePlan(r, userId, &inputData.PlansList[i])
+		if err != nil {
+			writePlanError(w, err)
+			return
+		}
+	}
+	response := PlansInsertResponse{}
+	result, err := h.PlanRepo.InsertPlans(r.Context(), inputData.PlansList, uint(userId))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not insert requested plans data, reason: %w", err).Error()))
+		return
+	}
+	noRows, err := rowsAffected(result)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("data has been inserted, but could not count inserted plans, reason: %w", err).Error()))
+		return
+	}
+	response.PlansInserted = noRows
+	byteJSONRepresentation, err := json.Marshal(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("data has been inserted, but could not generate json representation of response, reason: %w", err).Error()))
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	w.Write(byteJSONRepresentation)
+}
+
+// UpdatePlans update saved production plans
+//
+//	@Description	Update names, targets, alternative recipes and alternative machines of saved production plans, based on "id" field of every plan sent in request body. Production tree of every plan is calculated again with updated data. If a plan with a particular id does not belong to the user who presented authentication token, then that plan is not updated.
+//	@Param			plans	body	handler.PlansData	true	"Plans to be updated"
+//	@Tags			CRUD Authorization required
+//
+//	@Accept			json
+//
+//	@Success		200	{object}	handler.PlansUpdateResponse
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/plans [put]
+//
+//	@Security		apiTokenAuth
+func (h *CRUD) UpdatePlans(w http.ResponseWriter, r *http.Request) {
+	//parameters for request are:
+	//jwt = token with dispatcher server secret key, id of user who received the token and issue date of the token, not optional
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, userId := h.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	inputData := PlansData{}
+	err := json.NewDecoder(r.Body).Decode(&inputData)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Errorf("could not parse received body, reason: %w", err).Error()))
+		return
+	}
+	for i := range inputData.PlansList {
+		err = h.calculatePlan(r, userId, &inputData.PlansList[i])
+		if err != nil {
+			writePlanError(w, err)
+			return
+		}
+	}
+	response := PlansUpdateResponse{}
+	result, err := h.PlanRepo.UpdatePlans(r.Context(), inputData.PlansList, uint(userId))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not update requested plans data, reason: %w", err).Error()))
+		return
+	}
+	noRows, err := rowsAffected(result...)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("data has been updated, but could not count updated plans, reason: %w", err).Error()))
+		return
+	}
+	response.PlansUpdated = noRows
+	byteJSONRepresentation, err := json.Marshal(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("data has been updated, but could not generate json representation of response, reason: %w", err).Error()))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(byteJSONRepresentation)
+}
+
+// DeletePlans delete saved production plans
+//
+//	@Description	Delete saved production plans with provided ids. If a plan with a particular id does not belong to the user who presented authentication token, then that plan is not deleted.
+//	@Param			plans_id	query	integer	true	"Id of plan to be deleted. Can be present multiple times"
+//	@Tags			CRUD Authorization required
+//	@Success		200	{object}	handler.PlansDeleteResponse
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/plans [delete]
+//
+//	@Security		apiTokenAuth
+func (h *CRUD) DeletePlans(w http.ResponseWriter, r *http.Request) {
+	//parameters for request are:
+	//jwt = token with dispatcher server secret key, id of user who received the token and issue date of the token, not optional
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, userId := h.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	plansIds := h.convertArrToInt(r.URL.Query()["plans_id"])
+	if len(plansIds) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("plans_id should be a positive integer and cannot be empty"))
+		return
+	}
+	response := PlansDeleteResponse{}
+	result, err := h.PlanRepo.DeletePlans(r.Context(), plansIds, userId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not delete requested plans data, reason: %w", err).Error()))
+		return
+	}
+	noRows, err := rowsAffected(result)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("data has been deleted, but could not count deleted plans, reason: %w", err).Error()))
+		return
+	}
+	response.PlansDeleted = noRows
+	byteJSONRepresentation, err := json.Marshal(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("data has been deleted, but could not generate json representation of response, reason: %w", err).Error()))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(byteJSONRepresentation)
+}
+
+// RecomputePlans calculate saved production plans again
+//
+//	@Description	Calculate production trees of saved production plans again, using current recipes, machines and resources of the user that provided authentication token. If plans_id parameter is present, only plans with those ids are recomputed, otherwise all plans of the user are. Changed is true for plans whose production tree differs from the saved one, new production trees of such plans are saved.
+//	@Param			plans_id	query	integer	false	"Id of plan to be recomputed. Can be present multiple times"
+//	@Tags			CRUD Authorization required
+//	@Success		200	{object}	handler.PlansRecomputeResponse
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/plans/recompute [post]
+//
+//	@Security		apiTokenAuth
+func (h *CRUD) RecomputePlans(w http.ResponseWriter, r *http.Request) {
+	//parameters for request are:
+	//jwt = token with dispatcher server secret key, id of user who received the token and issue date of the token, not optional
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, userId := h.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	var plans []model.PlanInfo
+	var err error
+	if plansIds := r.URL.Query()["plans_id"]; plansIds != nil {
+		plans, err = h.PlanRepo.SelectPlansById(r.Context(), h.convertArrToInt(plansIds), userId)
+	} else {
+		plans, err = h.PlanRepo.SelectPlans(r.Context(), 0, 0, userId)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not retrieve data from database, reason: %w", err).Error()))
+		return
+	}
+	response := PlansRecomputeResponse{PlansList: []RecomputedPlan{}}
+	changedPlans := []model.PlanInfo{}
+	for _, plan := range plans {
+		savedTree := plan.ProductionTree
+		err = h.calculatePlan(r, userId, &plan)
+		if err != nil {
+			writePlanError(w, err)
+			return
+		}
+		changed := !sameProductionTrees(savedTree, plan.ProductionTree)
+		if changed {
+			changedPlans = append(changedPlans, plan)
+		}
+		response.PlansList = append(response.PlansList, RecomputedPlan{Id: plan.Id, Name: plan.Name, Changed: changed, ProductionTree: plan.ProductionTree})
+	}
+	if len(changedPlans) > 0 {
+		_, err = h.PlanRepo.UpdatePlans(r.Context(), changedPlans, uint(userId))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Errorf("could not save recomputed plans, reason: %w", err).Error()))
+			return
+		}
+	}
+	byteJSONRepresentation, err := json.Marshal(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("plans have been recomputed, but could not generate json representation of response, reason: %w", err).Error()))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(byteJSONRepresentation)
+}
+
+// errInvalidPlan marks errors caused by content of a plan rather than by calculator microservice
+var errInvalidPlan = errors.New("invalid plan")
+
+// calculatePlan validates plan and replaces its production tree with one calculated by calculator microservice
+func (h *CRUD) calculatePlan(r *http.Request, userId int, plan *model.PlanInfo) error {
+	if plan.Name == "" {
+		return fmt.Errorf("%w: name of a plan cannot be empty", errInvalidPlan)
+	}
+	if len(plan.Targets) == 0 {
+		return fmt.Errorf("%w: plan '%s' should have at least one target", errInvalidPlan, plan.Name)
+	}
+	for _, target := range plan.Targets {
+		if target.Resource == "" || target.Rate <= 0 {
+			return fmt.Errorf("%w: every target of plan '%s' should have a resource and a positive rate", errInvalidPlan, plan.Name)
+		}
+	}
+	productionTree, err := h.CalculatorNotifier.CalculatePlan(r.Context(), userId, *plan)
+	if err != nil {
+		return fmt.Errorf("could not calculate production tree of plan '%s': %w", plan.Name, err)
+	}
+	plan.ProductionTree = productionTree
+	return nil
+}
+
+func writePlanError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errInvalidPlan) {
+		w.WriteHeader(http.StatusBadRequest)
+	} else {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// sameProductionTrees compares json documents of production trees, ignoring whitespace
+func sameProductionTrees(first json.RawMessage, second json.RawMessage) bool {
+	compactFirst := bytes.Buffer{}
+	compactSecond := bytes.Buffer{}
+	if json.Compact(&compactFirst, first) != nil || json.Compact(&compactSecond, second) != nil {
+		return false
+	}
+	return bytes.Equal(compactFirst.Bytes(), compactSecond.Bytes())
+}
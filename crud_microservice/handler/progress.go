@@ -0,0 +1,137 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+)
+
+type ProgressUpdateResponse struct {
+	ProgressUpdated uint
+}
+
+// SelectProgress return current tier of the user
+//
+//	@Description	Return current progression tier of the user that provided authentication token. Recipes and machines assigned to unlock tiers with tier number higher than current tier are not used by calculator microservice. If current tier has not been set, all recipes and machines are unlocked and status 404 is returned.
+//	@Tags			CRUD Authorization required
+//	@Success		200	{object}	model.ProgressInfo
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		404	{string}	string	"Current tier has not been set"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/progress [get]
+//
+//	@Security		apiTokenAuth
+func (h *CRUD) SelectProgress(w http.ResponseWriter, r *http.Request) {
+	//parameters that are not mentioned in swagger directly:
+	//jwt = token with dispatcher server secret key, id of user who received the token and issue date of the token, not optional
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, userId := h.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	progress, exists, err := h.ProgressRepo.SelectProgress(r.Context(), userId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not retrieve data from database, reason: %w", err).Error()))
+		return
+	}
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("current tier has not been set, all recipes and machines are unlocked"))
+		return
+	}
+	byteJSONRepresentation, err := json.Marshal(progress)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not generate json representation of data, reason: %w", err).Error()))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(byteJSONRepresentation)
+}
+
+// UpdateProgress set current tier of the user
+//
+//	@Description	Set current progression tier of the user that provided authentication token. Recipes and machines are unlocked when tier number of their unlock tier is not higher than current tier, recipes and machines without unlock tier are always unlocked. Users id sent in request body is ignored.
+//	@Param			progress	body	model.ProgressInfo	true	"Current tier of the user"
+//	@Tags			CRUD Authorization required
+//
+//	@Accept			json
+//
+//	@Success		200	{object}	handler.ProgressUpdateResponse
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/progress [put]
+//
+//	@Security		apiTokenAuth
+func (h *CRUD) UpdateProgress(w http.ResponseWriter, r *http.Request) {
+	//parameters for request are:
+	//jwt = token with dispatcher server secret key, id of user who received the token and issue date of the token, not optional
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, userId := h.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	inputData := model.ProgressInfo{}
+	err := json.NewDecoder(r.Body).Decode(&inputData)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Errorf("could not parse received body, reason: %w", err).Error()))
+		return
+	}
+	response := ProgressUpdateResponse{}
+	result, err := h.ProgressRepo.UpdateProgress(r.Context(), inputData, uint(userId))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("could not update requested progress data, reason: %w", err).Error()))
+		return
+	}
+	h.CalculatorNotifier.InvalidateRecipeData(r.Context(), userId)
+	noRows, err := result.RowsAffected()
+	if err != nil {
+		w.Write([]byte("database driver does not support returning numbers of rows affected"))
+	}
+	response.ProgressUpdated = uint(noRows)
+	byteJSONRepresentation, err := json.Marshal(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Errorf("data has been updated, but could not generate json representation of response, reason: %w", err).Error()))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(byteJSONRepresentation)
+}
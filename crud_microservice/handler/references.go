@@ -0,0 +1,212 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+)
+
+var errUnresolvedReference = errors.New("reference does not match any record")
+
+// references maps temporary ids and names of records of one kind to their ids. Temporary ids take precedence over
+// names of records inserted in the same request, which take precedence over names of records already in database.
+type references struct {
+	kind    string
+	tempIds map[string]uint
+	names   map[string]uint
+	stored  map[string]uint
+}
+
+func newReferences(kind string) *references {
+	return &references{kind: kind, tempIds: make(map[string]uint), names: make(map[string]uint), stored: make(map[string]uint)}
+}
+
+func (r *references) add(tempId string, name string, id uint) {
+	if tempId != "" {
+		r.tempIds[tempId] = id
+	}
+	r.names[name] = id
+}
+
+// resolve returns id referenced by reference, or id itself if reference is empty.
+func (r *references) resolve(reference string, id uint) (uint, error) {
+	if reference == "" {
+		return id, nil
+	}
+	for _, ids := range []map[string]uint{r.tempIds, r.names, r.stored} {
+		if resolvedId, exists := ids[reference]; exists {
+			return resolvedId, nil
+		}
+	}
+	return 0, fmt.Errorf("%s '%s': %w", r.kind, reference, errUnresolvedReference)
+}
+
+// insertData inserts all records of data for the user in one transaction. Machines, resources and recipes are
+// inserted first, then references of the other records are resolved to ids of inserted or already existing records.
+// Nothing is inserted if any reference cannot be resolved, error wrapping errUnresolvedReference is returned.
+// If dryRun is set, transaction is rolled back after all records have been inserted.
+func (h *CRUD) insertData(ctx context.Context, data JSONData, userId uint, dryRun bool) (InsertResponse, error) {
+	response := InsertResponse{}
+	transaction, err := h.MachineRepo.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return response, fmt.Errorf("could not start a transaction, reason: %w", err)
+	}
+	machineReferences := newReferences("machine")
+	existingMachines, err := h.MachineRepo.SelectMachinesWithTransaction(ctx, transaction, int(userId))
+	if err != nil {
+		return response, rollback(transaction, fmt.Errorf("could not retrieve machines, reason: %w", err))
+	}
+	for _, machine := range existingMachines {
+		machineReferences.stored[machine.Name] = machine.Id
+	}
+	resourceReferences := newReferences("resource")
+	existingResources, err := h.ResourceRepo.SelectResourcesWithTransaction(ctx, transaction, int(userId))
+	if err != nil {
+		return response, rollback(transaction, fmt.Errorf("could not retrieve resources, reason: %w", err))
+	}
+	for _, resource := range existingResources {
+		resourceReferences.stored[resource.Name] = resource.Id
+	}
+	recipeReferences := newReferences("recipe")
+	existingRecipes, err := h.RecipeRepo.SelectRecipesWithTransaction(ctx, transaction, int(userId))
+	if err != nil {
+		return response, rollback(transaction, fmt.Errorf("could not retrieve recipes, reason: %w", err))
+	}
+	for _, recipe := range existingRecipes {
+		recipeReferences.stored[recipe.Name] = recipe.Id
+	}
+
+	for _, machine := range data.MachinesList {
+		id, err := h.MachineRepo.InsertMachineWithTransaction(ctx, transaction, machine, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert requested machines data, reason: %w", err))
+		}
+		machineReferences.add(machine.TempId, machine.Name, uint(id))
+		response.MachinesInserted++
+	}
+	for _, resource := range data.ResourcesList {
+		id, err := h.ResourceRepo.InsertResourceWithTransaction(ctx, transaction, resource, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert requested resources data, reason: %w", err))
+		}
+		resourceReferences.add(resource.TempId, resource.Name, uint(id))
+		response.ResourcesInserted++
+	}
+	for _, recipe := range data.RecipesList {
+		id, err := h.RecipeRepo.InsertRecipeWithTransaction(ctx, transaction, recipe, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert requested recipes data, reason: %w", err))
+		}
+		recipeReferences.add(recipe.TempId, recipe.Name, uint(id))
+		response.RecipesInserted++
+	}
+
+	resolveInputsOutputs := func(entries []model.RecipeInputOutputInfo) ([]model.RecipeInputOutputInfo, error) {
+		resolved := make([]model.RecipeInputOutputInfo, len(entries))
+		for i, entry := range entries {
+			var err error
+			if entry.RecipesId, err = recipeReferences.resolve(entry.RecipesRef, entry.RecipesId); err != nil {
+				return nil, err
+			}
+			if entry.ResourcesId, err = resourceReferences.resolve(entry.ResourcesRef, entry.ResourcesId); err != nil {
+				return nil, err
+			}
+			resolved[i] = entry
+		}
+		return resolved, nil
+	}
+	inputs, err := resolveInputsOutputs(data.RecipesInputsList)
+	if err != nil {
+		return response, rollback(transaction, err)
+	}
+	outputs, err := resolveInputsOutputs(data.RecipesOutputsList)
+	if err != nil {
+		return response, rollback(transaction, err)
+	}
+	machinesRecipes := make([]model.MachinesRecipesInfo, len(data.MachinesRecipesList))
+	for i, entry := range data.MachinesRecipesList {
+		if entry.RecipesId, err = recipeReferences.resolve(entry.RecipesRef, entry.RecipesId); err != nil {
+			return response, rollback(transaction, err)
+		}
+		if entry.MachinesId, err = machineReferences.resolve(entry.MachinesRef, entry.MachinesId); err != nil {
+			return response, rollback(transaction, err)
+		}
+		machinesRecipes[i] = entry
+	}
+	machinesCosts := make([]model.MachineCostInfo, len(data.MachinesCostsList))
+	for i, entry := range data.MachinesCostsList {
+		if entry.MachinesId, err = machineReferences.resolve(entry.MachinesRef, entry.MachinesId); err != nil {
+			return response, rollback(transaction, err)
+		}
+		if entry.ResourcesId, err = resourceReferences.resolve(entry.ResourcesRef, entry.ResourcesId); err != nil {
+			return response, rollback(transaction, err)
+		}
+		machinesCosts[i] = entry
+	}
+
+	if len(inputs) > 0 {
+		_, err = h.RecipeinputRepo.InsertRecipesInputsWithTransaction(ctx, transaction, inputs, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert requested recipes_inputs data, reason: %w", err))
+		}
+		response.RecipesInputsInserted = uint(len(inputs))
+	}
+	if len(outputs) > 0 {
+		_, err = h.RecipeoutputRepo.InsertRecipesOutputsWithTransaction(ctx, transaction, outputs, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert requested recipes_outputs data, reason: %w", err))
+		}
+		response.RecipesOutputsInserted = uint(len(outputs))
+	}
+	if len(machinesRecipes) > 0 {
+		_, err = h.MachineRecipeRepo.InsertMachinesRecipesWithTransaction(ctx, transaction, machinesRecipes, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert requested machines_recipes data, reason: %w", err))
+		}
+		response.MachinesRecipesInserted = uint(len(machinesRecipes))
+	}
+	if len(data.TransportTiersList) > 0 {
+		_, err = h.TransportTierRepo.InsertTransportTiersWithTransaction(ctx, transaction, data.TransportTiersList, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert requested transport_tiers data, reason: %w", err))
+		}
+		response.TransportTiersInserted = uint(len(data.TransportTiersList))
+	}
+	if len(data.UnlockTiersList) > 0 {
+		_, err = h.UnlockTierRepo.InsertUnlockTiersWithTransaction(ctx, transaction, data.UnlockTiersList, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert requested unlock_tiers data, reason: %w", err))
+		}
+		response.UnlockTiersInserted = uint(len(data.UnlockTiersList))
+	}
+	if len(machinesCosts) > 0 {
+		_, err = h.MachineCostRepo.InsertMachinesCostsWithTransaction(ctx, transaction, machinesCosts, userId)
+		if err != nil {
+			return response, rollback(transaction, fmt.Errorf("could not insert requested machines_costs data, reason: %w", err))
+		}
+		response.MachinesCostsInserted = uint(len(machinesCosts))
+	}
+	err = finishTransaction(transaction, dryRun)
+	if err != nil {
+		return response, err
+	}
+	return response, nil
+}
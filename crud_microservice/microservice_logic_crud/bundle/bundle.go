@@ -0,0 +1,297 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+)
+
+// Version is the version of bundles produced by export, bundles of other versions cannot be imported
+const Version = 1
+
+// Bundle is a self-contained copy of data of a user. Records reference each other by names instead of ids,
+// so that bundle can be imported into any account.
+type Bundle struct {
+	Version        uint
+	UnlockTiers    []UnlockTier
+	TransportTiers []TransportTier
+	Resources      []Resource
+	Machines       []Machine
+	Recipes        []Recipe
+	Plans          []Plan
+	// CurrentTier is current progression tier of the user, null if it has not been set
+	CurrentTier *uint
+}
+
+type UnlockTier struct {
+	Name       string
+	TierNumber uint
+}
+
+type TransportTier struct {
+	Name         string
+	Liquid       uint8
+	CapacityPerS float32
+}
+
+type Resource struct {
+	Name         string
+	Liquid       uint8
+	ResourceUnit string
+	SinkValue    float32
+}
+
+type Machine struct {
+	Name               string
+	InputsSolid        uint
+	InputsLiquid       uint
+	OutputsSolid       uint
+	OutputsLiquid      uint
+	Speed              float32
+	PowerConsumptionKw uint
+	PowerClockExponent float32
+	PowerGenerationKw  uint
+	DefaultChoice      uint8
+	// UnlockTier is name of unlock tier of machine, empty if machine is always unlocked
+	UnlockTier string
+	BuildCost  []Amount
+}
+
+type Recipe struct {
+	Name            string
+	ProductionTimeS uint
+	DefaultChoice   uint8
+	// UnlockTier is name of unlock tier of recipe, empty if recipe is always unlocked
+	UnlockTier string
+	Inputs     []Amount
+	Outputs    []Amount
+	// Machines are names of machines recipe can be produced in
+	Machines []string
+}
+
+type Amount struct {
+	Resource string
+	Amount   uint
+}
+
+type Plan struct {
+	Name           string
+	Targets        []model.PlanTarget
+	AltRecipes     []string
+	AltMachines    []string
+	ProductionTree json.RawMessage `swaggertype:"object"`
+}
+
+// Dataset holds all records of a user as stored in database.
+type Dataset struct {
+	UnlockTiers     []model.UnlockTierInfo
+	TransportTiers  []model.TransportTierInfo
+	Resources       []model.ResourceInfo
+	Machines        []model.MachineInfo
+	Recipes         []model.RecipeInfo
+	RecipesInputs   []model.RecipeInputOutputInfo
+	RecipesOutputs  []model.RecipeInputOutputInfo
+	MachinesRecipes []model.MachinesRecipesInfo
+	MachinesCosts   []model.MachineCostInfo
+	Plans           []model.PlanInfo
+	// Progress is nil if the user has not set current tier
+	Progress *model.ProgressInfo
+}
+
+// FromDataset builds bundle from records of a user, replacing ids with names. References to records missing
+// from dataset are left out.
+func FromDataset(dataset Dataset) Bundle {
+	bundle := Bundle{
+		Version:        Version,
+		UnlockTiers:    []UnlockTier{},
+		TransportTiers: []TransportTier{},
+		Resources:      []Resource{},
+		Machines:       []Machine{},
+		Recipes:        []Recipe{},
+		Plans:          []Plan{},
+	}
+	unlockTierNames := make(map[uint]string)
+	for _, unlockTier := range dataset.UnlockTiers {
+		unlockTierNames[unlockTier.Id] = unlockTier.Name
+		bundle.UnlockTiers = append(bundle.UnlockTiers, UnlockTier{Name: unlockTier.Name, TierNumber: unlockTier.TierNumber})
+	}
+	for _, transportTier := range dataset.TransportTiers {
+		bundle.TransportTiers = append(bundle.TransportTiers, TransportTier{Name: transportTier.Name, Liquid: transportTier.Liquid, CapacityPerS: transportTier.CapacityPerS})
+	}
+	resourceNames := make(map[uint]string)
+	for _, resource := range dataset.Resources {
+		resourceNames[resource.Id] = resource.Name
+		bundle.Resources = append(bundle.Resources, Resource{Name: resource.Name, Liquid: resource.Liquid, ResourceUnit: resource.ResourceUnit, SinkValue: resource.SinkValue})
+	}
+	machineNames := make(map[uint]string)
+	machineIndexes := make(map[uint]int)
+	for _, machine := range dataset.Machines {
+		machineNames[machine.Id] = machine.Name
+		machineIndexes[machine.Id] = len(bundle.Machines)
+		bundle.Machines = append(bundle.Machines, Machine{
+			Name:               machine.Name,
+			InputsSolid:        machine.InputsSolid,
+			InputsLiquid:       machine.InputsLiquid,
+			OutputsSolid:       machine.OutputsSolid,
+			OutputsLiquid:      machine.OutputsLiquid,
+			Speed:              machine.Speed,
+			PowerConsumptionKw: machine.PowerConsumptionKw,
+			PowerClockExponent: machine.PowerClockExponent,
+			PowerGenerationKw:  machine.PowerGenerationKw,
+			DefaultChoice:      machine.DefaultChoice,
+			UnlockTier:         unlockTierNames[machine.UnlockTiersId],
+			BuildCost:          []Amount{},
+		})
+	}
+	for _, cost := range dataset.MachinesCosts {
+		index, machineExists := machineIndexes[cost.MachinesId]
+		resourceName, resourceExists := resourceNames[cost.ResourcesId]
+		if machineExists && resourceExists {
+			bundle.Machines[index].BuildCost = append(bundle.Machines[index].BuildCost, Amount{Resource: resourceName, Amount: cost.Amount})
+		}
+	}
+	recipeIndexes := make(map[uint]int)
+	for _, recipe := range dataset.Recipes {
+		recipeIndexes[recipe.Id] = len(bundle.Recipes)
+		bundle.Recipes = append(bundle.Recipes, Recipe{
+			Name:            recipe.Name,
+			ProductionTimeS: recipe.ProductionTimeS,
+			DefaultChoice:   recipe.DefaultChoice,
+			UnlockTier:      unlockTierNames[recipe.UnlockTiersId],
+			Inputs:          []Amount{},
+			Outputs:         []Amount{},
+			Machines:        []string{},
+		})
+	}
+	for _, input := range dataset.RecipesInputs {
+		index, recipeExists := recipeIndexes[input.RecipesId]
+		resourceName, resourceExists := resourceNames[input.ResourcesId]
+		if recipeExists && resourceExists {
+			bundle.Recipes[index].Inputs = append(bundle.Recipes[index].Inputs, Amount{Resource: resourceName, Amount: input.Amount})
+		}
+	}
+	for _, output := range dataset.RecipesOutputs {
+		index, recipeExists := recipeIndexes[output.RecipesId]
+		resourceName, resourceExists := resourceNames[output.ResourcesId]
+		if recipeExists && resourceExists {
+			bundle.Recipes[index].Outputs = append(bundle.Recipes[index].Outputs, Amount{Resource: resourceName, Amount: output.Amount})
+		}
+	}
+	for _, machineRecipe := range dataset.MachinesRecipes {
+		index, recipeExists := recipeIndexes[machineRecipe.RecipesId]
+		machineName, machineExists := machineNames[machineRecipe.MachinesId]
+		if recipeExists && machineExists {
+			bundle.Recipes[index].Machines = append(bundle.Recipes[index].Machines, machineName)
+		}
+	}
+	for _, plan := range dataset.Plans {
+		bundle.Plans = append(bundle.Plans, Plan{Name: plan.Name, Targets: plan.Targets, AltRecipes: plan.AltRecipes, AltMachines: plan.AltMachines, ProductionTree: plan.ProductionTree})
+	}
+	if dataset.Progress != nil {
+		currentTier := dataset.Progress.CurrentTier
+		bundle.CurrentTier = &currentTier
+	}
+	return bundle
+}
+
+// Validate checks that bundle has supported version, names are unique among records of the same kind and every
+// name referenced by records belongs to a record of the bundle.
+func (b Bundle) Validate() error {
+	if b.Version != Version {
+		return fmt.Errorf("bundle version %d is not supported, supported version is %d", b.Version, Version)
+	}
+	unlockTiers := make(map[string]bool)
+	for _, unlockTier := range b.UnlockTiers {
+		if err := addName(unlockTiers, "unlock tier", unlockTier.Name); err != nil {
+			return err
+		}
+	}
+	transportTiers := make(map[string]bool)
+	for _, transportTier := range b.TransportTiers {
+		if err := addName(transportTiers, "transport tier", transportTier.Name); err != nil {
+			return err
+		}
+	}
+	resources := make(map[string]bool)
+	for _, resource := range b.Resources {
+		if err := addName(resources, "resource", resource.Name); err != nil {
+			return err
+		}
+	}
+	machines := make(map[string]bool)
+	for _, machine := range b.Machines {
+		if err := addName(machines, "machine", machine.Name); err != nil {
+			return err
+		}
+		if machine.UnlockTier != "" && !unlockTiers[machine.UnlockTier] {
+			return fmt.Errorf("machine '%s' references unknown unlock tier '%s'", machine.Name, machine.UnlockTier)
+		}
+		if err := checkAmounts(resources, "build cost of machine", machine.Name, machine.BuildCost); err != nil {
+			return err
+		}
+	}
+	recipes := make(map[string]bool)
+	for _, recipe := range b.Recipes {
+		if err := addName(recipes, "recipe", recipe.Name); err != nil {
+			return err
+		}
+		if recipe.UnlockTier != "" && !unlockTiers[recipe.UnlockTier] {
+			return fmt.Errorf("recipe '%s' references unknown unlock tier '%s'", recipe.Name, recipe.UnlockTier)
+		}
+		if err := checkAmounts(resources, "inputs of recipe", recipe.Name, recipe.Inputs); err != nil {
+			return err
+		}
+		if err := checkAmounts(resources, "outputs of recipe", recipe.Name, recipe.Outputs); err != nil {
+			return err
+		}
+		for _, machineName := range recipe.Machines {
+			if !machines[machineName] {
+				return fmt.Errorf("recipe '%s' references unknown machine '%s'", recipe.Name, machineName)
+			}
+		}
+	}
+	plans := make(map[string]bool)
+	for _, plan := range b.Plans {
+		if err := addName(plans, "plan", plan.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addName(names map[string]bool, kind string, name string) error {
+	if name == "" {
+		return fmt.Errorf("%s name cannot be empty", kind)
+	}
+	if names[name] {
+		return fmt.Errorf("%s name '%s' is not unique", kind, name)
+	}
+	names[name] = true
+	return nil
+}
+
+func checkAmounts(resources map[string]bool, description string, name string, amounts []Amount) error {
+	for _, amount := range amounts {
+		if !resources[amount.Resource] {
+			return fmt.Errorf("%s '%s' references unknown resource '%s'", description, name, amount.Resource)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,450 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+)
+
+// Difficulties of Factorio recipes
+const (
+	FactorioNormal    = "normal"
+	FactorioExpensive = "expensive"
+)
+
+var energyPattern = regexp.MustCompile(`^([\d.]+)\s*([kMGT]?)W$`)
+
+var (
+	craftingMachineTypes = []string{"assembling-machine", "furnace", "rocket-silo"}
+	itemTypes            = []string{"item", "ammo", "armor", "capsule", "gun", "item-with-entity-data", "module", "rail-planner", "repair-tool", "tool", "spidertron-remote", "item-with-label", "item-with-inventory", "item-with-tags", "selection-tool"}
+	unsupportedTypes     = []string{"boiler", "generator", "burner-generator", "reactor", "fusion-reactor", "fusion-generator"}
+)
+
+// factorioPrototype is a single prototype of data.raw.
+type factorioPrototype map[string]json.RawMessage
+
+func (p factorioPrototype) text(field string) string {
+	var value string
+	if err := json.Unmarshal(p[field], &value); err != nil {
+		return ""
+	}
+	return value
+}
+
+func (p factorioPrototype) number(field string) float64 {
+	var value float64
+	if err := json.Unmarshal(p[field], &value); err != nil {
+		return 0
+	}
+	return value
+}
+
+func (p factorioPrototype) object(field string) factorioPrototype {
+	value := factorioPrototype{}
+	if err := json.Unmarshal(p[field], &value); err != nil {
+		return factorioPrototype{}
+	}
+	return value
+}
+
+// texts returns list of strings of field, e.g. crafting categories.
+func (p factorioPrototype) texts(field string) []string {
+	values := []string{}
+	for _, element := range jsonList(p[field]) {
+		var value string
+		if err := json.Unmarshal(element, &value); err == nil {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+type factorioBuilder struct {
+	data      *GameData
+	liquid    map[string]bool
+	items     map[string]bool
+	resources map[string]string
+	// machines are names of machines by crafting or resource category
+	machines      map[string][]string
+	resourceNames *names
+	machineNames  *names
+	recipeNames   *names
+}
+
+// ParseFactorioDataRaw maps recipes, items, fluids and machines of Factorio data.raw, as dumped by the game with
+// --dump-data option, into game data. Assembling machines, furnaces and rocket silos become machines running recipes
+// of their crafting categories, mining drills and offshore pumps become machines with a recipe for every resource
+// they can extract. Recipes use ingredients and results of given difficulty, falling back to the other difficulty
+// if the recipe does not define one. Expected amounts are used for results with probabilities or amount ranges.
+// Liquid slots of machines are given by their fluid boxes, solid slots by recipes run on them. Only items and
+// fluids used by imported recipes become resources. Recipes filling and emptying barrels are not default choices.
+// Power of machines burning fuel is not imported.
+func ParseFactorioDataRaw(dataRaw []byte, difficulty string) (*GameData, error) {
+	text, err := decodeText(dataRaw)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode data.raw: %w", err)
+	}
+	prototypes := map[string]map[string]factorioPrototype{}
+	err = json.Unmarshal(text, &prototypes)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse data.raw: %w", err)
+	}
+	if len(prototypes["recipe"]) == 0 {
+		return nil, fmt.Errorf("data.raw does not contain any recipes")
+	}
+	builder := factorioBuilder{
+		data:          &GameData{Resources: []model.ResourceInfo{}, Machines: []model.MachineInfo{}, Recipes: []GameRecipe{}, Skipped: []SkippedEntry{}},
+		liquid:        make(map[string]bool),
+		items:         make(map[string]bool),
+		resources:     make(map[string]string),
+		machines:      make(map[string][]string),
+		resourceNames: newNames(),
+		machineNames:  newNames(),
+		recipeNames:   newNames(),
+	}
+	for name := range prototypes["fluid"] {
+		builder.liquid[name] = true
+	}
+	for _, prototypeType := range itemTypes {
+		for name := range prototypes[prototypeType] {
+			builder.items[name] = true
+		}
+	}
+	liquidSlots := make(map[string][2]uint)
+	for _, prototypeType := range slices.Sorted(maps.Keys(prototypes)) {
+		for _, name := range slices.Sorted(maps.Keys(prototypes[prototypeType])) {
+			prototype := prototypes[prototypeType][name]
+			switch {
+			case slices.Contains(craftingMachineTypes, prototypeType):
+				machineName := builder.addMachine(prototype, name, prototype.number("crafting_speed"), prototype.texts("crafting_categories"))
+				inputs, outputs := fluidBoxes(prototype)
+				liquidSlots[machineName] = [2]uint{inputs, outputs}
+			case prototypeType == "mining-drill":
+				machineName := builder.addMachine(prototype, name, prototype.number("mining_speed"), prototype.texts("resource_categories"))
+				inputs, outputs := fluidBoxes(prototype)
+				liquidSlots[machineName] = [2]uint{inputs, outputs}
+			case prototypeType == "offshore-pump":
+				builder.addOffshorePump(prototype, name)
+			case slices.Contains(unsupportedTypes, prototypeType):
+				builder.skip(SkippedMachine, name, fmt.Sprintf("machine type %s is not supported", prototypeType))
+			}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(prototypes["recipe"])) {
+		builder.addCraftingRecipe(prototypes["recipe"][name], name, difficulty)
+	}
+	for _, name := range slices.Sorted(maps.Keys(prototypes["resource"])) {
+		builder.addMiningRecipe(prototypes["resource"][name], name)
+	}
+	builder.data.setMachineSlots()
+	for i := range builder.data.Machines {
+		if slots, exists := liquidSlots[builder.data.Machines[i].Name]; exists {
+			builder.data.Machines[i].InputsLiquid = slots[0]
+			builder.data.Machines[i].OutputsLiquid = slots[1]
+		}
+	}
+	return builder.data, nil
+}
+
+func (b *factorioBuilder) skip(kind string, name string, reason string) {
+	b.data.Skipped = append(b.data.Skipped, SkippedEntry{Kind: kind, Name: name, Reason: reason})
+}
+
+// addMachine adds machine running recipes of categories and returns its name.
+func (b *factorioBuilder) addMachine(prototype factorioPrototype, prototypeName string, speed float64, categories []string) string {
+	if speed <= 0 {
+		speed = 1
+	}
+	powerConsumptionKw := 0.0
+	if prototype.object("energy_source").text("type") == "electric" {
+		powerConsumptionKw = energyKw(prototype.text("energy_usage"))
+	}
+	name := b.machineNames.assign(prototypeName, prototypeName)
+	for _, category := range categories {
+		b.machines[category] = append(b.machines[category], name)
+	}
+	b.data.Machines = append(b.data.Machines, model.MachineInfo{
+		Name:               name,
+		Speed:              float32(speed),
+		PowerConsumptionKw: uint(math.Round(powerConsumptionKw)),
+		PowerClockExponent: 1,
+		DefaultChoice:      1,
+	})
+	return name
+}
+
+// addOffshorePump adds offshore pump with recipe pumping its fluid.
+func (b *factorioBuilder) addOffshorePump(prototype factorioPrototype, prototypeName string) {
+	fluid := prototype.text("fluid")
+	if fluid == "" {
+		fluid = prototype.object("fluid_box").text("filter")
+	}
+	if !b.liquid[fluid] {
+		b.skip(SkippedMachine, prototypeName, "fluid pumped by offshore pump is not known")
+		return
+	}
+	machineName := b.addMachine(prototype, prototypeName, 1, []string{})
+	outputs := []rawAmount{{Resource: fluid, Amount: prototype.number("pumping_speed") * 60}}
+	b.addRecipe(prototypeName+" "+fluid, fmt.Sprintf("%s extraction %s", fluid, prototypeName), 1, []rawAmount{}, outputs, []string{machineName}, 1)
+}
+
+// addRecipe adds recipe with inputs and outputs given by names of items and fluids, unless it uses unknown items
+// or fluids or its duration and amounts cannot be scaled to whole numbers.
+func (b *factorioBuilder) addRecipe(prototypeName string, displayName string, durationS float64, inputs []rawAmount, outputs []rawAmount, machines []string, defaultChoice uint8) {
+	for _, amount := range slices.Concat(inputs, outputs) {
+		if !b.items[amount.Resource] && !b.liquid[amount.Resource] {
+			b.skip(SkippedRecipe, prototypeName, fmt.Sprintf("'%s' is neither an item nor a fluid", amount.Resource))
+			return
+		}
+	}
+	productionTimeS, scaledInputs, scaledOutputs, ok := integerRecipe(durationS, inputs, outputs)
+	if !ok {
+		b.skip(SkippedRecipe, prototypeName, "production time and amounts cannot be scaled to whole numbers")
+		return
+	}
+	for i := range scaledInputs {
+		scaledInputs[i].Resource = b.resource(scaledInputs[i].Resource)
+	}
+	for i := range scaledOutputs {
+		scaledOutputs[i].Resource = b.resource(scaledOutputs[i].Resource)
+	}
+	b.data.Recipes = append(b.data.Recipes, GameRecipe{
+		Recipe:   model.RecipeInfo{Name: b.recipeNames.assign(displayName, prototypeName), ProductionTimeS: productionTimeS, DefaultChoice: defaultChoice},
+		Inputs:   scaledInputs,
+		Outputs:  scaledOutputs,
+		Machines: machines,
+	})
+}
+
+// resource returns name of resource for item or fluid, adding the resource on first use.
+func (b *factorioBuilder) resource(prototypeName string) string {
+	if name, exists := b.resources[prototypeName]; exists {
+		return name
+	}
+	resource := model.ResourceInfo{Name: b.resourceNames.assign(prototypeName, prototypeName)}
+	if b.liquid[prototypeName] {
+		resource.Liquid = 1
+	}
+	b.resources[prototypeName] = resource.Name
+	b.data.Resources = append(b.data.Resources, resource)
+	return resource.Name
+}
+
+func (b *factorioBuilder) addCraftingRecipe(recipe factorioPrototype, prototypeName string, difficulty string) {
+	category := recipe.text("category")
+	if category == "" {
+		category = "crafting"
+	}
+	machines := b.machines[category]
+	if len(machines) == 0 {
+		b.skip(SkippedRecipe, prototypeName, fmt.Sprintf("recipe category '%s' is not crafted in any imported machine", category))
+		return
+	}
+	variant := recipeVariant(recipe, difficulty)
+	outputs := resultAmounts(variant)
+	if len(outputs) == 0 {
+		b.skip(SkippedRecipe, prototypeName, "recipe has no results")
+		return
+	}
+	durationS := 0.5
+	if _, exists := variant["energy_required"]; exists {
+		durationS = variant.number("energy_required")
+	}
+	defaultChoice := uint8(1)
+	if subgroup := recipe.text("subgroup"); subgroup == "fill-barrel" || subgroup == "empty-barrel" {
+		defaultChoice = 0
+	}
+	b.addRecipe(prototypeName, prototypeName, durationS, ingredientAmounts(variant["ingredients"]), outputs, machines, defaultChoice)
+}
+
+// addMiningRecipe adds recipe extracting resource entity, run on mining drills of its resource category. Fluid
+// required for mining is consumed per 10 mining cycles.
+func (b *factorioBuilder) addMiningRecipe(resource factorioPrototype, prototypeName string) {
+	recipeName := prototypeName + " mining"
+	category := resource.text("category")
+	if category == "" {
+		category = "basic-solid"
+	}
+	machines := b.machines[category]
+	if len(machines) == 0 {
+		b.skip(SkippedRecipe, recipeName, fmt.Sprintf("resource category '%s' is not mined by any imported machine", category))
+		return
+	}
+	minable := resource.object("minable")
+	outputs := resultAmounts(minable)
+	if len(outputs) == 0 {
+		b.skip(SkippedRecipe, recipeName, "resource is not minable")
+		return
+	}
+	inputs := []rawAmount{}
+	if fluid := minable.text("required_fluid"); fluid != "" {
+		inputs = append(inputs, rawAmount{Resource: fluid, Amount: minable.number("fluid_amount") / 10})
+	}
+	durationS := minable.number("mining_time")
+	if durationS <= 0 {
+		durationS = 1
+	}
+	b.addRecipe(recipeName, recipeName, durationS, inputs, outputs, machines, 1)
+}
+
+// recipeVariant returns ingredients, results and crafting time of recipe for difficulty. Recipes without difficulties
+// define them directly.
+func recipeVariant(recipe factorioPrototype, difficulty string) factorioPrototype {
+	other := FactorioExpensive
+	if difficulty == FactorioExpensive {
+		other = FactorioNormal
+	}
+	for _, field := range []string{difficulty, other} {
+		if variant := recipe.object(field); len(variant) > 0 {
+			return variant
+		}
+	}
+	return recipe
+}
+
+// resultAmounts parses results of recipe or minable resource, given either as list of results or as single result
+// with its count.
+func resultAmounts(prototype factorioPrototype) []rawAmount {
+	if result := prototype.text("result"); result != "" {
+		count := 1.0
+		for _, field := range []string{"result_count", "count"} {
+			if _, exists := prototype[field]; exists {
+				count = prototype.number(field)
+			}
+		}
+		return []rawAmount{{Resource: result, Amount: count}}
+	}
+	return ingredientAmounts(prototype["results"])
+}
+
+// ingredientAmounts parses list of ingredients or results, each given either in short form, e.g. ["iron-plate", 2],
+// or in full form, e.g. {"type": "fluid", "name": "water", "amount": 100}. Expected amount is used for results with
+// probability or amount range.
+func ingredientAmounts(list json.RawMessage) []rawAmount {
+	amounts := []rawAmount{}
+	for _, element := range jsonList(list) {
+		short := []json.RawMessage{}
+		if err := json.Unmarshal(element, &short); err == nil {
+			if len(short) != 2 {
+				continue
+			}
+			var name string
+			var amount float64
+			if json.Unmarshal(short[0], &name) != nil || json.Unmarshal(short[1], &amount) != nil {
+				continue
+			}
+			amounts = append(amounts, rawAmount{Resource: name, Amount: amount})
+			continue
+		}
+		full := factorioPrototype{}
+		if err := json.Unmarshal(element, &full); err != nil || full.text("name") == "" {
+			continue
+		}
+		amount := full.number("amount")
+		if _, exists := full["amount"]; !exists {
+			amount = (full.number("amount_min") + full.number("amount_max")) / 2
+		}
+		if _, exists := full["probability"]; exists {
+			amount *= full.number("probability")
+		}
+		if amount > 0 {
+			amounts = append(amounts, rawAmount{Resource: full.text("name"), Amount: amount})
+		}
+	}
+	return amounts
+}
+
+// fluidBoxes returns numbers of input and output fluid boxes of machine.
+func fluidBoxes(prototype factorioPrototype) (uint, uint) {
+	inputs, outputs := uint(0), uint(0)
+	for _, element := range jsonList(prototype["fluid_boxes"]) {
+		fluidBox := factorioPrototype{}
+		if err := json.Unmarshal(element, &fluidBox); err != nil {
+			continue
+		}
+		switch fluidBox.text("production_type") {
+		case "input", "input-output":
+			inputs++
+		case "output":
+			outputs++
+		}
+	}
+	if fluidBox := prototype.object("input_fluid_box"); len(fluidBox) > 0 {
+		inputs++
+	}
+	if fluidBox := prototype.object("output_fluid_box"); len(fluidBox) > 0 {
+		outputs++
+	}
+	return inputs, outputs
+}
+
+// jsonList returns elements of JSON list. Lua tables dumped as JSON objects with numeric keys, e.g. {"1": ..., "2": ...},
+// and empty tables dumped as {} are accepted as well, keys which are not numbers are ignored.
+func jsonList(value json.RawMessage) []json.RawMessage {
+	list := []json.RawMessage{}
+	if err := json.Unmarshal(value, &list); err == nil {
+		return list
+	}
+	table := map[string]json.RawMessage{}
+	if err := json.Unmarshal(value, &table); err != nil {
+		return list
+	}
+	indexes := []int{}
+	for key := range table {
+		if index, err := strconv.Atoi(key); err == nil {
+			indexes = append(indexes, index)
+		}
+	}
+	slices.Sort(indexes)
+	for _, index := range indexes {
+		list = append(list, table[strconv.Itoa(index)])
+	}
+	return list
+}
+
+// energyKw converts energy usage, e.g. "150kW" or "1.8MW", into kilowatts.
+func energyKw(energy string) float64 {
+	match := energyPattern.FindStringSubmatch(strings.TrimSpace(energy))
+	if match == nil {
+		return 0
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0
+	}
+	switch match[2] {
+	case "":
+		return value / 1000
+	case "k":
+		return value
+	case "M":
+		return value * 1000
+	case "G":
+		return value * 1000 * 1000
+	default:
+		return value * 1000 * 1000 * 1000
+	}
+}
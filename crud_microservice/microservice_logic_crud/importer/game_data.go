@@ -0,0 +1,177 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package importer
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+)
+
+// Kinds of skipped entries
+const (
+	SkippedMachine  = "machine"
+	SkippedResource = "resource"
+	SkippedRecipe   = "recipe"
+)
+
+// GameData holds machines, resources and recipes read from data of a game. Recipes reference resources
+// and machines by name, as ids are only known after records are inserted.
+type GameData struct {
+	Resources []model.ResourceInfo
+	Machines  []model.MachineInfo
+	Recipes   []GameRecipe
+	// Skipped lists entries of game data which could not be mapped into records
+	Skipped []SkippedEntry
+}
+
+type GameRecipe struct {
+	Recipe   model.RecipeInfo
+	Inputs   []GameAmount
+	Outputs  []GameAmount
+	Machines []string
+}
+
+type GameAmount struct {
+	Resource string
+	Amount   uint
+}
+
+type SkippedEntry struct {
+	Kind   string
+	Name   string
+	Reason string
+}
+
+// names assigns unique record names to entries of game data.
+type names struct {
+	used map[string]bool
+}
+
+func newNames() *names {
+	return &names{used: make(map[string]bool)}
+}
+
+// assign returns display name converted to lower snake case, e.g. "Alternate: Cast Screw" becomes "alternate_cast_screw".
+// If the name is already used, converted class name is appended to it.
+func (n *names) assign(displayName string, className string) string {
+	name := snakeCase(displayName)
+	if name == "" {
+		name = snakeCase(className)
+	}
+	if n.used[name] {
+		name += "_" + snakeCase(className)
+	}
+	n.used[name] = true
+	return name
+}
+
+func snakeCase(text string) string {
+	var builder strings.Builder
+	separate := false
+	for _, character := range text {
+		if character > unicode.MaxASCII || !(unicode.IsLetter(character) || unicode.IsDigit(character)) {
+			separate = builder.Len() > 0
+			continue
+		}
+		if separate {
+			builder.WriteRune('_')
+			separate = false
+		}
+		builder.WriteRune(unicode.ToLower(character))
+	}
+	return builder.String()
+}
+
+// rawAmount is an amount of resource as given by game data, before it is scaled to whole numbers.
+type rawAmount struct {
+	Resource string
+	Amount   float64
+}
+
+// integerRecipe scales duration of recipe and amounts of its inputs and outputs by the smallest factor, which makes
+// all of them whole numbers, so that production rates are kept. False is returned if there is no such factor up to 1000.
+func integerRecipe(durationS float64, inputs []rawAmount, outputs []rawAmount) (uint, []GameAmount, []GameAmount, bool) {
+	for factor := 1.0; factor <= 1000; factor++ {
+		if durationS*factor < 0.5 || !isWhole(durationS*factor) || !allWhole(inputs, factor) || !allWhole(outputs, factor) {
+			continue
+		}
+		return uint(math.Round(durationS * factor)), scaleAmounts(inputs, factor), scaleAmounts(outputs, factor), true
+	}
+	return 0, nil, nil, false
+}
+
+func allWhole(amounts []rawAmount, factor float64) bool {
+	for _, amount := range amounts {
+		if !isWhole(amount.Amount * factor) {
+			return false
+		}
+	}
+	return true
+}
+
+func isWhole(number float64) bool {
+	return math.Abs(number-math.Round(number)) < 1e-4
+}
+
+func scaleAmounts(amounts []rawAmount, factor float64) []GameAmount {
+	scaled := []GameAmount{}
+	for _, amount := range amounts {
+		scaled = append(scaled, GameAmount{Resource: amount.Resource, Amount: uint(math.Round(amount.Amount * factor))})
+	}
+	return scaled
+}
+
+// setMachineSlots sets input and output slots of machines to the highest numbers of distinct solid and liquid
+// inputs and outputs among recipes run on them, as game data does not describe slots of buildings.
+func (d *GameData) setMachineSlots() {
+	liquid := make(map[string]bool)
+	for _, resource := range d.Resources {
+		liquid[resource.Name] = resource.Liquid != 0
+	}
+	count := func(amounts []GameAmount) (uint, uint) {
+		solid, fluid := uint(0), uint(0)
+		for _, amount := range amounts {
+			if liquid[amount.Resource] {
+				fluid++
+			} else {
+				solid++
+			}
+		}
+		return solid, fluid
+	}
+	machines := make(map[string]*model.MachineInfo)
+	for i := range d.Machines {
+		machines[d.Machines[i].Name] = &d.Machines[i]
+	}
+	for _, recipe := range d.Recipes {
+		inputsSolid, inputsLiquid := count(recipe.Inputs)
+		outputsSolid, outputsLiquid := count(recipe.Outputs)
+		for _, machineName := range recipe.Machines {
+			machine, exists := machines[machineName]
+			if !exists {
+				continue
+			}
+			machine.InputsSolid = max(machine.InputsSolid, inputsSolid)
+			machine.InputsLiquid = max(machine.InputsLiquid, inputsLiquid)
+			machine.OutputsSolid = max(machine.OutputsSolid, outputsSolid)
+			machine.OutputsLiquid = max(machine.OutputsLiquid, outputsLiquid)
+		}
+	}
+}
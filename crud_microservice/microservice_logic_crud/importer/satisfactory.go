@@ -0,0 +1,364 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package importer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+)
+
+// defaultPowerClockExponent is used for buildings, which do not specify how their power consumption grows with clock speed
+const defaultPowerClockExponent = 1.321928
+
+var (
+	nativeClassPattern = regexp.MustCompile(`FactoryGame\.(\w+)'`)
+	itemAmountPattern  = regexp.MustCompile(`ItemClass=[^,]*?\.(\w+)['"]*,\s*Amount=([\d.]+)`)
+)
+
+var (
+	manufacturerClasses = []string{"FGBuildableManufacturer", "FGBuildableManufacturerVariablePower"}
+	extractorClasses    = []string{"FGBuildableResourceExtractor", "FGBuildableWaterPump"}
+	generatorClasses    = []string{"FGBuildableGeneratorFuel", "FGBuildableGeneratorNuclear"}
+	unsupportedClasses  = []string{"FGBuildableFrackingExtractor", "FGBuildableFrackingActivator", "FGBuildableGeneratorGeoThermal"}
+)
+
+// docsClass is a single class of Docs.json. Values of its fields are strings, except for a few nested lists.
+type docsClass map[string]json.RawMessage
+
+type docsNativeClass struct {
+	NativeClass string
+	Classes     []docsClass
+}
+
+func (c docsClass) text(field string) string {
+	var value string
+	if err := json.Unmarshal(c[field], &value); err != nil {
+		return ""
+	}
+	return value
+}
+
+func (c docsClass) number(field string) float64 {
+	value, err := strconv.ParseFloat(strings.TrimSpace(c.text(field)), 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+func (c docsClass) liquid() bool {
+	return c.text("mForm") == "RF_LIQUID" || c.text("mForm") == "RF_GAS"
+}
+
+type satisfactoryBuilder struct {
+	data *GameData
+	// items are descriptors of items and fluids by class name
+	items         map[string]docsClass
+	resources     map[string]string
+	machines      map[string]string
+	resourceNames *names
+	machineNames  *names
+	recipeNames   *names
+}
+
+// ParseSatisfactoryDocs maps buildings, items, fluids and recipes of Satisfactory Docs.json into game data. Docs.json
+// can be encoded in UTF-8 or, as shipped with the game, in UTF-16. Manufacturers, resource extractors and fuel
+// generators become machines. Recipes produced in manufacturers are imported, recipes of build gun and workshops
+// are skipped. Extractors get a recipe for every resource they can extract, at rates of a normal resource node,
+// and generators get a recipe burning every fuel they accept, consuming supplemental resource and producing
+// byproduct of the fuel. Only items and fluids used by imported recipes become resources, fluids are measured
+// in litres. Alternate recipes are not default choices.
+func ParseSatisfactoryDocs(docs []byte) (*GameData, error) {
+	text, err := decodeText(docs)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode Docs.json: %w", err)
+	}
+	nativeClasses := []docsNativeClass{}
+	err = json.Unmarshal(text, &nativeClasses)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse Docs.json: %w", err)
+	}
+	builder := satisfactoryBuilder{
+		data:          &GameData{Resources: []model.ResourceInfo{}, Machines: []model.MachineInfo{}, Recipes: []GameRecipe{}, Skipped: []SkippedEntry{}},
+		items:         make(map[string]docsClass),
+		resources:     make(map[string]string),
+		machines:      make(map[string]string),
+		resourceNames: newNames(),
+		machineNames:  newNames(),
+		recipeNames:   newNames(),
+	}
+	resourceDescriptors := []docsClass{}
+	recipes := []docsClass{}
+	extractors := []docsClass{}
+	generators := []docsClass{}
+	for _, nativeClass := range nativeClasses {
+		kind := ""
+		if match := nativeClassPattern.FindStringSubmatch(nativeClass.NativeClass); match != nil {
+			kind = match[1]
+		}
+		for _, class := range nativeClass.Classes {
+			switch {
+			case kind == "FGRecipe":
+				recipes = append(recipes, class)
+			case slices.Contains(manufacturerClasses, kind):
+				builder.addMachine(class, class.number("mManufacturingSpeed"), 0)
+			case slices.Contains(extractorClasses, kind):
+				builder.addMachine(class, 1, 0)
+				extractors = append(extractors, class)
+			case slices.Contains(generatorClasses, kind):
+				builder.addMachine(class, 1, class.number("mPowerProduction"))
+				generators = append(generators, class)
+			case slices.Contains(unsupportedClasses, kind):
+				builder.skip(SkippedMachine, class.text("ClassName"), fmt.Sprintf("building type %s is not supported", kind))
+			case slices.Contains([]string{"RF_SOLID", "RF_LIQUID", "RF_GAS"}, class.text("mForm")):
+				builder.items[class.text("ClassName")] = class
+				if kind == "FGResourceDescriptor" {
+					resourceDescriptors = append(resourceDescriptors, class)
+				}
+			}
+		}
+	}
+	for _, recipe := range recipes {
+		builder.addManufacturerRecipe(recipe)
+	}
+	for _, extractor := range extractors {
+		builder.addExtractionRecipes(extractor, resourceDescriptors)
+	}
+	for _, generator := range generators {
+		builder.addGeneratorRecipes(generator)
+	}
+	builder.data.setMachineSlots()
+	return builder.data, nil
+}
+
+func (b *satisfactoryBuilder) skip(kind string, name string, reason string) {
+	b.data.Skipped = append(b.data.Skipped, SkippedEntry{Kind: kind, Name: name, Reason: reason})
+}
+
+func (b *satisfactoryBuilder) addMachine(class docsClass, speed float64, powerGenerationMw float64) {
+	if speed <= 0 {
+		speed = 1
+	}
+	powerClockExponent := class.number("mPowerConsumptionExponent")
+	if powerClockExponent <= 0 {
+		powerClockExponent = defaultPowerClockExponent
+	}
+	name := b.machineNames.assign(class.text("mDisplayName"), class.text("ClassName"))
+	b.machines[class.text("ClassName")] = name
+	b.data.Machines = append(b.data.Machines, model.MachineInfo{
+		Name:               name,
+		Speed:              float32(speed),
+		PowerConsumptionKw: uint(math.Round(class.number("mPowerConsumption") * 1000)),
+		PowerClockExponent: float32(powerClockExponent),
+		PowerGenerationKw:  uint(math.Round(powerGenerationMw * 1000)),
+		DefaultChoice:      1,
+	})
+}
+
+// resource returns name of resource for item class, adding the resource on first use.
+func (b *satisfactoryBuilder) resource(className string) string {
+	if name, exists := b.resources[className]; exists {
+		return name
+	}
+	item := b.items[className]
+	resource := model.ResourceInfo{Name: b.resourceNames.assign(item.text("mDisplayName"), className), SinkValue: float32(item.number("mResourceSinkPoints"))}
+	if item.liquid() {
+		resource.Liquid = 1
+		resource.ResourceUnit = "litre"
+	}
+	b.resources[className] = resource.Name
+	b.data.Resources = append(b.data.Resources, resource)
+	return resource.Name
+}
+
+// addRecipe adds recipe with inputs and outputs given by item classes, unless it uses classes which are not items
+// or its duration and amounts cannot be scaled to whole numbers.
+func (b *satisfactoryBuilder) addRecipe(className string, displayName string, durationS float64, inputs []rawAmount, outputs []rawAmount, machines []string, defaultChoice uint8) {
+	for _, amount := range slices.Concat(inputs, outputs) {
+		if _, exists := b.items[amount.Resource]; !exists {
+			b.skip(SkippedRecipe, className, fmt.Sprintf("'%s' is not an item", amount.Resource))
+			return
+		}
+	}
+	productionTimeS, scaledInputs, scaledOutputs, ok := integerRecipe(durationS, inputs, outputs)
+	if !ok {
+		b.skip(SkippedRecipe, className, "production time and amounts cannot be scaled to whole numbers")
+		return
+	}
+	for i := range scaledInputs {
+		scaledInputs[i].Resource = b.resource(scaledInputs[i].Resource)
+	}
+	for i := range scaledOutputs {
+		scaledOutputs[i].Resource = b.resource(scaledOutputs[i].Resource)
+	}
+	b.data.Recipes = append(b.data.Recipes, GameRecipe{
+		Recipe:   model.RecipeInfo{Name: b.recipeNames.assign(displayName, className), ProductionTimeS: productionTimeS, DefaultChoice: defaultChoice},
+		Inputs:   scaledInputs,
+		Outputs:  scaledOutputs,
+		Machines: machines,
+	})
+}
+
+func (b *satisfactoryBuilder) addManufacturerRecipe(recipe docsClass) {
+	className := recipe.text("ClassName")
+	machines := []string{}
+	for _, producer := range classList(recipe.text("mProducedIn")) {
+		if name, exists := b.machines[producer]; exists && !slices.Contains(machines, name) {
+			machines = append(machines, name)
+		}
+	}
+	if len(machines) == 0 {
+		b.skip(SkippedRecipe, className, "recipe is not produced in any imported building")
+		return
+	}
+	outputs := itemAmounts(recipe.text("mProduct"))
+	if len(outputs) == 0 {
+		b.skip(SkippedRecipe, className, "recipe has no products")
+		return
+	}
+	defaultChoice := uint8(1)
+	if strings.Contains(className, "Alternate") {
+		defaultChoice = 0
+	}
+	b.addRecipe(className, recipe.text("mDisplayName"), recipe.number("mManufactoringDuration"), itemAmounts(recipe.text("mIngredients")), outputs, machines, defaultChoice)
+}
+
+func (b *satisfactoryBuilder) addExtractionRecipes(extractor docsClass, resourceDescriptors []docsClass) {
+	allowed := classList(extractor.text("mAllowedResources"))
+	if extractor.text("mOnlyAllowCertainResources") != "True" {
+		forms := classList(extractor.text("mAllowedResourceForms"))
+		allowed = []string{}
+		for _, descriptor := range resourceDescriptors {
+			if slices.Contains(forms, descriptor.text("mForm")) {
+				allowed = append(allowed, descriptor.text("ClassName"))
+			}
+		}
+	}
+	machineName := b.machines[extractor.text("ClassName")]
+	for _, resourceClass := range allowed {
+		displayName := fmt.Sprintf("%s extraction %s", b.items[resourceClass].text("mDisplayName"), extractor.text("mDisplayName"))
+		outputs := []rawAmount{{Resource: resourceClass, Amount: extractor.number("mItemsPerCycle")}}
+		b.addRecipe(extractor.text("ClassName")+" "+resourceClass, displayName, extractor.number("mExtractCycleTime"), []rawAmount{}, outputs, []string{machineName}, 1)
+	}
+}
+
+// addGeneratorRecipes adds recipes burning every fuel of generator. A single item of solid fuel or 1000 litres of
+// fluid fuel is burnt per cycle, which lasts as long as generator needs to turn energy of the fuel into power.
+func (b *satisfactoryBuilder) addGeneratorRecipes(generator docsClass) {
+	className := generator.text("ClassName")
+	powerMw := generator.number("mPowerProduction")
+	if powerMw <= 0 {
+		b.skip(SkippedRecipe, className, "generator does not produce power")
+		return
+	}
+	fuels := []map[string]string{}
+	if err := json.Unmarshal(generator["mFuel"], &fuels); err != nil {
+		b.skip(SkippedRecipe, className, "fuels of generator could not be parsed")
+		return
+	}
+	machineName := b.machines[className]
+	for _, fuel := range fuels {
+		fuelClass := lastSegment(fuel["mFuelClass"])
+		item, exists := b.items[fuelClass]
+		if !exists || item.number("mEnergyValue") <= 0 {
+			b.skip(SkippedRecipe, className+" "+fuelClass, fmt.Sprintf("'%s' is not an item with energy value", fuelClass))
+			continue
+		}
+		amount := 1.0
+		if item.liquid() {
+			amount = 1000
+		}
+		energyMj := amount * item.number("mEnergyValue")
+		inputs := []rawAmount{{Resource: fuelClass, Amount: amount}}
+		if supplementalClass := lastSegment(fuel["mSupplementalResourceClass"]); supplementalClass != "" && generator.number("mSupplementalToPowerRatio") > 0 {
+			inputs = append(inputs, rawAmount{Resource: supplementalClass, Amount: energyMj * generator.number("mSupplementalToPowerRatio")})
+		}
+		outputs := []rawAmount{}
+		if byproductClass := lastSegment(fuel["mByproduct"]); byproductClass != "" {
+			byproductAmount, _ := strconv.ParseFloat(fuel["mByproductAmount"], 64)
+			outputs = append(outputs, rawAmount{Resource: byproductClass, Amount: amount * byproductAmount})
+		}
+		displayName := fmt.Sprintf("%s burning %s", item.text("mDisplayName"), generator.text("mDisplayName"))
+		b.addRecipe(className+" "+fuelClass, displayName, energyMj/powerMw, inputs, outputs, []string{machineName}, 1)
+	}
+}
+
+// itemAmounts parses list of item amounts, e.g. ((ItemClass="/Script/Engine.BlueprintGeneratedClass'/Game/.../Desc_IronIngot.Desc_IronIngot_C'",Amount=3)).
+func itemAmounts(value string) []rawAmount {
+	amounts := []rawAmount{}
+	for _, match := range itemAmountPattern.FindAllStringSubmatch(value, -1) {
+		amount, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			continue
+		}
+		amounts = append(amounts, rawAmount{Resource: match[1], Amount: amount})
+	}
+	return amounts
+}
+
+// classList parses list of class paths, e.g. ("/Game/.../Build_ConstructorMk1.Build_ConstructorMk1_C","/Script/FactoryGame.FGBuildGun"),
+// into class names.
+func classList(value string) []string {
+	classes := []string{}
+	for _, path := range strings.Split(strings.Trim(value, "()"), ",") {
+		if class := lastSegment(path); class != "" {
+			classes = append(classes, class)
+		}
+	}
+	return classes
+}
+
+// lastSegment returns class name from class path, e.g. Desc_Coal_C from "/Game/.../Desc_Coal.Desc_Coal_C".
+func lastSegment(path string) string {
+	path = strings.Trim(strings.TrimSpace(path), `"'`)
+	if index := strings.LastIndexAny(path, "./"); index >= 0 {
+		path = path[index+1:]
+	}
+	return strings.Trim(path, `"'`)
+}
+
+// decodeText converts UTF-16 text with byte order mark into UTF-8 and removes UTF-8 byte order mark.
+func decodeText(data []byte) ([]byte, error) {
+	var order binary.ByteOrder
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		order = binary.LittleEndian
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		order = binary.BigEndian
+	default:
+		return bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF}), nil
+	}
+	data = data[2:]
+	if len(data)%2 != 0 {
+		return nil, fmt.Errorf("UTF-16 text has odd number of bytes")
+	}
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+	return []byte(string(utf16.Decode(units))), nil
+}
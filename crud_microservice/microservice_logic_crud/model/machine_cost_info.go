@@ -0,0 +1,28 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package model
+
+type MachineCostInfo struct {
+	Id          uint
+	UsersId     uint
+	MachinesId  uint
+	ResourcesId uint
+	Amount      uint
+	// MachinesRef and ResourcesRef reference machine and resource by temporary id or name instead of id
+	MachinesRef  string `json:",omitempty"`
+	ResourcesRef string `json:",omitempty"`
+}
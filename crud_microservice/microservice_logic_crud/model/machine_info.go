@@ -26,5 +26,11 @@ type MachineInfo struct {
 	OutputsLiquid      uint
 	Speed              float32
 	PowerConsumptionKw uint
+	// PowerClockExponent is the exponent of clock speed in power consumption, 0 is stored as 1
+	PowerClockExponent float32
+	PowerGenerationKw  uint
 	DefaultChoice      uint8
+	UnlockTiersId      uint
+	// TempId is a temporary id of machine inserted in the same request as records referencing it
+	TempId string `json:",omitempty"`
 }
@@ -21,4 +21,7 @@ type MachinesRecipesInfo struct {
 	UsersId    uint
 	RecipesId  uint
 	MachinesId uint
+	// RecipesRef and MachinesRef reference recipe and machine by temporary id or name instead of id
+	RecipesRef  string `json:",omitempty"`
+	MachinesRef string `json:",omitempty"`
 }
@@ -22,4 +22,7 @@ type RecipeInfo struct {
 	UsersId         uint
 	ProductionTimeS uint
 	DefaultChoice   uint8
+	UnlockTiersId   uint
+	// TempId is a temporary id of recipe inserted in the same request as records referencing it
+	TempId string `json:",omitempty"`
 }
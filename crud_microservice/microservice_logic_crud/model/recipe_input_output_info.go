@@ -22,4 +22,7 @@ type RecipeInputOutputInfo struct {
 	RecipesId   uint
 	ResourcesId uint
 	Amount      uint
+	// RecipesRef and ResourcesRef reference recipe and resource by temporary id or name instead of id
+	RecipesRef   string `json:",omitempty"`
+	ResourcesRef string `json:",omitempty"`
 }
@@ -22,4 +22,7 @@ type ResourceInfo struct {
 	UsersId      uint
 	Liquid       uint8
 	ResourceUnit string
+	SinkValue    float32
+	// TempId is a temporary id of resource inserted in the same request as records referencing it
+	TempId string `json:",omitempty"`
 }
@@ -41,20 +41,7 @@ func (r *MySQLRepo) SelectMachinesById(ctx context.Context, ids []int, userId in
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve data from db: %w", err)
 	}
-	var resultRows []model.MachineInfo
-	for result.Next() {
-		var row model.MachineInfo
-		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.InputsSolid, &row.InputsLiquid, &row.OutputsSolid, &row.OutputsLiquid, &row.Speed, &row.PowerConsumptionKw, &row.DefaultChoice)
-		if err != nil {
-			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
-		}
-		resultRows = append(resultRows, row)
-	}
-	err = result.Err()
-	if err != nil {
-		return nil, fmt.Errorf("encountered an unexpected error: %w", err)
-	}
-	return resultRows, nil
+	return scanMachines(result)
 }
 
 func (r *MySQLRepo) SelectMachines(ctx context.Context, startId int, rowsRet int, userId int) ([]model.MachineInfo, error) {
@@ -67,16 +54,40 @@ func (r *MySQLRepo) SelectMachines(ctx context.Context, startId int, rowsRet int
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve data from db: %w", err)
 	}
+	return scanMachines(result)
+}
+
+// SelectMachinesWithTransaction returns all machines of the user as a part of transaction. Transaction is rolled back
+// if data could not be retrieved.
+func (r *MySQLRepo) SelectMachinesWithTransaction(ctx context.Context, transaction *sql.Tx, userId int) ([]model.MachineInfo, error) {
+	query := "SELECT * FROM machines WHERE users_id = " + fmt.Sprint(userId) + ";"
+	result, err := transaction.QueryContext(ctx, query)
+	if err == nil {
+		var resultRows []model.MachineInfo
+		resultRows, err = scanMachines(result)
+		if err == nil {
+			return resultRows, nil
+		}
+	}
+	rollbackErr := transaction.Rollback()
+	if rollbackErr != nil {
+		return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+	}
+	return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+}
+
+func scanMachines(result *sql.Rows) ([]model.MachineInfo, error) {
+	defer result.Close()
 	var resultRows []model.MachineInfo
 	for result.Next() {
 		var row model.MachineInfo
-		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.InputsSolid, &row.InputsLiquid, &row.OutputsSolid, &row.OutputsLiquid, &row.Speed, &row.PowerConsumptionKw, &row.DefaultChoice)
+		err := result.Scan(&row.Id, &row.Name, &row.UsersId, &row.InputsSolid, &row.InputsLiquid, &row.OutputsSolid, &row.OutputsLiquid, &row.Speed, &row.PowerConsumptionKw, &row.PowerClockExponent, &row.PowerGenerationKw, &row.DefaultChoice, &row.UnlockTiersId)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
 		}
 		resultRows = append(resultRows, row)
 	}
-	err = result.Err()
+	err := result.Err()
 	if err != nil {
 		return nil, fmt.Errorf("encountered an unexpected error: %w", err)
 	}
@@ -84,7 +95,38 @@ func (r *MySQLRepo) SelectMachines(ctx context.Context, startId int, rowsRet int
 }
 
 func (r *MySQLRepo) InsertMachines(ctx context.Context, data []model.MachineInfo, userId uint) (sql.Result, error) {
-	query := "INSERT INTO machines(name, users_id, inputs_solid, inputs_liquid, outputs_solid, outputs_liquid, speed, power_consumption_kw, default_choice) VALUES"
+	query := insertMachinesQuery(data, userId)
+	result, err := r.DB.ExecContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("data has not been inserted: %w", err)
+	}
+	return result, nil
+}
+
+// InsertMachineWithTransaction inserts a single machine as a part of transaction and returns its id.
+// Transaction is rolled back if the machine could not be inserted.
+func (r *MySQLRepo) InsertMachineWithTransaction(ctx context.Context, transaction *sql.Tx, data model.MachineInfo, userId uint) (int64, error) {
+	result, err := transaction.ExecContext(ctx, insertMachinesQuery([]model.MachineInfo{data}, userId))
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return 0, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return 0, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return 0, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return 0, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return id, nil
+}
+
+func insertMachinesQuery(data []model.MachineInfo, userId uint) string {
+	query := "INSERT INTO machines(name, users_id, inputs_solid, inputs_liquid, outputs_solid, outputs_liquid, speed, power_consumption_kw, power_clock_exponent, power_generation_kw, default_choice, unlock_tiers_id) VALUES"
 	for i, entry := range data {
 		if i != 0 {
 			query += ","
@@ -97,17 +139,38 @@ func (r *MySQLRepo) InsertMachines(ctx context.Context, data []model.MachineInfo
 			`, ` + fmt.Sprint(entry.OutputsLiquid) +
 			`, ` + fmt.Sprint(entry.Speed) +
 			`, ` + fmt.Sprint(entry.PowerConsumptionKw) +
-			`, ` + fmt.Sprint(entry.DefaultChoice) + `)`
+			`, ` + fmt.Sprint(powerClockExponent(entry)) +
+			`, ` + fmt.Sprint(entry.PowerGenerationKw) +
+			`, ` + fmt.Sprint(entry.DefaultChoice) +
+			`, ` + fmt.Sprint(entry.UnlockTiersId) + `)`
 	}
 	query += ";"
+	return query
+}
+
+func (r *MySQLRepo) DeleteMachines(ctx context.Context, ids []int, userId int) (sql.Result, error) {
+	query := deleteMachinesQuery(ids, userId)
 	result, err := r.DB.ExecContext(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("data has not been inserted: %w", err)
+		return nil, fmt.Errorf("data has not been deleted: %w", err)
 	}
 	return result, nil
 }
 
-func (r *MySQLRepo) DeleteMachines(ctx context.Context, ids []int, userId int) (sql.Result, error) {
+// DeleteMachinesWithTransaction deletes machines as a part of transaction. Transaction is rolled back if data could not be deleted.
+func (r *MySQLRepo) DeleteMachinesWithTransaction(ctx context.Context, transaction *sql.Tx, ids []int, userId int) (sql.Result, error) {
+	result, err := transaction.ExecContext(ctx, deleteMachinesQuery(ids, userId))
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+func deleteMachinesQuery(ids []int, userId int) string {
 	query := "DELETE FROM machines WHERE id in ("
 	for i, id := range ids {
 		if i != 0 {
@@ -116,11 +179,7 @@ func (r *MySQLRepo) DeleteMachines(ctx context.Context, ids []int, userId int) (
 		query += " " + fmt.Sprint(id)
 	}
 	query += ") and users_id = " + fmt.Sprint(userId) + ";"
-	result, err := r.DB.ExecContext(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("data has not been deleted: %w", err)
-	}
-	return result, nil
+	return query
 }
 
 func (r *MySQLRepo) DeleteMachinesByUserId(ctx context.Context, transaction *sql.Tx, userId int) (sql.Result, error) {
@@ -143,8 +202,7 @@ func (r *MySQLRepo) UpdateMachines(ctx context.Context, data []model.MachineInfo
 		return results, fmt.Errorf("data has not been updated: %w", err)
 	}
 	for _, entry := range data {
-		query := fmt.Sprintf("UPDATE machines SET name='%s', inputs_solid=%d, inputs_liquid=%d, outputs_solid=%d, outputs_liquid=%d, speed=%f, power_consumption_kw=%d, default_choice=%d WHERE id=%d and users_id=%d;",
-			entry.Name, entry.InputsSolid, entry.InputsLiquid, entry.OutputsSolid, entry.OutputsLiquid, entry.Speed, entry.PowerConsumptionKw, entry.DefaultChoice, entry.Id, userId)
+		query := updateMachinesQuery(entry, userId)
 		result, err := transaction.ExecContext(ctx, query)
 		results = append(results, result)
 		if err != nil {
@@ -161,3 +219,35 @@ func (r *MySQLRepo) UpdateMachines(ctx context.Context, data []model.MachineInfo
 	}
 	return results, nil
 }
+
+// UpdateMachinesWithTransaction updates machines as a part of transaction. Transaction is rolled back if data could not be updated.
+func (r *MySQLRepo) UpdateMachinesWithTransaction(ctx context.Context, transaction *sql.Tx, data []model.MachineInfo, userId uint) ([]sql.Result, error) {
+	results := []sql.Result{}
+	for _, entry := range data {
+		result, err := transaction.ExecContext(ctx, updateMachinesQuery(entry, userId))
+		if err != nil {
+			rollbackErr := transaction.Rollback()
+			if rollbackErr != nil {
+				return results, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+			}
+			return results, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func updateMachinesQuery(entry model.MachineInfo, userId uint) string {
+	query := fmt.Sprintf("UPDATE machines SET name='%s', inputs_solid=%d, inputs_liquid=%d, outputs_solid=%d, outputs_liquid=%d, speed=%f, power_consumption_kw=%d, power_clock_exponent=%f, power_generation_kw=%d, default_choice=%d, unlock_tiers_id=%d WHERE id=%d and users_id=%d;",
+		entry.Name, entry.InputsSolid, entry.InputsLiquid, entry.OutputsSolid, entry.OutputsLiquid, entry.Speed, entry.PowerConsumptionKw, powerClockExponent(entry), entry.PowerGenerationKw, entry.DefaultChoice, entry.UnlockTiersId, entry.Id, userId)
+	return query
+}
+
+// powerClockExponent returns power clock exponent of machine, 0 means that it has not been provided and is stored
+// as 1, making power consumption proportional to clock speed
+func powerClockExponent(entry model.MachineInfo) float32 {
+	if entry.PowerClockExponent == 0 {
+		return 1
+	}
+	return entry.PowerClockExponent
+}
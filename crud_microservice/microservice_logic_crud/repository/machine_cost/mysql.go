@@ -0,0 +1,227 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package machinecost
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+)
+
+type MySQLRepo struct {
+	DB *sql.DB
+}
+
+func (r *MySQLRepo) SelectMachinesCostsById(ctx context.Context, ids []int, userId int) ([]model.MachineCostInfo, error) {
+	query := "SELECT * FROM machines_costs WHERE id in ("
+	for i, id := range ids {
+		if i != 0 {
+			query += ","
+		}
+		query += " " + fmt.Sprint(id)
+	}
+	query += ") AND users_id = " + fmt.Sprint(userId) + ";"
+	result, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve data from db: %w", err)
+	}
+	return scanMachinesCosts(result)
+}
+
+func (r *MySQLRepo) SelectMachinesCosts(ctx context.Context, startId int, rowsRet int, userId int) ([]model.MachineCostInfo, error) {
+	query := "SELECT * FROM machines_costs WHERE id >= " + fmt.Sprint(startId) + " AND users_id = " + fmt.Sprint(userId)
+	if rowsRet > 0 {
+		query += " LIMIT " + fmt.Sprint(rowsRet)
+	}
+	query += ";"
+	result, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve data from db: %w", err)
+	}
+	return scanMachinesCosts(result)
+}
+
+// SelectMachinesCostsWithTransaction returns all machines costs of the user as a part of transaction. Transaction is rolled back
+// if data could not be retrieved.
+func (r *MySQLRepo) SelectMachinesCostsWithTransaction(ctx context.Context, transaction *sql.Tx, userId int) ([]model.MachineCostInfo, error) {
+	query := "SELECT * FROM machines_costs WHERE users_id = " + fmt.Sprint(userId) + ";"
+	result, err := transaction.QueryContext(ctx, query)
+	if err == nil {
+		var resultRows []model.MachineCostInfo
+		resultRows, err = scanMachinesCosts(result)
+		if err == nil {
+			return resultRows, nil
+		}
+	}
+	rollbackErr := transaction.Rollback()
+	if rollbackErr != nil {
+		return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+	}
+	return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+}
+
+func scanMachinesCosts(result *sql.Rows) ([]model.MachineCostInfo, error) {
+	defer result.Close()
+	var resultRows []model.MachineCostInfo
+	for result.Next() {
+		var row model.MachineCostInfo
+		err := result.Scan(&row.Id, &row.UsersId, &row.MachinesId, &row.ResourcesId, &row.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
+		}
+		resultRows = append(resultRows, row)
+	}
+	err := result.Err()
+	if err != nil {
+		return nil, fmt.Errorf("encountered an unexpected error: %w", err)
+	}
+	return resultRows, nil
+}
+
+func (r *MySQLRepo) InsertMachinesCosts(ctx context.Context, data []model.MachineCostInfo, userId uint) (sql.Result, error) {
+	query := insertMachinesCostsQuery(data, userId)
+	result, err := r.DB.ExecContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("data has not been inserted: %w", err)
+	}
+	return result, nil
+}
+
+// InsertMachinesCostsWithTransaction inserts machines costs as a part of transaction. Transaction is rolled back if data could not be inserted.
+func (r *MySQLRepo) InsertMachinesCostsWithTransaction(ctx context.Context, transaction *sql.Tx, data []model.MachineCostInfo, userId uint) (sql.Result, error) {
+	result, err := transaction.ExecContext(ctx, insertMachinesCostsQuery(data, userId))
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+func insertMachinesCostsQuery(data []model.MachineCostInfo, userId uint) string {
+	query := "INSERT INTO machines_costs(users_id, machines_id, resources_id, amount) VALUES"
+	for i, entry := range data {
+		if i != 0 {
+			query += ","
+		}
+		query += ` ("` + fmt.Sprint(userId) +
+			`", ` + fmt.Sprint(entry.MachinesId) +
+			`, ` + fmt.Sprint(entry.ResourcesId) +
+			`, "` + fmt.Sprint(entry.Amount) + `")`
+	}
+	query += ";"
+	return query
+}
+
+func (r *MySQLRepo) DeleteMachinesCosts(ctx context.Context, ids []int, userId int) (sql.Result, error) {
+	query := deleteMachinesCostsQuery(ids, userId)
+	result, err := r.DB.ExecContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("data has not been deleted: %w", err)
+	}
+	return result, nil
+}
+
+// DeleteMachinesCostsWithTransaction deletes machines costs as a part of transaction. Transaction is rolled back if data could not be deleted.
+func (r *MySQLRepo) DeleteMachinesCostsWithTransaction(ctx context.Context, transaction *sql.Tx, ids []int, userId int) (sql.Result, error) {
+	result, err := transaction.ExecContext(ctx, deleteMachinesCostsQuery(ids, userId))
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+func deleteMachinesCostsQuery(ids []int, userId int) string {
+	query := "DELETE FROM machines_costs WHERE id in ("
+	for i, id := range ids {
+		if i != 0 {
+			query += ","
+		}
+		query += " " + fmt.Sprint(id)
+	}
+	query += ") and users_id = " + fmt.Sprint(userId) + ";"
+	return query
+}
+
+func (r *MySQLRepo) DeleteMachinesCostsByUserId(ctx context.Context, transaction *sql.Tx, userId int) (sql.Result, error) {
+	query := "DELETE FROM machines_costs WHERE users_id = " + fmt.Sprint(userId) + ";"
+	result, err := transaction.ExecContext(ctx, query)
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+func (r *MySQLRepo) UpdateMachinesCosts(ctx context.Context, data []model.MachineCostInfo, userId uint) ([]sql.Result, error) {
+	results := []sql.Result{}
+	transaction, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return results, fmt.Errorf("data has not been updated: %w", err)
+	}
+	for _, entry := range data {
+		query := updateMachinesCostsQuery(entry, userId)
+		result, err := transaction.ExecContext(ctx, query)
+		results = append(results, result)
+		if err != nil {
+			rollbackErr := transaction.Rollback()
+			if rollbackErr != nil {
+				return results, fmt.Errorf("could not rollback transaction: %w", rollbackErr)
+			}
+			return results, fmt.Errorf("data has not been updated: %w", err)
+		}
+	}
+	err = transaction.Commit()
+	if err != nil {
+		return results, fmt.Errorf("data has not been updated: %w", err)
+	}
+	return results, nil
+}
+
+// UpdateMachinesCostsWithTransaction updates machines costs as a part of transaction. Transaction is rolled back if data could not be updated.
+func (r *MySQLRepo) UpdateMachinesCostsWithTransaction(ctx context.Context, transaction *sql.Tx, data []model.MachineCostInfo, userId uint) ([]sql.Result, error) {
+	results := []sql.Result{}
+	for _, entry := range data {
+		result, err := transaction.ExecContext(ctx, updateMachinesCostsQuery(entry, userId))
+		if err != nil {
+			rollbackErr := transaction.Rollback()
+			if rollbackErr != nil {
+				return results, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+			}
+			return results, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func updateMachinesCostsQuery(entry model.MachineCostInfo, userId uint) string {
+	query := fmt.Sprintf("UPDATE machines_costs SET machines_id='%d', resources_id=%d, amount='%d' WHERE id=%d and users_id=%d;",
+		entry.MachinesId, entry.ResourcesId, entry.Amount, entry.Id, userId)
+	return query
+}
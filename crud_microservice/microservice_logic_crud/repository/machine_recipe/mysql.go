@@ -41,20 +41,7 @@ func (r *MySQLRepo) SelectMachinesRecipesById(ctx context.Context, ids []int, us
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve data from r.DB: %w", err)
 	}
-	var resultRows []model.MachinesRecipesInfo
-	for result.Next() {
-		var row model.MachinesRecipesInfo
-		err = result.Scan(&row.Id, &row.UsersId, &row.RecipesId, &row.MachinesId)
-		if err != nil {
-			return nil, fmt.Errorf("could not parse data retrieved from r.DB: %w", err)
-		}
-		resultRows = append(resultRows, row)
-	}
-	err = result.Err()
-	if err != nil {
-		return nil, fmt.Errorf("encountered an unexpected error: %w", err)
-	}
-	return resultRows, nil
+	return scanMachinesRecipes(result)
 }
 
 func (r *MySQLRepo) SelectMachinesRecipes(ctx context.Context, startId int, rowsRet int, userId int) ([]model.MachinesRecipesInfo, error) {
@@ -67,16 +54,40 @@ func (r *MySQLRepo) SelectMachinesRecipes(ctx context.Context, startId int, rows
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve data from r.DB: %w", err)
 	}
+	return scanMachinesRecipes(result)
+}
+
+// SelectMachinesRecipesWithTransaction returns all machines recipes of the user as a part of transaction. Transaction is rolled back
+// if data could not be retrieved.
+func (r *MySQLRepo) SelectMachinesRecipesWithTransaction(ctx context.Context, transaction *sql.Tx, userId int) ([]model.MachinesRecipesInfo, error) {
+	query := "SELECT * FROM machines_recipes WHERE users_id = " + fmt.Sprint(userId) + ";"
+	result, err := transaction.QueryContext(ctx, query)
+	if err == nil {
+		var resultRows []model.MachinesRecipesInfo
+		resultRows, err = scanMachinesRecipes(result)
+		if err == nil {
+			return resultRows, nil
+		}
+	}
+	rollbackErr := transaction.Rollback()
+	if rollbackErr != nil {
+		return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+	}
+	return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+}
+
+func scanMachinesRecipes(result *sql.Rows) ([]model.MachinesRecipesInfo, error) {
+	defer result.Close()
 	var resultRows []model.MachinesRecipesInfo
 	for result.Next() {
 		var row model.MachinesRecipesInfo
-		err = result.Scan(&row.Id, &row.UsersId, &row.RecipesId, &row.MachinesId)
+		err := result.Scan(&row.Id, &row.UsersId, &row.RecipesId, &row.MachinesId)
 		if err != nil {
-			return nil, fmt.Errorf("could not parse data retrieved from r.DB: %w", err)
+			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
 		}
 		resultRows = append(resultRows, row)
 	}
-	err = result.Err()
+	err := result.Err()
 	if err != nil {
 		return nil, fmt.Errorf("encountered an unexpected error: %w", err)
 	}
@@ -107,15 +118,33 @@ func (r *MySQLRepo) InsertMachinesRecipes(ctx context.Context, data []model.Mach
 	return result, nil
 }
 
-func (r *MySQLRepo) DeleteMachinesRecipes(ctx context.Context, ids []int, userId int) (sql.Result, error) {
-	query := "DELETE FROM machines_recipes WHERE id in ("
-	for i, id := range ids {
+// InsertMachinesRecipesWithTransaction inserts machines recipes as a part of transaction. Transaction is rolled back
+// if data could not be inserted. Slots of machines are not verified, as recipes inserted by the same transaction
+// are not visible to the verification, caller is responsible for pairing recipes only with fitting machines.
+func (r *MySQLRepo) InsertMachinesRecipesWithTransaction(ctx context.Context, transaction *sql.Tx, data []model.MachinesRecipesInfo, userId uint) (sql.Result, error) {
+	query := "INSERT INTO machines_recipes(users_id, recipes_id, machines_id) VALUES"
+	for i, entry := range data {
 		if i != 0 {
 			query += ","
 		}
-		query += " " + fmt.Sprint(id)
+		query += ` (` + fmt.Sprint(userId) +
+			`, ` + fmt.Sprint(entry.RecipesId) +
+			`, ` + fmt.Sprint(entry.MachinesId) + `)`
 	}
-	query += ") and users_id = " + fmt.Sprint(userId) + ";"
+	query += ";"
+	result, err := transaction.ExecContext(ctx, query)
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+func (r *MySQLRepo) DeleteMachinesRecipes(ctx context.Context, ids []int, userId int) (sql.Result, error) {
+	query := deleteMachinesRecipesQuery(ids, userId)
 	result, err := r.DB.ExecContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("data has not been deleted: %w", err)
@@ -123,6 +152,31 @@ func (r *MySQLRepo) DeleteMachinesRecipes(ctx context.Context, ids []int, userId
 	return result, nil
 }
 
+// DeleteMachinesRecipesWithTransaction deletes machines recipes as a part of transaction. Transaction is rolled back if data could not be deleted.
+func (r *MySQLRepo) DeleteMachinesRecipesWithTransaction(ctx context.Context, transaction *sql.Tx, ids []int, userId int) (sql.Result, error) {
+	result, err := transaction.ExecContext(ctx, deleteMachinesRecipesQuery(ids, userId))
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+func deleteMachinesRecipesQuery(ids []int, userId int) string {
+	query := "DELETE FROM machines_recipes WHERE id in ("
+	for i, id := range ids {
+		if i != 0 {
+			query += ","
+		}
+		query += " " + fmt.Sprint(id)
+	}
+	query += ") and users_id = " + fmt.Sprint(userId) + ";"
+	return query
+}
+
 func (r *MySQLRepo) DeleteMachinesRecipesByUserId(ctx context.Context, transaction *sql.Tx, userId int) (sql.Result, error) {
 	query := "DELETE FROM machines_recipes WHERE users_id = " + fmt.Sprint(userId) + ";"
 	result, err := transaction.ExecContext(ctx, query)
@@ -147,8 +201,7 @@ func (r *MySQLRepo) UpdateMachinesRecipes(ctx context.Context, data []model.Mach
 		if err.Error() == sql.ErrNoRows.Error() {
 			continue
 		}
-		query := fmt.Sprintf("UPDATE machines_recipes SET recipes_id='%d', machines_id=%d WHERE id=%d and users_id=%d;",
-			entry.RecipesId, entry.MachinesId, entry.Id, userId)
+		query := updateMachinesRecipesQuery(entry, userId)
 		result, err := transaction.ExecContext(ctx, query)
 		results = append(results, result)
 		if err != nil {
@@ -166,6 +219,30 @@ func (r *MySQLRepo) UpdateMachinesRecipes(ctx context.Context, data []model.Mach
 	return results, nil
 }
 
+// UpdateMachinesRecipesWithTransaction updates machines recipes as a part of transaction. Transaction is rolled back
+// if data could not be updated. Slots of machines are not verified, for the same reason as in InsertMachinesRecipesWithTransaction.
+func (r *MySQLRepo) UpdateMachinesRecipesWithTransaction(ctx context.Context, transaction *sql.Tx, data []model.MachinesRecipesInfo, userId uint) ([]sql.Result, error) {
+	results := []sql.Result{}
+	for _, entry := range data {
+		result, err := transaction.ExecContext(ctx, updateMachinesRecipesQuery(entry, userId))
+		if err != nil {
+			rollbackErr := transaction.Rollback()
+			if rollbackErr != nil {
+				return results, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+			}
+			return results, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func updateMachinesRecipesQuery(entry model.MachinesRecipesInfo, userId uint) string {
+	query := fmt.Sprintf("UPDATE machines_recipes SET recipes_id='%d', machines_id=%d WHERE id=%d and users_id=%d;",
+		entry.RecipesId, entry.MachinesId, entry.Id, userId)
+	return query
+}
+
 func (r *MySQLRepo) verifyRecipeMachineIntegrity(ctx context.Context, recipeId uint, machineId uint, userId uint) error {
 	query := fmt.Sprintf(`select * from machines where inputs_liquid >= 
 	(select count(*) from recipes r 
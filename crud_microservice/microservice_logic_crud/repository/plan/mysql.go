@@ -0,0 +1,277 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package plan
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+)
+
+// MySQLRepo stores targets, alternative recipes, alternative machines and production tree of a plan as json
+// documents, so values of plans are passed to queries as arguments instead of being inserted into query text.
+type MySQLRepo struct {
+	DB *sql.DB
+}
+
+func (r *MySQLRepo) SelectPlansById(ctx context.Context, ids []int, userId int) ([]model.PlanInfo, error) {
+	query := "SELECT * FROM plans WHERE id in ("
+	for i, id := range ids {
+		if i != 0 {
+			query += ","
+		}
+		query += " " + fmt.Sprint(id)
+	}
+	query += ") AND users_id = " + fmt.Sprint(userId) + ";"
+	result, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve data from db: %w", err)
+	}
+	return scanPlans(result)
+}
+
+func (r *MySQLRepo) SelectPlans(ctx context.Context, startId int, rowsRet int, userId int) ([]model.PlanInfo, error) {
+	query := "SELECT * FROM plans WHERE id >= " + fmt.Sprint(startId) + " AND users_id = " + fmt.Sprint(userId)
+	if rowsRet > 0 {
+		query += " LIMIT " + fmt.Sprint(rowsRet)
+	}
+	query += ";"
+	result, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve data from db: %w", err)
+	}
+	return scanPlans(result)
+}
+
+// SelectPlansWithTransaction returns all plans of the user as a part of transaction. Transaction is rolled back
+// if data could not be retrieved.
+func (r *MySQLRepo) SelectPlansWithTransaction(ctx context.Context, transaction *sql.Tx, userId int) ([]model.PlanInfo, error) {
+	result, err := transaction.QueryContext(ctx, "SELECT * FROM plans WHERE users_id = ?;", userId)
+	if err == nil {
+		var resultRows []model.PlanInfo
+		resultRows, err = scanPlans(result)
+		if err == nil {
+			return resultRows, nil
+		}
+	}
+	rollbackErr := transaction.Rollback()
+	if rollbackErr != nil {
+		return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+	}
+	return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+}
+
+func scanPlans(result *sql.Rows) ([]model.PlanInfo, error) {
+	defer result.Close()
+	var resultRows []model.PlanInfo
+	for result.Next() {
+		var row model.PlanInfo
+		var targets, altRecipes, altMachines, productionTree string
+		err := result.Scan(&row.Id, &row.Name, &row.UsersId, &targets, &altRecipes, &altMachines, &productionTree)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
+		}
+		err = json.Unmarshal([]byte(targets), &row.Targets)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse targets of plan retrieved from db: %w", err)
+		}
+		err = json.Unmarshal([]byte(altRecipes), &row.AltRecipes)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse alternative recipes of plan retrieved from db: %w", err)
+		}
+		err = json.Unmarshal([]byte(altMachines), &row.AltMachines)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse alternative machines of plan retrieved from db: %w", err)
+		}
+		row.ProductionTree = json.RawMessage(productionTree)
+		resultRows = append(resultRows, row)
+	}
+	err := result.Err()
+	if err != nil {
+		return nil, fmt.Errorf("encountered an unexpected error: %w", err)
+	}
+	return resultRows, nil
+}
+
+// planArguments returns values of plan columns following users_id, in order of columns of plans table
+func planArguments(entry model.PlanInfo) ([]any, error) {
+	if entry.Targets == nil {
+		entry.Targets = []model.PlanTarget{}
+	}
+	if entry.AltRecipes == nil {
+		entry.AltRecipes = []string{}
+	}
+	if entry.AltMachines == nil {
+		entry.AltMachines = []string{}
+	}
+	targets, err := json.Marshal(entry.Targets)
+	if err != nil {
+		return nil, err
+	}
+	altRecipes, err := json.Marshal(entry.AltRecipes)
+	if err != nil {
+		return nil, err
+	}
+	altMachines, err := json.Marshal(entry.AltMachines)
+	if err != nil {
+		return nil, err
+	}
+	return []any{string(targets), string(altRecipes), string(altMachines), string(entry.ProductionTree)}, nil
+}
+
+func (r *MySQLRepo) InsertPlans(ctx context.Context, data []model.PlanInfo, userId uint) (sql.Result, error) {
+	query, arguments, err := insertPlansQuery(data, userId)
+	if err != nil {
+		return nil, fmt.Errorf("data has not been inserted: %w", err)
+	}
+	result, err := r.DB.ExecContext(ctx, query, arguments...)
+	if err != nil {
+		return nil, fmt.Errorf("data has not been inserted: %w", err)
+	}
+	return result, nil
+}
+
+// InsertPlansWithTransaction inserts plans as a part of transaction. Transaction is rolled back if data could not be inserted.
+func (r *MySQLRepo) InsertPlansWithTransaction(ctx context.Context, transaction *sql.Tx, data []model.PlanInfo, userId uint) (sql.Result, error) {
+	query, arguments, err := insertPlansQuery(data, userId)
+	if err == nil {
+		var result sql.Result
+		result, err = transaction.ExecContext(ctx, query, arguments...)
+		if err == nil {
+			return result, nil
+		}
+	}
+	rollbackErr := transaction.Rollback()
+	if rollbackErr != nil {
+		return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+	}
+	return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+}
+
+func insertPlansQuery(data []model.PlanInfo, userId uint) (string, []any, error) {
+	query := "INSERT INTO plans(name, users_id, targets, alt_recipes, alt_machines, production_tree) VALUES"
+	arguments := []any{}
+	for i, entry := range data {
+		if i != 0 {
+			query += ","
+		}
+		query += " (?, ?, ?, ?, ?, ?)"
+		planArgs, err := planArguments(entry)
+		if err != nil {
+			return "", nil, err
+		}
+		arguments = append(arguments, entry.Name, userId)
+		arguments = append(arguments, planArgs...)
+	}
+	query += ";"
+	return query, arguments, nil
+}
+
+func (r *MySQLRepo) DeletePlans(ctx context.Context, ids []int, userId int) (sql.Result, error) {
+	query := "DELETE FROM plans WHERE id in ("
+	for i, id := range ids {
+		if i != 0 {
+			query += ","
+		}
+		query += " " + fmt.Sprint(id)
+	}
+	query += ") and users_id = " + fmt.Sprint(userId) + ";"
+	result, err := r.DB.ExecContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("data has not been deleted: %w", err)
+	}
+	return result, nil
+}
+
+func (r *MySQLRepo) DeletePlansByUserId(ctx context.Context, transaction *sql.Tx, userId int) (sql.Result, error) {
+	query := "DELETE FROM plans WHERE users_id = " + fmt.Sprint(userId) + ";"
+	result, err := transaction.ExecContext(ctx, query)
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+func (r *MySQLRepo) UpdatePlans(ctx context.Context, data []model.PlanInfo, userId uint) ([]sql.Result, error) {
+	results := []sql.Result{}
+	transaction, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return results, fmt.Errorf("data has not been updated: %w", err)
+	}
+	for _, entry := range data {
+		arguments, err := updatePlansArguments(entry, userId)
+		if err != nil {
+			transaction.Rollback()
+			return results, fmt.Errorf("data has not been updated: %w", err)
+		}
+		result, err := transaction.ExecContext(ctx, updatePlansQuery, arguments...)
+		results = append(results, result)
+		if err != nil {
+			rollbackErr := transaction.Rollback()
+			if rollbackErr != nil {
+				return results, fmt.Errorf("could not rollback transaction: %w", rollbackErr)
+			}
+			return results, fmt.Errorf("data has not been updated: %w", err)
+		}
+	}
+	err = transaction.Commit()
+	if err != nil {
+		return results, fmt.Errorf("data has not been updated: %w", err)
+	}
+	return results, nil
+}
+
+// UpdatePlansWithTransaction updates plans as a part of transaction. Transaction is rolled back if data could not be updated.
+func (r *MySQLRepo) UpdatePlansWithTransaction(ctx context.Context, transaction *sql.Tx, data []model.PlanInfo, userId uint) ([]sql.Result, error) {
+	results := []sql.Result{}
+	for _, entry := range data {
+		arguments, err := updatePlansArguments(entry, userId)
+		if err == nil {
+			var result sql.Result
+			result, err = transaction.ExecContext(ctx, updatePlansQuery, arguments...)
+			if err == nil {
+				results = append(results, result)
+				continue
+			}
+		}
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return results, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return results, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return results, nil
+}
+
+const updatePlansQuery = "UPDATE plans SET name=?, targets=?, alt_recipes=?, alt_machines=?, production_tree=? WHERE id=? and users_id=?;"
+
+func updatePlansArguments(entry model.PlanInfo, userId uint) ([]any, error) {
+	planArgs, err := planArguments(entry)
+	if err != nil {
+		return nil, err
+	}
+	arguments := append([]any{entry.Name}, planArgs...)
+	arguments = append(arguments, entry.Id, userId)
+	return arguments, nil
+}
@@ -0,0 +1,100 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package progress
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+)
+
+type MySQLRepo struct {
+	DB *sql.DB
+}
+
+// SelectProgress returns progress of user, exists is false if user has not set current tier yet
+func (r *MySQLRepo) SelectProgress(ctx context.Context, userId int) (model.ProgressInfo, bool, error) {
+	var row model.ProgressInfo
+	err := r.DB.QueryRowContext(ctx, selectProgressQuery, userId).Scan(&row.UsersId, &row.CurrentTier)
+	if errors.Is(err, sql.ErrNoRows) {
+		return row, false, nil
+	}
+	if err != nil {
+		return row, false, fmt.Errorf("could not retrieve data from db: %w", err)
+	}
+	return row, true, nil
+}
+
+// SelectProgressWithTransaction returns progress of user as a part of transaction, exists is false if user has not set
+// current tier yet. Transaction is rolled back if data could not be retrieved.
+func (r *MySQLRepo) SelectProgressWithTransaction(ctx context.Context, transaction *sql.Tx, userId int) (model.ProgressInfo, bool, error) {
+	var row model.ProgressInfo
+	err := transaction.QueryRowContext(ctx, selectProgressQuery, userId).Scan(&row.UsersId, &row.CurrentTier)
+	if errors.Is(err, sql.ErrNoRows) {
+		return row, false, nil
+	}
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return row, false, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return row, false, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return row, true, nil
+}
+
+const selectProgressQuery = "SELECT users_id, current_tier FROM progress WHERE users_id = ?;"
+
+// UpdateProgress sets current tier of user, creating progress of user if it does not exist
+func (r *MySQLRepo) UpdateProgress(ctx context.Context, data model.ProgressInfo, userId uint) (sql.Result, error) {
+	result, err := r.DB.ExecContext(ctx, updateProgressQuery, userId, data.CurrentTier)
+	if err != nil {
+		return nil, fmt.Errorf("data has not been updated: %w", err)
+	}
+	return result, nil
+}
+
+// UpdateProgressWithTransaction sets current tier of user as a part of transaction. Transaction is rolled back if data could not be updated.
+func (r *MySQLRepo) UpdateProgressWithTransaction(ctx context.Context, transaction *sql.Tx, data model.ProgressInfo, userId uint) (sql.Result, error) {
+	result, err := transaction.ExecContext(ctx, updateProgressQuery, userId, data.CurrentTier)
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+const updateProgressQuery = "INSERT INTO progress(users_id, current_tier) VALUES (?, ?) ON DUPLICATE KEY UPDATE current_tier = VALUES(current_tier);"
+
+func (r *MySQLRepo) DeleteProgressByUserId(ctx context.Context, transaction *sql.Tx, userId int) (sql.Result, error) {
+	query := "DELETE FROM progress WHERE users_id = " + fmt.Sprint(userId) + ";"
+	result, err := transaction.ExecContext(ctx, query)
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
@@ -41,20 +41,7 @@ func (r *MySQLRepo) SelectRecipesById(ctx context.Context, ids []int, userId int
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve data from db: %w", err)
 	}
-	var resultRows []model.RecipeInfo
-	for result.Next() {
-		var row model.RecipeInfo
-		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.ProductionTimeS, &row.DefaultChoice)
-		if err != nil {
-			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
-		}
-		resultRows = append(resultRows, row)
-	}
-	err = result.Err()
-	if err != nil {
-		return nil, fmt.Errorf("encountered an unexpected error: %w", err)
-	}
-	return resultRows, nil
+	return scanRecipes(result)
 }
 
 func (r *MySQLRepo) SelectRecipes(ctx context.Context, startId int, rowsRet int, userId int) ([]model.RecipeInfo, error) {
@@ -67,16 +54,40 @@ func (r *MySQLRepo) SelectRecipes(ctx context.Context, startId int, rowsRet int,
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve data from db: %w", err)
 	}
+	return scanRecipes(result)
+}
+
+// SelectRecipesWithTransaction returns all recipes of the user as a part of transaction. Transaction is rolled back
+// if data could not be retrieved.
+func (r *MySQLRepo) SelectRecipesWithTransaction(ctx context.Context, transaction *sql.Tx, userId int) ([]model.RecipeInfo, error) {
+	query := "SELECT * FROM recipes WHERE users_id = " + fmt.Sprint(userId) + ";"
+	result, err := transaction.QueryContext(ctx, query)
+	if err == nil {
+		var resultRows []model.RecipeInfo
+		resultRows, err = scanRecipes(result)
+		if err == nil {
+			return resultRows, nil
+		}
+	}
+	rollbackErr := transaction.Rollback()
+	if rollbackErr != nil {
+		return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+	}
+	return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+}
+
+func scanRecipes(result *sql.Rows) ([]model.RecipeInfo, error) {
+	defer result.Close()
 	var resultRows []model.RecipeInfo
 	for result.Next() {
 		var row model.RecipeInfo
-		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.ProductionTimeS, &row.DefaultChoice)
+		err := result.Scan(&row.Id, &row.Name, &row.UsersId, &row.ProductionTimeS, &row.DefaultChoice, &row.UnlockTiersId)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
 		}
 		resultRows = append(resultRows, row)
 	}
-	err = result.Err()
+	err := result.Err()
 	if err != nil {
 		return nil, fmt.Errorf("encountered an unexpected error: %w", err)
 	}
@@ -84,7 +95,38 @@ func (r *MySQLRepo) SelectRecipes(ctx context.Context, startId int, rowsRet int,
 }
 
 func (r *MySQLRepo) InsertRecipes(ctx context.Context, data []model.RecipeInfo, userId uint) (sql.Result, error) {
-	query := "INSERT INTO recipes(name, users_id, production_time_s, default_choice) VALUES"
+	query := insertRecipesQuery(data, userId)
+	result, err := r.DB.ExecContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("data has not been inserted: %w", err)
+	}
+	return result, nil
+}
+
+// InsertRecipeWithTransaction inserts a single recipe as a part of transaction and returns its id.
+// Transaction is rolled back if the recipe could not be inserted.
+func (r *MySQLRepo) InsertRecipeWithTransaction(ctx context.Context, transaction *sql.Tx, data model.RecipeInfo, userId uint) (int64, error) {
+	result, err := transaction.ExecContext(ctx, insertRecipesQuery([]model.RecipeInfo{data}, userId))
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return 0, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return 0, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return 0, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return 0, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return id, nil
+}
+
+func insertRecipesQuery(data []model.RecipeInfo, userId uint) string {
+	query := "INSERT INTO recipes(name, users_id, production_time_s, default_choice, unlock_tiers_id) VALUES"
 	for i, entry := range data {
 		if i != 0 {
 			query += ","
@@ -92,17 +134,36 @@ func (r *MySQLRepo) InsertRecipes(ctx context.Context, data []model.RecipeInfo,
 		query += ` ("` + entry.Name +
 			`", ` + fmt.Sprint(userId) +
 			`, ` + fmt.Sprint(entry.ProductionTimeS) +
-			`, "` + fmt.Sprint(entry.DefaultChoice) + `")`
+			`, "` + fmt.Sprint(entry.DefaultChoice) +
+			`", ` + fmt.Sprint(entry.UnlockTiersId) + `)`
 	}
 	query += ";"
+	return query
+}
+
+func (r *MySQLRepo) DeleteRecipes(ctx context.Context, ids []int, userId int) (sql.Result, error) {
+	query := deleteRecipesQuery(ids, userId)
 	result, err := r.DB.ExecContext(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("data has not been inserted: %w", err)
+		return nil, fmt.Errorf("data has not been deleted: %w", err)
 	}
 	return result, nil
 }
 
-func (r *MySQLRepo) DeleteRecipes(ctx context.Context, ids []int, userId int) (sql.Result, error) {
+// DeleteRecipesWithTransaction deletes recipes as a part of transaction. Transaction is rolled back if data could not be deleted.
+func (r *MySQLRepo) DeleteRecipesWithTransaction(ctx context.Context, transaction *sql.Tx, ids []int, userId int) (sql.Result, error) {
+	result, err := transaction.ExecContext(ctx, deleteRecipesQuery(ids, userId))
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+func deleteRecipesQuery(ids []int, userId int) string {
 	query := "DELETE FROM recipes WHERE id in ("
 	for i, id := range ids {
 		if i != 0 {
@@ -111,11 +172,7 @@ func (r *MySQLRepo) DeleteRecipes(ctx context.Context, ids []int, userId int) (s
 		query += " " + fmt.Sprint(id)
 	}
 	query += ") and users_id = " + fmt.Sprint(userId) + ";"
-	result, err := r.DB.ExecContext(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("data has not been deleted: %w", err)
-	}
-	return result, nil
+	return query
 }
 
 func (r *MySQLRepo) DeleteRecipesByUserId(ctx context.Context, transaction *sql.Tx, userId int) (sql.Result, error) {
@@ -138,8 +195,7 @@ func (r *MySQLRepo) UpdateRecipes(ctx context.Context, data []model.RecipeInfo,
 		return results, fmt.Errorf("data has not been updated: %w", err)
 	}
 	for _, entry := range data {
-		query := fmt.Sprintf("UPDATE recipes SET name='%s', production_time_s=%d, default_choice='%d' WHERE id=%d and users_id=%d;",
-			entry.Name, entry.ProductionTimeS, entry.DefaultChoice, entry.Id, userId)
+		query := updateRecipesQuery(entry, userId)
 		result, err := transaction.ExecContext(ctx, query)
 		results = append(results, result)
 		if err != nil {
@@ -156,3 +212,26 @@ func (r *MySQLRepo) UpdateRecipes(ctx context.Context, data []model.RecipeInfo,
 	}
 	return results, nil
 }
+
+// UpdateRecipesWithTransaction updates recipes as a part of transaction. Transaction is rolled back if data could not be updated.
+func (r *MySQLRepo) UpdateRecipesWithTransaction(ctx context.Context, transaction *sql.Tx, data []model.RecipeInfo, userId uint) ([]sql.Result, error) {
+	results := []sql.Result{}
+	for _, entry := range data {
+		result, err := transaction.ExecContext(ctx, updateRecipesQuery(entry, userId))
+		if err != nil {
+			rollbackErr := transaction.Rollback()
+			if rollbackErr != nil {
+				return results, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+			}
+			return results, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func updateRecipesQuery(entry model.RecipeInfo, userId uint) string {
+	query := fmt.Sprintf("UPDATE recipes SET name='%s', production_time_s=%d, default_choice='%d', unlock_tiers_id=%d WHERE id=%d and users_id=%d;",
+		entry.Name, entry.ProductionTimeS, entry.DefaultChoice, entry.UnlockTiersId, entry.Id, userId)
+	return query
+}
@@ -41,20 +41,7 @@ func (r *MySQLRepo) SelectRecipesInputsById(ctx context.Context, ids []int, user
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve data from db: %w", err)
 	}
-	var resultRows []model.RecipeInputOutputInfo
-	for result.Next() {
-		var row model.RecipeInputOutputInfo
-		err = result.Scan(&row.Id, &row.UsersId, &row.RecipesId, &row.ResourcesId, &row.Amount)
-		if err != nil {
-			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
-		}
-		resultRows = append(resultRows, row)
-	}
-	err = result.Err()
-	if err != nil {
-		return nil, fmt.Errorf("encountered an unexpected error: %w", err)
-	}
-	return resultRows, nil
+	return scanRecipesInputs(result)
 }
 
 func (r *MySQLRepo) SelectRecipesInputs(ctx context.Context, startId int, rowsRet int, userId int) ([]model.RecipeInputOutputInfo, error) {
@@ -67,16 +54,40 @@ func (r *MySQLRepo) SelectRecipesInputs(ctx context.Context, startId int, rowsRe
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve data from db: %w", err)
 	}
+	return scanRecipesInputs(result)
+}
+
+// SelectRecipesInputsWithTransaction returns all recipes inputs of the user as a part of transaction. Transaction is rolled back
+// if data could not be retrieved.
+func (r *MySQLRepo) SelectRecipesInputsWithTransaction(ctx context.Context, transaction *sql.Tx, userId int) ([]model.RecipeInputOutputInfo, error) {
+	query := "SELECT * FROM recipes_inputs WHERE users_id = " + fmt.Sprint(userId) + ";"
+	result, err := transaction.QueryContext(ctx, query)
+	if err == nil {
+		var resultRows []model.RecipeInputOutputInfo
+		resultRows, err = scanRecipesInputs(result)
+		if err == nil {
+			return resultRows, nil
+		}
+	}
+	rollbackErr := transaction.Rollback()
+	if rollbackErr != nil {
+		return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+	}
+	return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+}
+
+func scanRecipesInputs(result *sql.Rows) ([]model.RecipeInputOutputInfo, error) {
+	defer result.Close()
 	var resultRows []model.RecipeInputOutputInfo
 	for result.Next() {
 		var row model.RecipeInputOutputInfo
-		err = result.Scan(&row.Id, &row.UsersId, &row.RecipesId, &row.ResourcesId, &row.Amount)
+		err := result.Scan(&row.Id, &row.UsersId, &row.RecipesId, &row.ResourcesId, &row.Amount)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
 		}
 		resultRows = append(resultRows, row)
 	}
-	err = result.Err()
+	err := result.Err()
 	if err != nil {
 		return nil, fmt.Errorf("encountered an unexpected error: %w", err)
 	}
@@ -84,6 +95,28 @@ func (r *MySQLRepo) SelectRecipesInputs(ctx context.Context, startId int, rowsRe
 }
 
 func (r *MySQLRepo) InsertRecipesInputs(ctx context.Context, data []model.RecipeInputOutputInfo, userId uint) (sql.Result, error) {
+	query := insertRecipesInputsQuery(data, userId)
+	result, err := r.DB.ExecContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("data has not been inserted: %w", err)
+	}
+	return result, nil
+}
+
+// InsertRecipesInputsWithTransaction inserts recipes inputs as a part of transaction. Transaction is rolled back if data could not be inserted.
+func (r *MySQLRepo) InsertRecipesInputsWithTransaction(ctx context.Context, transaction *sql.Tx, data []model.RecipeInputOutputInfo, userId uint) (sql.Result, error) {
+	result, err := transaction.ExecContext(ctx, insertRecipesInputsQuery(data, userId))
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+func insertRecipesInputsQuery(data []model.RecipeInputOutputInfo, userId uint) string {
 	query := "INSERT INTO recipes_inputs(users_id, recipes_id, resources_id, amount) VALUES"
 	for i, entry := range data {
 		if i != 0 {
@@ -95,14 +128,32 @@ func (r *MySQLRepo) InsertRecipesInputs(ctx context.Context, data []model.Recipe
 			`, "` + fmt.Sprint(entry.Amount) + `")`
 	}
 	query += ";"
+	return query
+}
+
+func (r *MySQLRepo) DeleteRecipesInputs(ctx context.Context, ids []int, userId int) (sql.Result, error) {
+	query := deleteRecipesInputsQuery(ids, userId)
 	result, err := r.DB.ExecContext(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("data has not been inserted: %w", err)
+		return nil, fmt.Errorf("data has not been deleted: %w", err)
 	}
 	return result, nil
 }
 
-func (r *MySQLRepo) DeleteRecipesInputs(ctx context.Context, ids []int, userId int) (sql.Result, error) {
+// DeleteRecipesInputsWithTransaction deletes recipes inputs as a part of transaction. Transaction is rolled back if data could not be deleted.
+func (r *MySQLRepo) DeleteRecipesInputsWithTransaction(ctx context.Context, transaction *sql.Tx, ids []int, userId int) (sql.Result, error) {
+	result, err := transaction.ExecContext(ctx, deleteRecipesInputsQuery(ids, userId))
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+func deleteRecipesInputsQuery(ids []int, userId int) string {
 	query := "DELETE FROM recipes_inputs WHERE id in ("
 	for i, id := range ids {
 		if i != 0 {
@@ -111,11 +162,7 @@ func (r *MySQLRepo) DeleteRecipesInputs(ctx context.Context, ids []int, userId i
 		query += " " + fmt.Sprint(id)
 	}
 	query += ") and users_id = " + fmt.Sprint(userId) + ";"
-	result, err := r.DB.ExecContext(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("data has not been deleted: %w", err)
-	}
-	return result, nil
+	return query
 }
 
 func (r *MySQLRepo) DeleteRecipesInputsByUserId(ctx context.Context, transaction *sql.Tx, userId int) (sql.Result, error) {
@@ -138,8 +185,7 @@ func (r *MySQLRepo) UpdateRecipesInputs(ctx context.Context, data []model.Recipe
 		return results, fmt.Errorf("data has not been updated: %w", err)
 	}
 	for _, entry := range data {
-		query := fmt.Sprintf("UPDATE recipes_inputs SET recipes_id='%d', resources_id=%d, amount='%d' WHERE id=%d and users_id=%d;",
-			entry.RecipesId, entry.ResourcesId, entry.Amount, entry.Id, userId)
+		query := updateRecipesInputsQuery(entry, userId)
 		result, err := transaction.ExecContext(ctx, query)
 		results = append(results, result)
 		if err != nil {
@@ -156,3 +202,26 @@ func (r *MySQLRepo) UpdateRecipesInputs(ctx context.Context, data []model.Recipe
 	}
 	return results, nil
 }
+
+// UpdateRecipesInputsWithTransaction updates recipes inputs as a part of transaction. Transaction is rolled back if data could not be updated.
+func (r *MySQLRepo) UpdateRecipesInputsWithTransaction(ctx context.Context, transaction *sql.Tx, data []model.RecipeInputOutputInfo, userId uint) ([]sql.Result, error) {
+	results := []sql.Result{}
+	for _, entry := range data {
+		result, err := transaction.ExecContext(ctx, updateRecipesInputsQuery(entry, userId))
+		if err != nil {
+			rollbackErr := transaction.Rollback()
+			if rollbackErr != nil {
+				return results, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+			}
+			return results, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func updateRecipesInputsQuery(entry model.RecipeInputOutputInfo, userId uint) string {
+	query := fmt.Sprintf("UPDATE recipes_inputs SET recipes_id='%d', resources_id=%d, amount='%d' WHERE id=%d and users_id=%d;",
+		entry.RecipesId, entry.ResourcesId, entry.Amount, entry.Id, userId)
+	return query
+}
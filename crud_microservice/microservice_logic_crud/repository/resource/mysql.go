@@ -41,20 +41,7 @@ func (r *MySQLRepo) SelectResourcesById(ctx context.Context, ids []int, userId i
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve data from db: %w", err)
 	}
-	var resultRows []model.ResourceInfo
-	for result.Next() {
-		var row model.ResourceInfo
-		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.Liquid, &row.ResourceUnit)
-		if err != nil {
-			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
-		}
-		resultRows = append(resultRows, row)
-	}
-	err = result.Err()
-	if err != nil {
-		return nil, fmt.Errorf("encountered an unexpected error: %w", err)
-	}
-	return resultRows, nil
+	return scanResources(result)
 }
 
 func (r *MySQLRepo) SelectResources(ctx context.Context, startId int, rowsRet int, userId int) ([]model.ResourceInfo, error) {
@@ -67,16 +54,40 @@ func (r *MySQLRepo) SelectResources(ctx context.Context, startId int, rowsRet in
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve data from db: %w", err)
 	}
+	return scanResources(result)
+}
+
+// SelectResourcesWithTransaction returns all resources of the user as a part of transaction. Transaction is rolled back
+// if data could not be retrieved.
+func (r *MySQLRepo) SelectResourcesWithTransaction(ctx context.Context, transaction *sql.Tx, userId int) ([]model.ResourceInfo, error) {
+	query := "SELECT * FROM resources WHERE users_id = " + fmt.Sprint(userId) + ";"
+	result, err := transaction.QueryContext(ctx, query)
+	if err == nil {
+		var resultRows []model.ResourceInfo
+		resultRows, err = scanResources(result)
+		if err == nil {
+			return resultRows, nil
+		}
+	}
+	rollbackErr := transaction.Rollback()
+	if rollbackErr != nil {
+		return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+	}
+	return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+}
+
+func scanResources(result *sql.Rows) ([]model.ResourceInfo, error) {
+	defer result.Close()
 	var resultRows []model.ResourceInfo
 	for result.Next() {
 		var row model.ResourceInfo
-		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.Liquid, &row.ResourceUnit)
+		err := result.Scan(&row.Id, &row.Name, &row.UsersId, &row.Liquid, &row.ResourceUnit, &row.SinkValue)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
 		}
 		resultRows = append(resultRows, row)
 	}
-	err = result.Err()
+	err := result.Err()
 	if err != nil {
 		return nil, fmt.Errorf("encountered an unexpected error: %w", err)
 	}
@@ -84,7 +95,38 @@ func (r *MySQLRepo) SelectResources(ctx context.Context, startId int, rowsRet in
 }
 
 func (r *MySQLRepo) InsertResources(ctx context.Context, data []model.ResourceInfo, userId uint) (sql.Result, error) {
-	query := "INSERT INTO resources(name, users_id, liquid, resource_unit) VALUES"
+	query := insertResourcesQuery(data, userId)
+	result, err := r.DB.ExecContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("data has not been inserted: %w", err)
+	}
+	return result, nil
+}
+
+// InsertResourceWithTransaction inserts a single resource as a part of transaction and returns its id.
+// Transaction is rolled back if the resource could not be inserted.
+func (r *MySQLRepo) InsertResourceWithTransaction(ctx context.Context, transaction *sql.Tx, data model.ResourceInfo, userId uint) (int64, error) {
+	result, err := transaction.ExecContext(ctx, insertResourcesQuery([]model.ResourceInfo{data}, userId))
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return 0, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return 0, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return 0, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return 0, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return id, nil
+}
+
+func insertResourcesQuery(data []model.ResourceInfo, userId uint) string {
+	query := "INSERT INTO resources(name, users_id, liquid, resource_unit, sink_value) VALUES"
 	for i, entry := range data {
 		if i != 0 {
 			query += ","
@@ -92,17 +134,36 @@ func (r *MySQLRepo) InsertResources(ctx context.Context, data []model.ResourceIn
 		query += ` ("` + entry.Name +
 			`", ` + fmt.Sprint(userId) +
 			`, ` + fmt.Sprint(entry.Liquid) +
-			`, "` + fmt.Sprint(entry.ResourceUnit) + `")`
+			`, "` + fmt.Sprint(entry.ResourceUnit) +
+			`", ` + fmt.Sprint(entry.SinkValue) + `)`
 	}
 	query += ";"
+	return query
+}
+
+func (r *MySQLRepo) DeleteResources(ctx context.Context, ids []int, userId int) (sql.Result, error) {
+	query := deleteResourcesQuery(ids, userId)
 	result, err := r.DB.ExecContext(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("data has not been inserted: %w", err)
+		return nil, fmt.Errorf("data has not been deleted: %w", err)
 	}
 	return result, nil
 }
 
-func (r *MySQLRepo) DeleteResources(ctx context.Context, ids []int, userId int) (sql.Result, error) {
+// DeleteResourcesWithTransaction deletes resources as a part of transaction. Transaction is rolled back if data could not be deleted.
+func (r *MySQLRepo) DeleteResourcesWithTransaction(ctx context.Context, transaction *sql.Tx, ids []int, userId int) (sql.Result, error) {
+	result, err := transaction.ExecContext(ctx, deleteResourcesQuery(ids, userId))
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+func deleteResourcesQuery(ids []int, userId int) string {
 	query := "DELETE FROM resources WHERE id in ("
 	for i, id := range ids {
 		if i != 0 {
@@ -111,11 +172,7 @@ func (r *MySQLRepo) DeleteResources(ctx context.Context, ids []int, userId int)
 		query += " " + fmt.Sprint(id)
 	}
 	query += ") and users_id = " + fmt.Sprint(userId) + ";"
-	result, err := r.DB.ExecContext(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("data has not been deleted: %w", err)
-	}
-	return result, nil
+	return query
 }
 
 func (r *MySQLRepo) DeleteResourcesByUserId(ctx context.Context, transaction *sql.Tx, userId int) (sql.Result, error) {
@@ -138,8 +195,7 @@ func (r *MySQLRepo) UpdateResources(ctx context.Context, data []model.ResourceIn
 		return results, fmt.Errorf("data has not been updated: %w", err)
 	}
 	for _, entry := range data {
-		query := fmt.Sprintf("UPDATE resources SET name='%s', liquid=%d, resource_unit='%s' WHERE id=%d and users_id=%d;",
-			entry.Name, entry.Liquid, entry.ResourceUnit, entry.Id, userId)
+		query := updateResourcesQuery(entry, userId)
 		result, err := transaction.ExecContext(ctx, query)
 		results = append(results, result)
 		if err != nil {
@@ -156,3 +212,26 @@ func (r *MySQLRepo) UpdateResources(ctx context.Context, data []model.ResourceIn
 	}
 	return results, nil
 }
+
+// UpdateResourcesWithTransaction updates resources as a part of transaction. Transaction is rolled back if data could not be updated.
+func (r *MySQLRepo) UpdateResourcesWithTransaction(ctx context.Context, transaction *sql.Tx, data []model.ResourceInfo, userId uint) ([]sql.Result, error) {
+	results := []sql.Result{}
+	for _, entry := range data {
+		result, err := transaction.ExecContext(ctx, updateResourcesQuery(entry, userId))
+		if err != nil {
+			rollbackErr := transaction.Rollback()
+			if rollbackErr != nil {
+				return results, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+			}
+			return results, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func updateResourcesQuery(entry model.ResourceInfo, userId uint) string {
+	query := fmt.Sprintf("UPDATE resources SET name='%s', liquid=%d, resource_unit='%s', sink_value=%f WHERE id=%d and users_id=%d;",
+		entry.Name, entry.Liquid, entry.ResourceUnit, entry.SinkValue, entry.Id, userId)
+	return query
+}
@@ -0,0 +1,248 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package unlocktier
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+)
+
+type MySQLRepo struct {
+	DB *sql.DB
+}
+
+func (r *MySQLRepo) SelectUnlockTiersById(ctx context.Context, ids []int, userId int) ([]model.UnlockTierInfo, error) {
+	query := "SELECT * FROM unlock_tiers WHERE id in ("
+	for i, id := range ids {
+		if i != 0 {
+			query += ","
+		}
+		query += " " + fmt.Sprint(id)
+	}
+	query += ") AND users_id = " + fmt.Sprint(userId) + ";"
+	result, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve data from db: %w", err)
+	}
+	return scanUnlockTiers(result)
+}
+
+func (r *MySQLRepo) SelectUnlockTiers(ctx context.Context, startId int, rowsRet int, userId int) ([]model.UnlockTierInfo, error) {
+	query := "SELECT * FROM unlock_tiers WHERE id >= " + fmt.Sprint(startId) + " AND users_id = " + fmt.Sprint(userId)
+	if rowsRet > 0 {
+		query += " LIMIT " + fmt.Sprint(rowsRet)
+	}
+	query += ";"
+	result, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve data from db: %w", err)
+	}
+	return scanUnlockTiers(result)
+}
+
+// SelectUnlockTiersWithTransaction returns all unlock tiers of the user as a part of transaction. Transaction is rolled back
+// if data could not be retrieved.
+func (r *MySQLRepo) SelectUnlockTiersWithTransaction(ctx context.Context, transaction *sql.Tx, userId int) ([]model.UnlockTierInfo, error) {
+	query := "SELECT * FROM unlock_tiers WHERE users_id = " + fmt.Sprint(userId) + ";"
+	result, err := transaction.QueryContext(ctx, query)
+	if err == nil {
+		var resultRows []model.UnlockTierInfo
+		resultRows, err = scanUnlockTiers(result)
+		if err == nil {
+			return resultRows, nil
+		}
+	}
+	rollbackErr := transaction.Rollback()
+	if rollbackErr != nil {
+		return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+	}
+	return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+}
+
+func scanUnlockTiers(result *sql.Rows) ([]model.UnlockTierInfo, error) {
+	defer result.Close()
+	var resultRows []model.UnlockTierInfo
+	for result.Next() {
+		var row model.UnlockTierInfo
+		err := result.Scan(&row.Id, &row.Name, &row.UsersId, &row.TierNumber)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
+		}
+		resultRows = append(resultRows, row)
+	}
+	err := result.Err()
+	if err != nil {
+		return nil, fmt.Errorf("encountered an unexpected error: %w", err)
+	}
+	return resultRows, nil
+}
+
+func (r *MySQLRepo) InsertUnlockTiers(ctx context.Context, data []model.UnlockTierInfo, userId uint) (sql.Result, error) {
+	query := insertUnlockTiersQuery(data, userId)
+	result, err := r.DB.ExecContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("data has not been inserted: %w", err)
+	}
+	return result, nil
+}
+
+// InsertUnlockTiersWithTransaction inserts unlock tiers as a part of transaction. Transaction is rolled back if data could not be inserted.
+func (r *MySQLRepo) InsertUnlockTiersWithTransaction(ctx context.Context, transaction *sql.Tx, data []model.UnlockTierInfo, userId uint) (sql.Result, error) {
+	result, err := transaction.ExecContext(ctx, insertUnlockTiersQuery(data, userId))
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+// InsertUnlockTierWithTransaction inserts a single unlock tier as a part of transaction and returns its id.
+// Transaction is rolled back if the unlock tier could not be inserted.
+func (r *MySQLRepo) InsertUnlockTierWithTransaction(ctx context.Context, transaction *sql.Tx, data model.UnlockTierInfo, userId uint) (int64, error) {
+	result, err := transaction.ExecContext(ctx, insertUnlockTiersQuery([]model.UnlockTierInfo{data}, userId))
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return 0, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return 0, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return 0, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return 0, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return id, nil
+}
+
+func insertUnlockTiersQuery(data []model.UnlockTierInfo, userId uint) string {
+	query := "INSERT INTO unlock_tiers(name, users_id, tier_number) VALUES"
+	for i, entry := range data {
+		if i != 0 {
+			query += ","
+		}
+		query += ` ("` + entry.Name +
+			`", ` + fmt.Sprint(userId) +
+			`, ` + fmt.Sprint(entry.TierNumber) + `)`
+	}
+	query += ";"
+	return query
+}
+
+func (r *MySQLRepo) DeleteUnlockTiers(ctx context.Context, ids []int, userId int) (sql.Result, error) {
+	query := deleteUnlockTiersQuery(ids, userId)
+	result, err := r.DB.ExecContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("data has not been deleted: %w", err)
+	}
+	return result, nil
+}
+
+// DeleteUnlockTiersWithTransaction deletes unlock tiers as a part of transaction. Transaction is rolled back if data could not be deleted.
+func (r *MySQLRepo) DeleteUnlockTiersWithTransaction(ctx context.Context, transaction *sql.Tx, ids []int, userId int) (sql.Result, error) {
+	result, err := transaction.ExecContext(ctx, deleteUnlockTiersQuery(ids, userId))
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+func deleteUnlockTiersQuery(ids []int, userId int) string {
+	query := "DELETE FROM unlock_tiers WHERE id in ("
+	for i, id := range ids {
+		if i != 0 {
+			query += ","
+		}
+		query += " " + fmt.Sprint(id)
+	}
+	query += ") and users_id = " + fmt.Sprint(userId) + ";"
+	return query
+}
+
+func (r *MySQLRepo) DeleteUnlockTiersByUserId(ctx context.Context, transaction *sql.Tx, userId int) (sql.Result, error) {
+	query := "DELETE FROM unlock_tiers WHERE users_id = " + fmt.Sprint(userId) + ";"
+	result, err := transaction.ExecContext(ctx, query)
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			return nil, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+		}
+		return nil, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+	}
+	return result, nil
+}
+
+func (r *MySQLRepo) UpdateUnlockTiers(ctx context.Context, data []model.UnlockTierInfo, userId uint) ([]sql.Result, error) {
+	results := []sql.Result{}
+	transaction, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return results, fmt.Errorf("data has not been updated: %w", err)
+	}
+	for _, entry := range data {
+		query := updateUnlockTiersQuery(entry, userId)
+		result, err := transaction.ExecContext(ctx, query)
+		results = append(results, result)
+		if err != nil {
+			rollbackErr := transaction.Rollback()
+			if rollbackErr != nil {
+				return results, fmt.Errorf("could not rollback transaction: %w", rollbackErr)
+			}
+			return results, fmt.Errorf("data has not been updated: %w", err)
+		}
+	}
+	err = transaction.Commit()
+	if err != nil {
+		return results, fmt.Errorf("data has not been updated: %w", err)
+	}
+	return results, nil
+}
+
+// UpdateUnlockTiersWithTransaction updates unlock tiers as a part of transaction. Transaction is rolled back if data could not be updated.
+func (r *MySQLRepo) UpdateUnlockTiersWithTransaction(ctx context.Context, transaction *sql.Tx, data []model.UnlockTierInfo, userId uint) ([]sql.Result, error) {
+	results := []sql.Result{}
+	for _, entry := range data {
+		result, err := transaction.ExecContext(ctx, updateUnlockTiersQuery(entry, userId))
+		if err != nil {
+			rollbackErr := transaction.Rollback()
+			if rollbackErr != nil {
+				return results, fmt.Errorf("could not rollback changes: %w", rollbackErr)
+			}
+			return results, fmt.Errorf("an error occurred, transaction has been rolled back: %w", err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func updateUnlockTiersQuery(entry model.UnlockTierInfo, userId uint) string {
+	query := fmt.Sprintf("UPDATE unlock_tiers SET name='%s', tier_number=%d WHERE id=%d and users_id=%d;",
+		entry.Name, entry.TierNumber, entry.Id, userId)
+	return query
+}
@@ -32,7 +32,10 @@ type MachineInfo struct {
 	OutputsLiquid      uint
 	Speed              float32
 	PowerConsumptionKw uint
+	PowerClockExponent float32
+	PowerGenerationKw  uint
 	DefaultChoice      uint8
+	UnlockTiersId      uint
 }
 
 type ResourceInfo struct {
@@ -41,6 +44,7 @@ type ResourceInfo struct {
 	UsersId      uint
 	Liquid       uint8
 	ResourceUnit string
+	SinkValue    float32
 }
 
 type RecipeInfo struct {
@@ -49,6 +53,7 @@ type RecipeInfo struct {
 	UsersId         uint
 	ProductionTimeS uint
 	DefaultChoice   uint8
+	UnlockTiersId   uint
 }
 
 type RecipeInputOutputInfo struct {
@@ -93,7 +98,7 @@ func SelectMachinesById(ctx context.Context, db *sql.DB, ids []int, userId int)
 	var resultRows []MachineInfo
 	for result.Next() {
 		var row MachineInfo
-		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.InputsSolid, &row.InputsLiquid, &row.OutputsSolid, &row.OutputsLiquid, &row.Speed, &row.PowerConsumptionKw, &row.DefaultChoice)
+		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.InputsSolid, &row.InputsLiquid, &row.OutputsSolid, &row.OutputsLiquid, &row.Speed, &row.PowerConsumptionKw, &row.PowerClockExponent, &row.PowerGenerationKw, &row.DefaultChoice, &row.UnlockTiersId)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
 		}
@@ -119,7 +124,7 @@ func SelectMachines(ctx context.Context, db *sql.DB, startId int, rowsRet int, u
 	var resultRows []MachineInfo
 	for result.Next() {
 		var row MachineInfo
-		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.InputsSolid, &row.InputsLiquid, &row.OutputsSolid, &row.OutputsLiquid, &row.Speed, &row.PowerConsumptionKw, &row.DefaultChoice)
+		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.InputsSolid, &row.InputsLiquid, &row.OutputsSolid, &row.OutputsLiquid, &row.Speed, &row.PowerConsumptionKw, &row.PowerClockExponent, &row.PowerGenerationKw, &row.DefaultChoice, &row.UnlockTiersId)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
 		}
@@ -133,7 +138,7 @@ func SelectMachines(ctx context.Context, db *sql.DB, startId int, rowsRet int, u
 }
 
 func InsertMachines(ctx context.Context, db *sql.DB, data []MachineInfo) (sql.Result, error) {
-	query := "INSERT INTO machines(name, users_id, inputs_solid, inputs_liquid, outputs_solid, outputs_liquid, speed, power_consumption_kw, default_choice) VALUES"
+	query := "INSERT INTO machines(name, users_id, inputs_solid, inputs_liquid, outputs_solid, outputs_liquid, speed, power_consumption_kw, power_clock_exponent, power_generation_kw, default_choice, unlock_tiers_id) VALUES"
 	for i, entry := range data {
 		if i != 0 {
 			query += ","
@@ -146,7 +151,10 @@ func InsertMachines(ctx context.Context, db *sql.DB, data []MachineInfo) (sql.Re
 			`, ` + fmt.Sprint(entry.OutputsLiquid) +
 			`, ` + fmt.Sprint(entry.Speed) +
 			`, ` + fmt.Sprint(entry.PowerConsumptionKw) +
-			`, ` + fmt.Sprint(entry.DefaultChoice) + `)`
+			`, ` + fmt.Sprint(entry.PowerClockExponent) +
+			`, ` + fmt.Sprint(entry.PowerGenerationKw) +
+			`, ` + fmt.Sprint(entry.DefaultChoice) +
+			`, ` + fmt.Sprint(entry.UnlockTiersId) + `)`
 	}
 	query += ";"
 	result, err := db.ExecContext(ctx, query)
@@ -175,8 +183,8 @@ func DeleteMachines(ctx context.Context, db *sql.DB, ids []int, userId int) (sql
 func UpdateMachines(ctx context.Context, db *sql.DB, data []MachineInfo) ([]sql.Result, error) {
 	results := []sql.Result{}
 	for _, entry := range data {
-		query := fmt.Sprintf("UPDATE machines SET name='%s', inputs_solid=%d, inputs_liquid=%d, outputs_solid=%d, outputs_liquid=%d, speed=%f, power_consumption_kw=%d, default_choice=%d WHERE id=%d and users_id=%d;",
-			entry.Name, entry.InputsSolid, entry.InputsLiquid, entry.OutputsSolid, entry.OutputsLiquid, entry.Speed, entry.PowerConsumptionKw, entry.DefaultChoice, entry.Id, entry.UsersId)
+		query := fmt.Sprintf("UPDATE machines SET name='%s', inputs_solid=%d, inputs_liquid=%d, outputs_solid=%d, outputs_liquid=%d, speed=%f, power_consumption_kw=%d, power_clock_exponent=%f, power_generation_kw=%d, default_choice=%d, unlock_tiers_id=%d WHERE id=%d and users_id=%d;",
+			entry.Name, entry.InputsSolid, entry.InputsLiquid, entry.OutputsSolid, entry.OutputsLiquid, entry.Speed, entry.PowerConsumptionKw, entry.PowerClockExponent, entry.PowerGenerationKw, entry.DefaultChoice, entry.UnlockTiersId, entry.Id, entry.UsersId)
 		result, err := db.ExecContext(ctx, query)
 		results = append(results, result)
 		if err != nil {
@@ -202,7 +210,7 @@ func SelectResourcesById(ctx context.Context, db *sql.DB, ids []int, userId int)
 	var resultRows []ResourceInfo
 	for result.Next() {
 		var row ResourceInfo
-		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.Liquid, &row.ResourceUnit)
+		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.Liquid, &row.ResourceUnit, &row.SinkValue)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
 		}
@@ -228,7 +236,7 @@ func SelectResources(ctx context.Context, db *sql.DB, startId int, rowsRet int,
 	var resultRows []ResourceInfo
 	for result.Next() {
 		var row ResourceInfo
-		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.Liquid, &row.ResourceUnit)
+		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.Liquid, &row.ResourceUnit, &row.SinkValue)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
 		}
@@ -242,7 +250,7 @@ func SelectResources(ctx context.Context, db *sql.DB, startId int, rowsRet int,
 }
 
 func InsertResources(ctx context.Context, db *sql.DB, data []ResourceInfo) (sql.Result, error) {
-	query := "INSERT INTO resources(name, users_id, liquid, resource_unit) VALUES"
+	query := "INSERT INTO resources(name, users_id, liquid, resource_unit, sink_value) VALUES"
 	for i, entry := range data {
 		if i != 0 {
 			query += ","
@@ -250,7 +258,8 @@ func InsertResources(ctx context.Context, db *sql.DB, data []ResourceInfo) (sql.
 		query += ` ("` + entry.Name +
 			`", ` + fmt.Sprint(entry.UsersId) +
 			`, ` + fmt.Sprint(entry.Liquid) +
-			`, "` + fmt.Sprint(entry.ResourceUnit) + `")`
+			`, "` + fmt.Sprint(entry.ResourceUnit) +
+			`", ` + fmt.Sprint(entry.SinkValue) + `)`
 	}
 	query += ";"
 	result, err := db.ExecContext(ctx, query)
@@ -279,8 +288,8 @@ func DeleteResources(ctx context.Context, db *sql.DB, ids []int, userId int) (sq
 func UpdateResources(ctx context.Context, db *sql.DB, data []ResourceInfo) ([]sql.Result, error) {
 	results := []sql.Result{}
 	for _, entry := range data {
-		query := fmt.Sprintf("UPDATE resources SET name='%s', liquid=%d, resource_unit='%s' WHERE id=%d and users_id=%d;",
-			entry.Name, entry.Liquid, entry.ResourceUnit, entry.Id, entry.UsersId)
+		query := fmt.Sprintf("UPDATE resources SET name='%s', liquid=%d, resource_unit='%s', sink_value=%f WHERE id=%d and users_id=%d;",
+			entry.Name, entry.Liquid, entry.ResourceUnit, entry.SinkValue, entry.Id, entry.UsersId)
 		result, err := db.ExecContext(ctx, query)
 		results = append(results, result)
 		if err != nil {
@@ -306,7 +315,7 @@ func SelectRecipesById(ctx context.Context, db *sql.DB, ids []int, userId int) (
 	var resultRows []RecipeInfo
 	for result.Next() {
 		var row RecipeInfo
-		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.ProductionTimeS, &row.DefaultChoice)
+		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.ProductionTimeS, &row.DefaultChoice, &row.UnlockTiersId)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
 		}
@@ -332,7 +341,7 @@ func SelectRecipes(ctx context.Context, db *sql.DB, startId int, rowsRet int, us
 	var resultRows []RecipeInfo
 	for result.Next() {
 		var row RecipeInfo
-		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.ProductionTimeS, &row.DefaultChoice)
+		err = result.Scan(&row.Id, &row.Name, &row.UsersId, &row.ProductionTimeS, &row.DefaultChoice, &row.UnlockTiersId)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse data retrieved from db: %w", err)
 		}
@@ -346,7 +355,7 @@ func SelectRecipes(ctx context.Context, db *sql.DB, startId int, rowsRet int, us
 }
 
 func InsertRecipes(ctx context.Context, db *sql.DB, data []RecipeInfo) (sql.Result, error) {
-	query := "INSERT INTO recipes(name, users_id, production_time_s, default_choice) VALUES"
+	query := "INSERT INTO recipes(name, users_id, production_time_s, default_choice, unlock_tiers_id) VALUES"
 	for i, entry := range data {
 		if i != 0 {
 			query += ","
@@ -354,7 +363,8 @@ func InsertRecipes(ctx context.Context, db *sql.DB, data []RecipeInfo) (sql.Resu
 		query += ` ("` + entry.Name +
 			`", ` + fmt.Sprint(entry.UsersId) +
 			`, ` + fmt.Sprint(entry.ProductionTimeS) +
-			`, "` + fmt.Sprint(entry.DefaultChoice) + `")`
+			`, "` + fmt.Sprint(entry.DefaultChoice) +
+			`", ` + fmt.Sprint(entry.UnlockTiersId) + `)`
 	}
 	query += ";"
 	result, err := db.ExecContext(ctx, query)
@@ -383,8 +393,8 @@ func DeleteRecipes(ctx context.Context, db *sql.DB, ids []int, userId int) (sql.
 func UpdateRecipes(ctx context.Context, db *sql.DB, data []RecipeInfo) ([]sql.Result, error) {
 	results := []sql.Result{}
 	for _, entry := range data {
-		query := fmt.Sprintf("UPDATE recipes SET name='%s', production_time_s=%d, default_choice='%d' WHERE id=%d and users_id=%d;",
-			entry.Name, entry.ProductionTimeS, entry.DefaultChoice, entry.Id, entry.UsersId)
+		query := fmt.Sprintf("UPDATE recipes SET name='%s', production_time_s=%d, default_choice='%d', unlock_tiers_id=%d WHERE id=%d and users_id=%d;",
+			entry.Name, entry.ProductionTimeS, entry.DefaultChoice, entry.UnlockTiersId, entry.Id, entry.UsersId)
 		result, err := db.ExecContext(ctx, query)
 		results = append(results, result)
 		if err != nil {
@@ -0,0 +1,120 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/bundle"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type BundleTestSuite struct {
+	suite.Suite
+	dataset bundle.Dataset
+}
+
+func TestBundleTestSuite(t *testing.T) {
+	suite.Run(t, &BundleTestSuite{})
+}
+
+func (bts *BundleTestSuite) SetupTest() {
+	bts.dataset = bundle.Dataset{
+		UnlockTiers:    []model.UnlockTierInfo{{Id: 3, Name: "tier_1", UsersId: 1, TierNumber: 1}},
+		TransportTiers: []model.TransportTierInfo{{Id: 2, Name: "belt_mk1", UsersId: 1, CapacityPerS: 1}},
+		Resources: []model.ResourceInfo{
+			{Id: 5, Name: "iron_ore", UsersId: 1, SinkValue: 1},
+			{Id: 6, Name: "iron_ingot", UsersId: 1, SinkValue: 2},
+		},
+		Machines: []model.MachineInfo{
+			{Id: 7, Name: "miner", UsersId: 1, OutputsSolid: 1, Speed: 1},
+			{Id: 8, Name: "smelter", UsersId: 1, InputsSolid: 1, OutputsSolid: 1, Speed: 1, UnlockTiersId: 3},
+		},
+		Recipes:         []model.RecipeInfo{{Id: 9, Name: "iron_ingot", UsersId: 1, ProductionTimeS: 2, DefaultChoice: 1, UnlockTiersId: 3}},
+		RecipesInputs:   []model.RecipeInputOutputInfo{{Id: 1, UsersId: 1, RecipesId: 9, ResourcesId: 5, Amount: 1}},
+		RecipesOutputs:  []model.RecipeInputOutputInfo{{Id: 1, UsersId: 1, RecipesId: 9, ResourcesId: 6, Amount: 1}, {Id: 2, UsersId: 1, RecipesId: 10, ResourcesId: 6, Amount: 1}},
+		MachinesRecipes: []model.MachinesRecipesInfo{{Id: 1, UsersId: 1, RecipesId: 9, MachinesId: 8}},
+		MachinesCosts:   []model.MachineCostInfo{{Id: 1, UsersId: 1, MachinesId: 8, ResourcesId: 6, Amount: 5}},
+		Plans:           []model.PlanInfo{{Id: 4, Name: "ingots", UsersId: 1, Targets: []model.PlanTarget{{Resource: "iron_ingot", Rate: 1}}}},
+		Progress:        &model.ProgressInfo{UsersId: 1, CurrentTier: 2},
+	}
+}
+
+func (bts *BundleTestSuite) TestFromDataset() {
+	currentTier := uint(2)
+	expected := bundle.Bundle{
+		Version:        bundle.Version,
+		UnlockTiers:    []bundle.UnlockTier{{Name: "tier_1", TierNumber: 1}},
+		TransportTiers: []bundle.TransportTier{{Name: "belt_mk1", CapacityPerS: 1}},
+		Resources: []bundle.Resource{
+			{Name: "iron_ore", SinkValue: 1},
+			{Name: "iron_ingot", SinkValue: 2},
+		},
+		Machines: []bundle.Machine{
+			{Name: "miner", OutputsSolid: 1, Speed: 1, BuildCost: []bundle.Amount{}},
+			{Name: "smelter", InputsSolid: 1, OutputsSolid: 1, Speed: 1, UnlockTier: "tier_1", BuildCost: []bundle.Amount{{Resource: "iron_ingot", Amount: 5}}},
+		},
+		Recipes: []bundle.Recipe{{
+			Name:            "iron_ingot",
+			ProductionTimeS: 2,
+			DefaultChoice:   1,
+			UnlockTier:      "tier_1",
+			Inputs:          []bundle.Amount{{Resource: "iron_ore", Amount: 1}},
+			Outputs:         []bundle.Amount{{Resource: "iron_ingot", Amount: 1}},
+			Machines:        []string{"smelter"},
+		}},
+		Plans:       []bundle.Plan{{Name: "ingots", Targets: []model.PlanTarget{{Resource: "iron_ingot", Rate: 1}}}},
+		CurrentTier: &currentTier,
+	}
+	result := bundle.FromDataset(bts.dataset)
+	bts.Equal(expected, result)
+	bts.NoError(result.Validate())
+}
+
+func (bts *BundleTestSuite) TestFromEmptyDataset() {
+	result := bundle.FromDataset(bundle.Dataset{})
+	bts.Nil(result.CurrentTier)
+	bts.Empty(result.Machines)
+	bts.NoError(result.Validate())
+}
+
+func (bts *BundleTestSuite) TestValidateVersion() {
+	result := bundle.FromDataset(bts.dataset)
+	result.Version = bundle.Version + 1
+	bts.ErrorContains(result.Validate(), "not supported")
+}
+
+func (bts *BundleTestSuite) TestValidateDuplicateName() {
+	result := bundle.FromDataset(bts.dataset)
+	result.Resources = append(result.Resources, bundle.Resource{Name: "iron_ore"})
+	bts.ErrorContains(result.Validate(), "resource name 'iron_ore' is not unique")
+}
+
+func (bts *BundleTestSuite) TestValidateUnknownReferences() {
+	result := bundle.FromDataset(bts.dataset)
+	result.Recipes[0].Machines = append(result.Recipes[0].Machines, "constructor")
+	bts.ErrorContains(result.Validate(), "unknown machine 'constructor'")
+
+	result = bundle.FromDataset(bts.dataset)
+	result.Machines[0].BuildCost = append(result.Machines[0].BuildCost, bundle.Amount{Resource: "copper_ore", Amount: 1})
+	bts.ErrorContains(result.Validate(), "unknown resource 'copper_ore'")
+
+	result = bundle.FromDataset(bts.dataset)
+	result.Recipes[0].UnlockTier = "tier_2"
+	bts.ErrorContains(result.Validate(), "unknown unlock tier 'tier_2'")
+}
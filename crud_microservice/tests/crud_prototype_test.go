@@ -66,8 +66,8 @@ func (uts *UnitTestSuite) TearDownTest() {
 func (uts *UnitTestSuite) TestSelectMachinesById() {
 
 	expectedRows := []prototypes.MachineInfo{
-		{Id: 1, Name: "harvester_mk1", UsersId: 1, InputsSolid: 0, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 20000, DefaultChoice: 1},
-		{Id: 4, Name: "assembler_mk1", UsersId: 1, InputsSolid: 2, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 30000, DefaultChoice: 1},
+		{Id: 1, Name: "harvester_mk1", UsersId: 1, InputsSolid: 0, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 20000, PowerClockExponent: 1.321928, DefaultChoice: 1},
+		{Id: 4, Name: "assembler_mk1", UsersId: 1, InputsSolid: 2, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 30000, PowerClockExponent: 1.321928, DefaultChoice: 1},
 	}
 	returnedRows, err := prototypes.SelectMachinesById(context.Background(), uts.db, []int{1, 4}, 1)
 	uts.Nil(err)
@@ -77,10 +77,10 @@ func (uts *UnitTestSuite) TestSelectMachinesById() {
 func (uts *UnitTestSuite) TestSelectMachines() {
 
 	expectedRows := []prototypes.MachineInfo{
-		{Id: 1, Name: "harvester_mk1", UsersId: 1, InputsSolid: 0, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 20000, DefaultChoice: 1},
-		{Id: 2, Name: "smelter_mk1", UsersId: 1, InputsSolid: 1, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 10000, DefaultChoice: 1},
-		{Id: 3, Name: "constructor_mk1", UsersId: 1, InputsSolid: 1, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 10000, DefaultChoice: 1},
-		{Id: 4, Name: "assembler_mk1", UsersId: 1, InputsSolid: 2, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 30000, DefaultChoice: 1},
+		{Id: 1, Name: "harvester_mk1", UsersId: 1, InputsSolid: 0, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 20000, PowerClockExponent: 1.321928, DefaultChoice: 1},
+		{Id: 2, Name: "smelter_mk1", UsersId: 1, InputsSolid: 1, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 10000, PowerClockExponent: 1.321928, DefaultChoice: 1},
+		{Id: 3, Name: "constructor_mk1", UsersId: 1, InputsSolid: 1, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 10000, PowerClockExponent: 1.321928, DefaultChoice: 1},
+		{Id: 4, Name: "assembler_mk1", UsersId: 1, InputsSolid: 2, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 30000, PowerClockExponent: 1.321928, DefaultChoice: 1},
 	}
 	returnedRows, err := prototypes.SelectMachines(context.Background(), uts.db, 0, 0, 1)
 	uts.Nil(err)
@@ -135,8 +135,8 @@ func (uts *UnitTestSuite) TestUpdateMachines() {
 
 func (uts *UnitTestSuite) TestDeleteMachines() {
 	expectedRows := []prototypes.MachineInfo{
-		{Id: 1, Name: "harvester_mk1", UsersId: 1, InputsSolid: 0, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 20000, DefaultChoice: 1},
-		{Id: 3, Name: "constructor_mk1", UsersId: 1, InputsSolid: 1, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 10000, DefaultChoice: 1},
+		{Id: 1, Name: "harvester_mk1", UsersId: 1, InputsSolid: 0, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 20000, PowerClockExponent: 1.321928, DefaultChoice: 1},
+		{Id: 3, Name: "constructor_mk1", UsersId: 1, InputsSolid: 1, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 10000, PowerClockExponent: 1.321928, DefaultChoice: 1},
 	}
 	ids := []int{2, 4}
 	result, err := prototypes.DeleteMachines(context.Background(), uts.db, ids, 1)
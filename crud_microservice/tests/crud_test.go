@@ -28,11 +28,16 @@ import (
 	"github.com/marban004/factory_games_organizer/handler"
 	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
 	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/machine"
+	machinecost "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/machine_cost"
 	machinerecipe "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/machine_recipe"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/plan"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/progress"
 	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/recipe"
 	recipeinput "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/recipe_input"
 	recipeoutput "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/recipe_output"
 	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/resource"
+	transporttier "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/transport_tier"
+	unlocktier "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/unlock_tier"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -73,8 +78,8 @@ func (cits *CrudIntegrationTestSuite) TearDownTest() {
 func (cits *CrudIntegrationTestSuite) TestSelectMachinesById() {
 	repo := machine.MySQLRepo{DB: cits.db}
 	expectedRows := []model.MachineInfo{
-		{Id: 1, Name: "harvester_mk1", UsersId: 1, InputsSolid: 0, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 20000, DefaultChoice: 1},
-		{Id: 4, Name: "assembler_mk1", UsersId: 1, InputsSolid: 2, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 30000, DefaultChoice: 1},
+		{Id: 1, Name: "harvester_mk1", UsersId: 1, InputsSolid: 0, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 20000, PowerClockExponent: 1.321928, PowerGenerationKw: 0, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 4, Name: "assembler_mk1", UsersId: 1, InputsSolid: 2, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 30000, PowerClockExponent: 1.321928, PowerGenerationKw: 0, DefaultChoice: 1, UnlockTiersId: 0},
 	}
 	returnedRows, err := repo.SelectMachinesById(context.Background(), []int{1, 4}, 1)
 	cits.Nil(err)
@@ -84,10 +89,10 @@ func (cits *CrudIntegrationTestSuite) TestSelectMachinesById() {
 func (cits *CrudIntegrationTestSuite) TestSelectMachines() {
 	repo := machine.MySQLRepo{DB: cits.db}
 	expectedRows := []model.MachineInfo{
-		{Id: 1, Name: "harvester_mk1", UsersId: 1, InputsSolid: 0, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 20000, DefaultChoice: 1},
-		{Id: 2, Name: "smelter_mk1", UsersId: 1, InputsSolid: 1, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 10000, DefaultChoice: 1},
-		{Id: 3, Name: "constructor_mk1", UsersId: 1, InputsSolid: 1, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 10000, DefaultChoice: 1},
-		{Id: 4, Name: "assembler_mk1", UsersId: 1, InputsSolid: 2, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 30000, DefaultChoice: 1},
+		{Id: 1, Name: "harvester_mk1", UsersId: 1, InputsSolid: 0, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 20000, PowerClockExponent: 1.321928, PowerGenerationKw: 0, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 2, Name: "smelter_mk1", UsersId: 1, InputsSolid: 1, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 10000, PowerClockExponent: 1.321928, PowerGenerationKw: 0, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 3, Name: "constructor_mk1", UsersId: 1, InputsSolid: 1, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 10000, PowerClockExponent: 1.321928, PowerGenerationKw: 0, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 4, Name: "assembler_mk1", UsersId: 1, InputsSolid: 2, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 30000, PowerClockExponent: 1.321928, PowerGenerationKw: 0, DefaultChoice: 1, UnlockTiersId: 0},
 	}
 	returnedRows, err := repo.SelectMachines(context.Background(), 0, 0, 1)
 	cits.Nil(err)
@@ -142,11 +147,33 @@ func (cits *CrudIntegrationTestSuite) TestUpdateMachines() {
 	cits.ElementsMatch(returnedRows, update.MachinesList, "The returned and expected values don't match")
 }
 
+func (cits *CrudIntegrationTestSuite) TestInsertMachinesWithoutPowerClockExponent() {
+	repo := machine.MySQLRepo{DB: cits.db}
+	ctx := context.Background()
+	input := []model.MachineInfo{{Name: "refinery_mk1", InputsSolid: 1, InputsLiquid: 1, OutputsSolid: 1, OutputsLiquid: 1, Speed: 1, PowerConsumptionKw: 30000, DefaultChoice: 1}}
+
+	_, err := repo.InsertMachines(ctx, input, 1)
+	cits.Require().Nil(err)
+	returnedRows, err := repo.SelectMachines(ctx, 5, 1, 1)
+	cits.Require().Nil(err)
+	cits.Require().Len(returnedRows, 1)
+	cits.Equal(float32(1), returnedRows[0].PowerClockExponent, "Missing power clock exponent has not been stored as 1")
+
+	update := returnedRows[0]
+	update.PowerClockExponent = 0
+	_, err = repo.UpdateMachines(ctx, []model.MachineInfo{update}, 1)
+	cits.Require().Nil(err)
+	returnedRows, err = repo.SelectMachinesById(ctx, []int{int(update.Id)}, 1)
+	cits.Require().Nil(err)
+	cits.Require().Len(returnedRows, 1)
+	cits.Equal(float32(1), returnedRows[0].PowerClockExponent, "Missing power clock exponent has not been stored as 1")
+}
+
 func (cits *CrudIntegrationTestSuite) TestDeleteMachines() {
 	repo := machine.MySQLRepo{DB: cits.db}
 	expectedRows := []model.MachineInfo{
-		{Id: 1, Name: "harvester_mk1", UsersId: 1, InputsSolid: 0, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 20000, DefaultChoice: 1},
-		{Id: 3, Name: "constructor_mk1", UsersId: 1, InputsSolid: 1, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 10000, DefaultChoice: 1},
+		{Id: 1, Name: "harvester_mk1", UsersId: 1, InputsSolid: 0, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 20000, PowerClockExponent: 1.321928, PowerGenerationKw: 0, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 3, Name: "constructor_mk1", UsersId: 1, InputsSolid: 1, InputsLiquid: 0, OutputsSolid: 1, OutputsLiquid: 0, Speed: 1, PowerConsumptionKw: 10000, PowerClockExponent: 1.321928, PowerGenerationKw: 0, DefaultChoice: 1, UnlockTiersId: 0},
 	}
 	ids := []int{2, 4}
 	result, err := repo.DeleteMachines(context.Background(), ids, 1)
@@ -183,8 +210,8 @@ func (cits *CrudIntegrationTestSuite) TestDeleteMachinesByUserId() {
 func (cits *CrudIntegrationTestSuite) TestSelectResourcesById() {
 	repo := resource.MySQLRepo{DB: cits.db}
 	expectedRows := []model.ResourceInfo{
-		{Id: 5, Name: "screw", UsersId: 1, Liquid: 0, ResourceUnit: ""},
-		{Id: 6, Name: "reinforced_iron_plate", UsersId: 1, Liquid: 0, ResourceUnit: ""},
+		{Id: 5, Name: "screw", UsersId: 1, Liquid: 0, ResourceUnit: "", SinkValue: 0},
+		{Id: 6, Name: "reinforced_iron_plate", UsersId: 1, Liquid: 0, ResourceUnit: "", SinkValue: 0},
 	}
 	returnedRows, err := repo.SelectResourcesById(context.Background(), []int{5, 6}, 1)
 	cits.Nil(err)
@@ -194,12 +221,12 @@ func (cits *CrudIntegrationTestSuite) TestSelectResourcesById() {
 func (cits *CrudIntegrationTestSuite) TestSelectResources() {
 	repo := resource.MySQLRepo{DB: cits.db}
 	expectedRows := []model.ResourceInfo{
-		{Id: 1, Name: "iron_ore", UsersId: 1, Liquid: 0, ResourceUnit: ""},
-		{Id: 2, Name: "iron_ingot", UsersId: 1, Liquid: 0, ResourceUnit: ""},
-		{Id: 3, Name: "iron_plate", UsersId: 1, Liquid: 0, ResourceUnit: ""},
-		{Id: 4, Name: "iron_rod", UsersId: 1, Liquid: 0, ResourceUnit: ""},
-		{Id: 5, Name: "screw", UsersId: 1, Liquid: 0, ResourceUnit: ""},
-		{Id: 6, Name: "reinforced_iron_plate", UsersId: 1, Liquid: 0, ResourceUnit: ""},
+		{Id: 1, Name: "iron_ore", UsersId: 1, Liquid: 0, ResourceUnit: "", SinkValue: 0},
+		{Id: 2, Name: "iron_ingot", UsersId: 1, Liquid: 0, ResourceUnit: "", SinkValue: 0},
+		{Id: 3, Name: "iron_plate", UsersId: 1, Liquid: 0, ResourceUnit: "", SinkValue: 0},
+		{Id: 4, Name: "iron_rod", UsersId: 1, Liquid: 0, ResourceUnit: "", SinkValue: 0},
+		{Id: 5, Name: "screw", UsersId: 1, Liquid: 0, ResourceUnit: "", SinkValue: 0},
+		{Id: 6, Name: "reinforced_iron_plate", UsersId: 1, Liquid: 0, ResourceUnit: "", SinkValue: 0},
 	}
 	returnedRows, err := repo.SelectResources(context.Background(), 0, 0, 1)
 	cits.Nil(err)
@@ -257,9 +284,9 @@ func (cits *CrudIntegrationTestSuite) TestUpdateResources() {
 func (cits *CrudIntegrationTestSuite) TestDeleteResources() {
 	repo := resource.MySQLRepo{DB: cits.db}
 	expectedRows := []model.ResourceInfo{
-		{Id: 2, Name: "iron_ingot", UsersId: 1, Liquid: 0, ResourceUnit: ""},
-		{Id: 5, Name: "screw", UsersId: 1, Liquid: 0, ResourceUnit: ""},
-		{Id: 6, Name: "reinforced_iron_plate", UsersId: 1, Liquid: 0, ResourceUnit: ""},
+		{Id: 2, Name: "iron_ingot", UsersId: 1, Liquid: 0, ResourceUnit: "", SinkValue: 0},
+		{Id: 5, Name: "screw", UsersId: 1, Liquid: 0, ResourceUnit: "", SinkValue: 0},
+		{Id: 6, Name: "reinforced_iron_plate", UsersId: 1, Liquid: 0, ResourceUnit: "", SinkValue: 0},
 	}
 	ids := []int{1, 3, 4}
 	result, err := repo.DeleteResources(context.Background(), ids, 1)
@@ -296,10 +323,10 @@ func (cits *CrudIntegrationTestSuite) TestDeleteResourcesByUserId() {
 func (cits *CrudIntegrationTestSuite) TestSelectRecipesById() {
 	repo := recipe.MySQLRepo{DB: cits.db}
 	expectedRows := []model.RecipeInfo{
-		{Id: 2, Name: "iron_ingot", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1},
-		{Id: 3, Name: "iron_plate", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1},
-		{Id: 4, Name: "iron_rods", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1},
-		{Id: 5, Name: "screw", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1},
+		{Id: 2, Name: "iron_ingot", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 3, Name: "iron_plate", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 4, Name: "iron_rods", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 5, Name: "screw", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1, UnlockTiersId: 0},
 	}
 	returnedRows, err := repo.SelectRecipesById(context.Background(), []int{2, 3, 4, 5}, 1)
 	cits.Nil(err)
@@ -309,12 +336,12 @@ func (cits *CrudIntegrationTestSuite) TestSelectRecipesById() {
 func (cits *CrudIntegrationTestSuite) TestSelectRecipes() {
 	repo := recipe.MySQLRepo{DB: cits.db}
 	expectedRows := []model.RecipeInfo{
-		{Id: 1, Name: "iron_ore_harvesting_default", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1},
-		{Id: 2, Name: "iron_ingot", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1},
-		{Id: 3, Name: "iron_plate", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1},
-		{Id: 4, Name: "iron_rods", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1},
-		{Id: 5, Name: "screw", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1},
-		{Id: 6, Name: "reinforced_iron_plate", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1},
+		{Id: 1, Name: "iron_ore_harvesting_default", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 2, Name: "iron_ingot", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 3, Name: "iron_plate", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 4, Name: "iron_rods", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 5, Name: "screw", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 6, Name: "reinforced_iron_plate", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1, UnlockTiersId: 0},
 	}
 	returnedRows, err := repo.SelectRecipes(context.Background(), 0, 0, 1)
 	cits.Nil(err)
@@ -372,9 +399,9 @@ func (cits *CrudIntegrationTestSuite) TestUpdateRecipes() {
 func (cits *CrudIntegrationTestSuite) TestDeleteRecipes() {
 	repo := recipe.MySQLRepo{DB: cits.db}
 	expectedRows := []model.RecipeInfo{
-		{Id: 1, Name: "iron_ore_harvesting_default", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1},
-		{Id: 2, Name: "iron_ingot", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1},
-		{Id: 3, Name: "iron_plate", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1},
+		{Id: 1, Name: "iron_ore_harvesting_default", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 2, Name: "iron_ingot", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1, UnlockTiersId: 0},
+		{Id: 3, Name: "iron_plate", UsersId: 1, ProductionTimeS: 60, DefaultChoice: 1, UnlockTiersId: 0},
 	}
 	ids := []int{4, 5, 6}
 	result, err := repo.DeleteRecipes(context.Background(), ids, 1)
@@ -752,6 +779,584 @@ func (cits *CrudIntegrationTestSuite) TestDeleteMachinesRecipesByUserId() {
 	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
 }
 
+func (cits *CrudIntegrationTestSuite) TestSelectTransportTiersById() {
+	repo := transporttier.MySQLRepo{DB: cits.db}
+	expectedRows := []model.TransportTierInfo{
+		{Id: 1, Name: "conveyor_belt_mk1", UsersId: 1, Liquid: 0, CapacityPerS: 1},
+		{Id: 3, Name: "pipeline_mk1", UsersId: 1, Liquid: 1, CapacityPerS: 5},
+	}
+	returnedRows, err := repo.SelectTransportTiersById(context.Background(), []int{1, 3}, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestSelectTransportTiers() {
+	repo := transporttier.MySQLRepo{DB: cits.db}
+	expectedRows := []model.TransportTierInfo{
+		{Id: 1, Name: "conveyor_belt_mk1", UsersId: 1, Liquid: 0, CapacityPerS: 1},
+		{Id: 2, Name: "conveyor_belt_mk2", UsersId: 1, Liquid: 0, CapacityPerS: 2},
+		{Id: 3, Name: "pipeline_mk1", UsersId: 1, Liquid: 1, CapacityPerS: 5},
+	}
+	returnedRows, err := repo.SelectTransportTiers(context.Background(), 0, 0, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestInsertTransportTiers() {
+	repo := transporttier.MySQLRepo{DB: cits.db}
+	jsonFileBytes, err := os.ReadFile("test_input.json")
+	if err != nil {
+		cits.FailNowf("failed to read file", err.Error())
+	}
+	input := handler.JSONData{}
+	json.Unmarshal(jsonFileBytes, &input)
+
+	result, err := repo.InsertTransportTiers(context.Background(), input.TransportTiersList, 1)
+	cits.Nil(err)
+
+	rowsChanged, err := result.RowsAffected()
+	cits.Nil(err)
+	cits.Equal(int64(2), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectTransportTiers(context.Background(), 4, 2, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, input.TransportTiersList, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestUpdateTransportTiers() {
+	repo := transporttier.MySQLRepo{DB: cits.db}
+	jsonFileBytes, err := os.ReadFile("test_update.json")
+	if err != nil {
+		cits.FailNowf("failed to read file", err.Error())
+	}
+	update := handler.JSONData{}
+	json.Unmarshal(jsonFileBytes, &update)
+
+	resultArr, err := repo.UpdateTransportTiers(context.Background(), update.TransportTiersList, 1)
+	cits.Nil(err)
+
+	rowsChanged := int64(0)
+	for _, result := range resultArr {
+		temp, err := result.RowsAffected()
+		cits.Nil(err)
+		rowsChanged += temp
+	}
+
+	cits.Nil(err)
+	cits.Equal(int64(2), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectTransportTiers(context.Background(), 2, 2, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, update.TransportTiersList, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestDeleteTransportTiers() {
+	repo := transporttier.MySQLRepo{DB: cits.db}
+	expectedRows := []model.TransportTierInfo{
+		{Id: 2, Name: "conveyor_belt_mk2", UsersId: 1, Liquid: 0, CapacityPerS: 2},
+	}
+	ids := []int{1, 3}
+	result, err := repo.DeleteTransportTiers(context.Background(), ids, 1)
+	cits.Nil(err)
+
+	rowsChanged, err := result.RowsAffected()
+	cits.Nil(err)
+	cits.Equal(int64(2), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectTransportTiers(context.Background(), 0, 0, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestDeleteTransportTiersByUserId() {
+	repo := transporttier.MySQLRepo{DB: cits.db}
+	expectedRows := []model.TransportTierInfo{}
+	transaction, err := repo.DB.BeginTx(context.Background(), nil)
+	cits.Nil(err)
+	result, err := repo.DeleteTransportTiersByUserId(context.Background(), transaction, 1)
+	cits.Nil(err)
+	err = transaction.Commit()
+	cits.Nil(err)
+
+	rowsChanged, err := result.RowsAffected()
+	cits.Nil(err)
+	cits.Equal(int64(3), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectTransportTiers(context.Background(), 0, 0, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestSelectUnlockTiersById() {
+	repo := unlocktier.MySQLRepo{DB: cits.db}
+	expectedRows := []model.UnlockTierInfo{
+		{Id: 1, Name: "onboarding", UsersId: 1, TierNumber: 0},
+		{Id: 3, Name: "tier_2", UsersId: 1, TierNumber: 2},
+	}
+	returnedRows, err := repo.SelectUnlockTiersById(context.Background(), []int{1, 3}, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestSelectUnlockTiers() {
+	repo := unlocktier.MySQLRepo{DB: cits.db}
+	expectedRows := []model.UnlockTierInfo{
+		{Id: 1, Name: "onboarding", UsersId: 1, TierNumber: 0},
+		{Id: 2, Name: "tier_1", UsersId: 1, TierNumber: 1},
+		{Id: 3, Name: "tier_2", UsersId: 1, TierNumber: 2},
+	}
+	returnedRows, err := repo.SelectUnlockTiers(context.Background(), 0, 0, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestInsertUnlockTiers() {
+	repo := unlocktier.MySQLRepo{DB: cits.db}
+	jsonFileBytes, err := os.ReadFile("test_input.json")
+	if err != nil {
+		cits.FailNowf("failed to read file", err.Error())
+	}
+	input := handler.JSONData{}
+	json.Unmarshal(jsonFileBytes, &input)
+
+	result, err := repo.InsertUnlockTiers(context.Background(), input.UnlockTiersList, 1)
+	cits.Nil(err)
+
+	rowsChanged, err := result.RowsAffected()
+	cits.Nil(err)
+	cits.Equal(int64(2), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectUnlockTiers(context.Background(), 4, 2, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, input.UnlockTiersList, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestUpdateUnlockTiers() {
+	repo := unlocktier.MySQLRepo{DB: cits.db}
+	jsonFileBytes, err := os.ReadFile("test_update.json")
+	if err != nil {
+		cits.FailNowf("failed to read file", err.Error())
+	}
+	update := handler.JSONData{}
+	json.Unmarshal(jsonFileBytes, &update)
+
+	resultArr, err := repo.UpdateUnlockTiers(context.Background(), update.UnlockTiersList, 1)
+	cits.Nil(err)
+
+	rowsChanged := int64(0)
+	for _, result := range resultArr {
+		temp, err := result.RowsAffected()
+		cits.Nil(err)
+		rowsChanged += temp
+	}
+
+	cits.Nil(err)
+	cits.Equal(int64(2), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectUnlockTiers(context.Background(), 2, 2, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, update.UnlockTiersList, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestDeleteUnlockTiers() {
+	repo := unlocktier.MySQLRepo{DB: cits.db}
+	expectedRows := []model.UnlockTierInfo{
+		{Id: 2, Name: "tier_1", UsersId: 1, TierNumber: 1},
+	}
+	ids := []int{1, 3}
+	result, err := repo.DeleteUnlockTiers(context.Background(), ids, 1)
+	cits.Nil(err)
+
+	rowsChanged, err := result.RowsAffected()
+	cits.Nil(err)
+	cits.Equal(int64(2), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectUnlockTiers(context.Background(), 0, 0, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestDeleteUnlockTiersByUserId() {
+	repo := unlocktier.MySQLRepo{DB: cits.db}
+	expectedRows := []model.UnlockTierInfo{}
+	transaction, err := repo.DB.BeginTx(context.Background(), nil)
+	cits.Nil(err)
+	result, err := repo.DeleteUnlockTiersByUserId(context.Background(), transaction, 1)
+	cits.Nil(err)
+	err = transaction.Commit()
+	cits.Nil(err)
+
+	rowsChanged, err := result.RowsAffected()
+	cits.Nil(err)
+	cits.Equal(int64(3), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectUnlockTiers(context.Background(), 0, 0, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestSelectMachinesCostsById() {
+	repo := machinecost.MySQLRepo{DB: cits.db}
+	expectedRows := []model.MachineCostInfo{
+		{Id: 1, UsersId: 1, MachinesId: 1, ResourcesId: 3, Amount: 10},
+		{Id: 5, UsersId: 1, MachinesId: 3, ResourcesId: 5, Amount: 16},
+	}
+	returnedRows, err := repo.SelectMachinesCostsById(context.Background(), []int{1, 5}, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestSelectMachinesCosts() {
+	repo := machinecost.MySQLRepo{DB: cits.db}
+	expectedRows := []model.MachineCostInfo{
+		{Id: 1, UsersId: 1, MachinesId: 1, ResourcesId: 3, Amount: 10},
+		{Id: 2, UsersId: 1, MachinesId: 1, ResourcesId: 4, Amount: 10},
+		{Id: 3, UsersId: 1, MachinesId: 2, ResourcesId: 4, Amount: 5},
+		{Id: 4, UsersId: 1, MachinesId: 3, ResourcesId: 3, Amount: 6},
+		{Id: 5, UsersId: 1, MachinesId: 3, ResourcesId: 5, Amount: 16},
+		{Id: 6, UsersId: 1, MachinesId: 4, ResourcesId: 6, Amount: 8},
+		{Id: 7, UsersId: 1, MachinesId: 4, ResourcesId: 4, Amount: 20},
+	}
+	returnedRows, err := repo.SelectMachinesCosts(context.Background(), 0, 0, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestInsertMachinesCosts() {
+	repo := machinecost.MySQLRepo{DB: cits.db}
+	jsonFileBytes, err := os.ReadFile("test_input.json")
+	if err != nil {
+		cits.FailNowf("failed to read file", err.Error())
+	}
+	input := handler.JSONData{}
+	json.Unmarshal(jsonFileBytes, &input)
+
+	result, err := repo.InsertMachinesCosts(context.Background(), input.MachinesCostsList, 1)
+	cits.Nil(err)
+
+	rowsChanged, err := result.RowsAffected()
+	cits.Nil(err)
+	cits.Equal(int64(2), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectMachinesCosts(context.Background(), 8, 2, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, input.MachinesCostsList, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestUpdateMachinesCosts() {
+	repo := machinecost.MySQLRepo{DB: cits.db}
+	jsonFileBytes, err := os.ReadFile("test_update.json")
+	if err != nil {
+		cits.FailNowf("failed to read file", err.Error())
+	}
+	update := handler.JSONData{}
+	json.Unmarshal(jsonFileBytes, &update)
+
+	resultArr, err := repo.UpdateMachinesCosts(context.Background(), update.MachinesCostsList, 1)
+	cits.Nil(err)
+
+	rowsChanged := int64(0)
+	for _, result := range resultArr {
+		temp, err := result.RowsAffected()
+		cits.Nil(err)
+		rowsChanged += temp
+	}
+
+	cits.Nil(err)
+	cits.Equal(int64(2), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectMachinesCosts(context.Background(), 3, 2, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, update.MachinesCostsList, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestDeleteMachinesCosts() {
+	repo := machinecost.MySQLRepo{DB: cits.db}
+	expectedRows := []model.MachineCostInfo{
+		{Id: 2, UsersId: 1, MachinesId: 1, ResourcesId: 4, Amount: 10},
+		{Id: 3, UsersId: 1, MachinesId: 2, ResourcesId: 4, Amount: 5},
+		{Id: 4, UsersId: 1, MachinesId: 3, ResourcesId: 3, Amount: 6},
+		{Id: 5, UsersId: 1, MachinesId: 3, ResourcesId: 5, Amount: 16},
+		{Id: 6, UsersId: 1, MachinesId: 4, ResourcesId: 6, Amount: 8},
+	}
+	ids := []int{1, 7}
+	result, err := repo.DeleteMachinesCosts(context.Background(), ids, 1)
+	cits.Nil(err)
+
+	rowsChanged, err := result.RowsAffected()
+	cits.Nil(err)
+	cits.Equal(int64(2), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectMachinesCosts(context.Background(), 0, 0, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestDeleteMachinesCostsByUserId() {
+	repo := machinecost.MySQLRepo{DB: cits.db}
+	expectedRows := []model.MachineCostInfo{}
+	transaction, err := repo.DB.BeginTx(context.Background(), nil)
+	cits.Nil(err)
+	result, err := repo.DeleteMachinesCostsByUserId(context.Background(), transaction, 1)
+	cits.Nil(err)
+	err = transaction.Commit()
+	cits.Nil(err)
+
+	rowsChanged, err := result.RowsAffected()
+	cits.Nil(err)
+	cits.Equal(int64(7), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectMachinesCosts(context.Background(), 0, 0, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestInsertWithTransaction() {
+	resourceRepo := resource.MySQLRepo{DB: cits.db}
+	recipeRepo := recipe.MySQLRepo{DB: cits.db}
+	inputRepo := recipeinput.MySQLRepo{DB: cits.db}
+	ctx := context.Background()
+	transaction, err := cits.db.BeginTx(ctx, nil)
+	cits.Require().Nil(err)
+
+	resourceId, err := resourceRepo.InsertResourceWithTransaction(ctx, transaction, model.ResourceInfo{Name: "steel_ingot"}, 1)
+	cits.Require().Nil(err)
+	recipeId, err := recipeRepo.InsertRecipeWithTransaction(ctx, transaction, model.RecipeInfo{Name: "steel_ingot", ProductionTimeS: 4, DefaultChoice: 1}, 1)
+	cits.Require().Nil(err)
+	inputs := []model.RecipeInputOutputInfo{{RecipesId: uint(recipeId), ResourcesId: 1, Amount: 3}, {RecipesId: uint(recipeId), ResourcesId: uint(resourceId), Amount: 1}}
+	_, err = inputRepo.InsertRecipesInputsWithTransaction(ctx, transaction, inputs, 1)
+	cits.Require().Nil(err)
+	cits.Require().Nil(transaction.Commit())
+
+	returnedRecipes, err := recipeRepo.SelectRecipesById(ctx, []int{int(recipeId)}, 1)
+	cits.Nil(err)
+	cits.Equal([]model.RecipeInfo{{Id: uint(recipeId), Name: "steel_ingot", UsersId: 1, ProductionTimeS: 4, DefaultChoice: 1}}, returnedRecipes)
+	returnedInputs, err := inputRepo.SelectRecipesInputs(ctx, 0, 0, 1)
+	cits.Nil(err)
+	insertedInputs := 0
+	for _, input := range returnedInputs {
+		if input.RecipesId == uint(recipeId) {
+			insertedInputs++
+		}
+	}
+	cits.Equal(2, insertedInputs, "The number of inserted recipes inputs differs from expected")
+}
+
+func (cits *CrudIntegrationTestSuite) TestInsertWithTransactionRollback() {
+	resourceRepo := resource.MySQLRepo{DB: cits.db}
+	inputRepo := recipeinput.MySQLRepo{DB: cits.db}
+	ctx := context.Background()
+	transaction, err := cits.db.BeginTx(ctx, nil)
+	cits.Require().Nil(err)
+
+	resourceId, err := resourceRepo.InsertResourceWithTransaction(ctx, transaction, model.ResourceInfo{Name: "steel_ingot"}, 1)
+	cits.Require().Nil(err)
+	inputs := []model.RecipeInputOutputInfo{{RecipesId: 1000, ResourcesId: uint(resourceId), Amount: 1}}
+	_, err = inputRepo.InsertRecipesInputsWithTransaction(ctx, transaction, inputs, 1)
+	cits.NotNil(err)
+
+	returnedResources, err := resourceRepo.SelectResourcesById(ctx, []int{int(resourceId)}, 1)
+	cits.Nil(err)
+	cits.Empty(returnedResources, "Resource inserted by rolled back transaction has been found")
+}
+
+func (cits *CrudIntegrationTestSuite) TestUpdateWithTransactionRollback() {
+	repo := machine.MySQLRepo{DB: cits.db}
+	ctx := context.Background()
+	expectedRows, err := repo.SelectMachinesById(ctx, []int{1}, 1)
+	cits.Require().Nil(err)
+	transaction, err := cits.db.BeginTx(ctx, nil)
+	cits.Require().Nil(err)
+
+	update := expectedRows[0]
+	update.Name = "renamed_machine"
+	results, err := repo.UpdateMachinesWithTransaction(ctx, transaction, []model.MachineInfo{update}, 1)
+	cits.Require().Nil(err)
+	rowsChanged, err := results[0].RowsAffected()
+	cits.Nil(err)
+	cits.Equal(int64(1), rowsChanged, "The number of changed rows differs from expected")
+	cits.Require().Nil(transaction.Rollback())
+
+	returnedRows, err := repo.SelectMachinesById(ctx, []int{1}, 1)
+	cits.Nil(err)
+	cits.Equal(expectedRows, returnedRows, "Machine updated by rolled back transaction has been changed")
+}
+
+func (cits *CrudIntegrationTestSuite) TestDeleteWithTransaction() {
+	inputRepo := recipeinput.MySQLRepo{DB: cits.db}
+	outputRepo := recipeoutput.MySQLRepo{DB: cits.db}
+	ctx := context.Background()
+	inputs, err := inputRepo.SelectRecipesInputs(ctx, 0, 0, 1)
+	cits.Require().Nil(err)
+	outputs, err := outputRepo.SelectRecipesOutputs(ctx, 0, 0, 1)
+	cits.Require().Nil(err)
+	transaction, err := cits.db.BeginTx(ctx, nil)
+	cits.Require().Nil(err)
+
+	_, err = inputRepo.DeleteRecipesInputsWithTransaction(ctx, transaction, []int{int(inputs[0].Id)}, 1)
+	cits.Require().Nil(err)
+	_, err = outputRepo.DeleteRecipesOutputsWithTransaction(ctx, transaction, []int{int(outputs[0].Id)}, 1)
+	cits.Require().Nil(err)
+	cits.Require().Nil(transaction.Commit())
+
+	returnedInputs, err := inputRepo.SelectRecipesInputsById(ctx, []int{int(inputs[0].Id)}, 1)
+	cits.Nil(err)
+	cits.Empty(returnedInputs)
+	returnedOutputs, err := outputRepo.SelectRecipesOutputsById(ctx, []int{int(outputs[0].Id)}, 1)
+	cits.Nil(err)
+	cits.Empty(returnedOutputs)
+}
+
+func (cits *CrudIntegrationTestSuite) TestSelectProgress() {
+	repo := progress.MySQLRepo{DB: cits.db}
+	expectedRow := model.ProgressInfo{UsersId: 1, CurrentTier: 2}
+	returnedRow, exists, err := repo.SelectProgress(context.Background(), 1)
+	cits.Nil(err)
+	cits.True(exists)
+	cits.Equal(expectedRow, returnedRow, "The returned and expected values don't match")
+
+	_, exists, err = repo.SelectProgress(context.Background(), 2)
+	cits.Nil(err)
+	cits.False(exists)
+}
+
+func (cits *CrudIntegrationTestSuite) TestUpdateProgress() {
+	repo := progress.MySQLRepo{DB: cits.db}
+	_, err := repo.UpdateProgress(context.Background(), model.ProgressInfo{CurrentTier: 1}, 1)
+	cits.Nil(err)
+	_, err = repo.UpdateProgress(context.Background(), model.ProgressInfo{CurrentTier: 3}, 2)
+	cits.Nil(err)
+
+	returnedRow, exists, err := repo.SelectProgress(context.Background(), 1)
+	cits.Nil(err)
+	cits.True(exists)
+	cits.Equal(model.ProgressInfo{UsersId: 1, CurrentTier: 1}, returnedRow, "The returned and expected values don't match")
+	returnedRow, exists, err = repo.SelectProgress(context.Background(), 2)
+	cits.Nil(err)
+	cits.True(exists)
+	cits.Equal(model.ProgressInfo{UsersId: 2, CurrentTier: 3}, returnedRow, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestDeleteProgressByUserId() {
+	repo := progress.MySQLRepo{DB: cits.db}
+	transaction, err := repo.DB.BeginTx(context.Background(), nil)
+	cits.Nil(err)
+	result, err := repo.DeleteProgressByUserId(context.Background(), transaction, 1)
+	cits.Nil(err)
+	err = transaction.Commit()
+	cits.Nil(err)
+
+	rowsChanged, err := result.RowsAffected()
+	cits.Nil(err)
+	cits.Equal(int64(1), rowsChanged, "The number of changed rows differs from expected")
+
+	_, exists, err := repo.SelectProgress(context.Background(), 1)
+	cits.Nil(err)
+	cits.False(exists)
+}
+
+func (cits *CrudIntegrationTestSuite) TestSelectPlansById() {
+	repo := plan.MySQLRepo{DB: cits.db}
+	expectedRows := []model.PlanInfo{
+		{Id: 2, Name: "screw_plan", UsersId: 1, Targets: []model.PlanTarget{{Resource: "screw", Rate: 2}}, AltRecipes: []string{}, AltMachines: []string{}, ProductionTree: json.RawMessage("{}")},
+	}
+	returnedRows, err := repo.SelectPlansById(context.Background(), []int{2}, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestSelectPlans() {
+	repo := plan.MySQLRepo{DB: cits.db}
+	expectedRows := []model.PlanInfo{
+		{Id: 1, Name: "reinforced_iron_plate_plan", UsersId: 1, Targets: []model.PlanTarget{{Resource: "reinforced_iron_plate", Rate: 0.5}}, AltRecipes: []string{}, AltMachines: []string{}, ProductionTree: json.RawMessage("{}")},
+		{Id: 2, Name: "screw_plan", UsersId: 1, Targets: []model.PlanTarget{{Resource: "screw", Rate: 2}}, AltRecipes: []string{}, AltMachines: []string{}, ProductionTree: json.RawMessage("{}")},
+	}
+	returnedRows, err := repo.SelectPlans(context.Background(), 0, 0, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestInsertPlans() {
+	repo := plan.MySQLRepo{DB: cits.db}
+	input := []model.PlanInfo{
+		{Id: 3, Name: "iron_rod_plan", UsersId: 1, Targets: []model.PlanTarget{{Resource: "iron_rod", Rate: 1}}, AltRecipes: []string{"alt_iron_rod"}, AltMachines: []string{}, ProductionTree: json.RawMessage(`{"TreeNodes":[]}`)},
+		{Id: 4, Name: "iron_plate_plan", UsersId: 1, Targets: []model.PlanTarget{{Resource: "iron_plate", Rate: 1}, {Resource: "screw", Rate: 0.5}}, AltRecipes: []string{}, AltMachines: []string{"constructor_mk2"}, ProductionTree: json.RawMessage(`{"TreeNodes":[]}`)},
+	}
+
+	result, err := repo.InsertPlans(context.Background(), input, 1)
+	cits.Nil(err)
+
+	rowsChanged, err := result.RowsAffected()
+	cits.Nil(err)
+	cits.Equal(int64(2), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectPlans(context.Background(), 3, 2, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, input, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestUpdatePlans() {
+	repo := plan.MySQLRepo{DB: cits.db}
+	update := []model.PlanInfo{
+		{Id: 1, Name: "reinforced_iron_plate_plan_alt", UsersId: 1, Targets: []model.PlanTarget{{Resource: "reinforced_iron_plate", Rate: 1}}, AltRecipes: []string{"alt_screw"}, AltMachines: []string{}, ProductionTree: json.RawMessage(`{"TreeNodes":[]}`)},
+		{Id: 2, Name: "screw_plan", UsersId: 1, Targets: []model.PlanTarget{{Resource: "screw", Rate: 4}}, AltRecipes: []string{}, AltMachines: []string{}, ProductionTree: json.RawMessage(`{"TreeNodes":[]}`)},
+	}
+
+	resultArr, err := repo.UpdatePlans(context.Background(), update, 1)
+	cits.Nil(err)
+
+	rowsChanged := int64(0)
+	for _, result := range resultArr {
+		temp, err := result.RowsAffected()
+		cits.Nil(err)
+		rowsChanged += temp
+	}
+
+	cits.Nil(err)
+	cits.Equal(int64(2), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectPlans(context.Background(), 1, 2, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, update, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestDeletePlans() {
+	repo := plan.MySQLRepo{DB: cits.db}
+	expectedRows := []model.PlanInfo{
+		{Id: 2, Name: "screw_plan", UsersId: 1, Targets: []model.PlanTarget{{Resource: "screw", Rate: 2}}, AltRecipes: []string{}, AltMachines: []string{}, ProductionTree: json.RawMessage("{}")},
+	}
+	ids := []int{1}
+	result, err := repo.DeletePlans(context.Background(), ids, 1)
+	cits.Nil(err)
+
+	rowsChanged, err := result.RowsAffected()
+	cits.Nil(err)
+	cits.Equal(int64(1), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectPlans(context.Background(), 0, 0, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
+func (cits *CrudIntegrationTestSuite) TestDeletePlansByUserId() {
+	repo := plan.MySQLRepo{DB: cits.db}
+	expectedRows := []model.PlanInfo{}
+	transaction, err := repo.DB.BeginTx(context.Background(), nil)
+	cits.Nil(err)
+	result, err := repo.DeletePlansByUserId(context.Background(), transaction, 1)
+	cits.Nil(err)
+	err = transaction.Commit()
+	cits.Nil(err)
+
+	rowsChanged, err := result.RowsAffected()
+	cits.Nil(err)
+	cits.Equal(int64(2), rowsChanged, "The number of changed rows differs from expected")
+
+	returnedRows, err := repo.SelectPlans(context.Background(), 0, 0, 1)
+	cits.Nil(err)
+	cits.ElementsMatch(returnedRows, expectedRows, "The returned and expected values don't match")
+}
+
 func setupDatabaseSchemaCITS(cits *CrudIntegrationTestSuite) {
 	cits.T().Log("setting up database schema")
 	_, err := cits.db.Exec(`CREATE DATABASE users_data_test`)
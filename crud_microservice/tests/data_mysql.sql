@@ -1,3 +1,8 @@
+DELETE FROM plans;
+DELETE FROM progress;
+DELETE FROM machines_costs;
+DELETE FROM unlock_tiers;
+DELETE FROM transport_tiers;
 DELETE FROM machines_recipes;
 DELETE FROM recipes_outputs;
 DELETE FROM recipes_inputs;
@@ -5,22 +10,22 @@ DELETE FROM recipes;
 DELETE FROM resources;
 DELETE FROM machines;
 
-INSERT INTO machines VALUES (1, 'harvester_mk1', 1, 0, 0, 1, 0, 1, 20000, TRUE);
-INSERT INTO machines VALUES (2, 'smelter_mk1', 1, 1, 0, 1, 0, 1, 10000, TRUE);
-INSERT INTO machines VALUES (3, 'constructor_mk1', 1, 1, 0, 1, 0, 1, 10000, TRUE);
-INSERT INTO machines VALUES (4, 'assembler_mk1', 1, 2, 0, 1, 0, 1, 30000, TRUE);
-INSERT INTO resources VALUES (1, 'iron_ore', 1, FALSE, '');
-INSERT INTO resources VALUES (2, 'iron_ingot', 1, FALSE, '');
-INSERT INTO resources VALUES (3, 'iron_plate', 1, FALSE, '');
-INSERT INTO resources VALUES (4, 'iron_rod', 1, FALSE, '');
-INSERT INTO resources VALUES (5, 'screw', 1, FALSE, '');
-INSERT INTO resources VALUES (6, 'reinforced_iron_plate', 1, FALSE, '');
-INSERT INTO recipes VALUES (1, 'iron_ore_harvesting_default', 1, 60, TRUE);
-INSERT INTO recipes VALUES (2, 'iron_ingot', 1, 60, TRUE);
-INSERT INTO recipes VALUES (3, 'iron_plate', 1, 60, TRUE);
-INSERT INTO recipes VALUES (4, 'iron_rods', 1, 60, TRUE);
-INSERT INTO recipes VALUES (5, 'screw', 1, 60, TRUE);
-INSERT INTO recipes VALUES (6, 'reinforced_iron_plate', 1, 60, TRUE);
+INSERT INTO machines VALUES (1, 'harvester_mk1', 1, 0, 0, 1, 0, 1, 20000, 1.321928, 0, TRUE, 0);
+INSERT INTO machines VALUES (2, 'smelter_mk1', 1, 1, 0, 1, 0, 1, 10000, 1.321928, 0, TRUE, 0);
+INSERT INTO machines VALUES (3, 'constructor_mk1', 1, 1, 0, 1, 0, 1, 10000, 1.321928, 0, TRUE, 0);
+INSERT INTO machines VALUES (4, 'assembler_mk1', 1, 2, 0, 1, 0, 1, 30000, 1.321928, 0, TRUE, 0);
+INSERT INTO resources VALUES (1, 'iron_ore', 1, FALSE, '', 0);
+INSERT INTO resources VALUES (2, 'iron_ingot', 1, FALSE, '', 0);
+INSERT INTO resources VALUES (3, 'iron_plate', 1, FALSE, '', 0);
+INSERT INTO resources VALUES (4, 'iron_rod', 1, FALSE, '', 0);
+INSERT INTO resources VALUES (5, 'screw', 1, FALSE, '', 0);
+INSERT INTO resources VALUES (6, 'reinforced_iron_plate', 1, FALSE, '', 0);
+INSERT INTO recipes VALUES (1, 'iron_ore_harvesting_default', 1, 60, TRUE, 0);
+INSERT INTO recipes VALUES (2, 'iron_ingot', 1, 60, TRUE, 0);
+INSERT INTO recipes VALUES (3, 'iron_plate', 1, 60, TRUE, 0);
+INSERT INTO recipes VALUES (4, 'iron_rods', 1, 60, TRUE, 0);
+INSERT INTO recipes VALUES (5, 'screw', 1, 60, TRUE, 0);
+INSERT INTO recipes VALUES (6, 'reinforced_iron_plate', 1, 60, TRUE, 0);
 INSERT INTO recipes_inputs VALUES (1, 1, 2, 1, 30);
 INSERT INTO recipes_inputs VALUES (2, 1, 3, 2, 30);
 INSERT INTO recipes_inputs VALUES (3, 1, 4, 2, 15);
@@ -38,4 +43,20 @@ INSERT INTO machines_recipes VALUES (2, 1, 2, 2);
 INSERT INTO machines_recipes VALUES (3, 1, 3, 3);
 INSERT INTO machines_recipes VALUES (4, 1, 4, 3);
 INSERT INTO machines_recipes VALUES (5, 1, 5, 3);
-INSERT INTO machines_recipes VALUES (6, 1, 6, 4);
\ No newline at end of file
+INSERT INTO machines_recipes VALUES (6, 1, 6, 4);
+INSERT INTO transport_tiers VALUES (1, 'conveyor_belt_mk1', 1, FALSE, 1);
+INSERT INTO transport_tiers VALUES (2, 'conveyor_belt_mk2', 1, FALSE, 2);
+INSERT INTO transport_tiers VALUES (3, 'pipeline_mk1', 1, TRUE, 5);
+INSERT INTO unlock_tiers VALUES (1, 'onboarding', 1, 0);
+INSERT INTO unlock_tiers VALUES (2, 'tier_1', 1, 1);
+INSERT INTO unlock_tiers VALUES (3, 'tier_2', 1, 2);
+INSERT INTO machines_costs VALUES (1, 1, 1, 3, 10);
+INSERT INTO machines_costs VALUES (2, 1, 1, 4, 10);
+INSERT INTO machines_costs VALUES (3, 1, 2, 4, 5);
+INSERT INTO machines_costs VALUES (4, 1, 3, 3, 6);
+INSERT INTO machines_costs VALUES (5, 1, 3, 5, 16);
+INSERT INTO machines_costs VALUES (6, 1, 4, 6, 8);
+INSERT INTO machines_costs VALUES (7, 1, 4, 4, 20);
+INSERT INTO progress VALUES (1, 2);
+INSERT INTO plans VALUES (1, 'reinforced_iron_plate_plan', 1, '[{"Resource":"reinforced_iron_plate","Rate":0.5}]', '[]', '[]', '{}');
+INSERT INTO plans VALUES (2, 'screw_plan', 1, '[{"Resource":"screw","Rate":2}]', '[]', '[]', '{}');
\ No newline at end of file
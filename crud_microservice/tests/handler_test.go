@@ -0,0 +1,487 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/marban004/factory_games_organizer/handler"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/bundle"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/machine"
+	machinecost "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/machine_cost"
+	machinerecipe "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/machine_recipe"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/plan"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/progress"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/recipe"
+	recipeinput "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/recipe_input"
+	recipeoutput "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/recipe_output"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/resource"
+	transporttier "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/transport_tier"
+	unlocktier "github.com/marban004/factory_games_organizer/microservice_logic_crud/repository/unlock_tier"
+)
+
+var testSecret = []byte("test_secret")
+
+func (cits *CrudIntegrationTestSuite) newCrudHandler() *handler.CRUD {
+	return &handler.CRUD{
+		MachineRepo:       &machine.MySQLRepo{DB: cits.db},
+		ResourceRepo:      &resource.MySQLRepo{DB: cits.db},
+		RecipeRepo:        &recipe.MySQLRepo{DB: cits.db},
+		RecipeinputRepo:   &recipeinput.MySQLRepo{DB: cits.db},
+		RecipeoutputRepo:  &recipeoutput.MySQLRepo{DB: cits.db},
+		MachineRecipeRepo: &machinerecipe.MySQLRepo{DB: cits.db},
+		TransportTierRepo: &transporttier.MySQLRepo{DB: cits.db},
+		UnlockTierRepo:    &unlocktier.MySQLRepo{DB: cits.db},
+		MachineCostRepo:   &machinecost.MySQLRepo{DB: cits.db},
+		PlanRepo:          &plan.MySQLRepo{DB: cits.db},
+		ProgressRepo:      &progress.MySQLRepo{DB: cits.db},
+		Secret:            testSecret,
+	}
+}
+
+// serveAuthorized sends body to handler function as a request of user 1 with query appended to the jwt parameter
+// and returns the recorded response.
+func (cits *CrudIntegrationTestSuite) serveAuthorized(handlerFunc http.HandlerFunc, method string, query string, body any) *httptest.ResponseRecorder {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"userId": 1,
+		"iat":    time.Now().Add(-time.Minute).Unix(),
+		"exp":    time.Now().Add(time.Minute).Unix(),
+	}).SignedString(testSecret)
+	cits.Require().Nil(err)
+	byteJSONRepresentation, err := json.Marshal(body)
+	cits.Require().Nil(err)
+	request := httptest.NewRequest(method, "/?jwt="+token+query, bytes.NewReader(byteJSONRepresentation))
+	recorder := httptest.NewRecorder()
+	handlerFunc(recorder, request)
+	return recorder
+}
+
+// resourceIdsByName returns ids of resources of user 1 with name, in ascending order.
+func (cits *CrudIntegrationTestSuite) resourceIdsByName(name string) []uint {
+	resources, err := (&resource.MySQLRepo{DB: cits.db}).SelectResources(context.Background(), 0, 0, 1)
+	cits.Require().Nil(err)
+	ids := []uint{}
+	for _, resource := range resources {
+		if resource.Name == name {
+			ids = append(ids, resource.Id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// recipeInputsOf returns recipe inputs of user 1 belonging to the recipe with name inserted last.
+func (cits *CrudIntegrationTestSuite) recipeInputsOf(recipeName string) []model.RecipeInputOutputInfo {
+	ctx := context.Background()
+	recipes, err := (&recipe.MySQLRepo{DB: cits.db}).SelectRecipes(ctx, 0, 0, 1)
+	cits.Require().Nil(err)
+	var recipeId uint
+	for _, recipe := range recipes {
+		if recipe.Name == recipeName && recipe.Id > recipeId {
+			recipeId = recipe.Id
+		}
+	}
+	cits.Require().NotZero(recipeId, "Recipe '%s' has not been found", recipeName)
+	inputs, err := (&recipeinput.MySQLRepo{DB: cits.db}).SelectRecipesInputs(ctx, 0, 0, 1)
+	cits.Require().Nil(err)
+	recipeInputs := []model.RecipeInputOutputInfo{}
+	for _, input := range inputs {
+		if input.RecipesId == recipeId {
+			recipeInputs = append(recipeInputs, input)
+		}
+	}
+	return recipeInputs
+}
+
+func (cits *CrudIntegrationTestSuite) TestInsertResolvesReferencesByTempId() {
+	h := cits.newCrudHandler()
+	input := handler.JSONData{
+		ResourcesList: []model.ResourceInfo{{Name: "copper_ore", TempId: "ore"}},
+		RecipesList:   []model.RecipeInfo{{Name: "copper_ingot", ProductionTimeS: 2, DefaultChoice: 1, TempId: "smelting"}},
+		RecipesInputsList: []model.RecipeInputOutputInfo{
+			{RecipesRef: "smelting", ResourcesRef: "ore", Amount: 1},
+		},
+	}
+	response := cits.serveAuthorized(h.Insert, http.MethodPost, "", input)
+	cits.Require().Equal(http.StatusCreated, response.Code, response.Body.String())
+
+	inputs := cits.recipeInputsOf("copper_ingot")
+	cits.Require().Len(inputs, 1)
+	cits.Equal(cits.resourceIdsByName("copper_ore"), []uint{inputs[0].ResourcesId})
+}
+
+func (cits *CrudIntegrationTestSuite) TestInsertResolvesReferencesByNameOfInsertedRecord() {
+	h := cits.newCrudHandler()
+	input := handler.JSONData{
+		ResourcesList: []model.ResourceInfo{{Name: "copper_ore"}},
+		RecipesList:   []model.RecipeInfo{{Name: "copper_ingot", ProductionTimeS: 2, DefaultChoice: 1}},
+		RecipesInputsList: []model.RecipeInputOutputInfo{
+			{RecipesRef: "copper_ingot", ResourcesRef: "copper_ore", Amount: 1},
+		},
+	}
+	response := cits.serveAuthorized(h.Insert, http.MethodPost, "", input)
+	cits.Require().Equal(http.StatusCreated, response.Code, response.Body.String())
+
+	inputs := cits.recipeInputsOf("copper_ingot")
+	cits.Require().Len(inputs, 1)
+	cits.Equal(cits.resourceIdsByName("copper_ore"), []uint{inputs[0].ResourcesId})
+}
+
+func (cits *CrudIntegrationTestSuite) TestInsertResolvesReferencesByNameOfStoredRecord() {
+	h := cits.newCrudHandler()
+	input := handler.JSONData{
+		RecipesInputsList: []model.RecipeInputOutputInfo{
+			{RecipesRef: "iron_plate", ResourcesRef: "iron_ore", Amount: 1},
+		},
+		MachinesRecipesList: []model.MachinesRecipesInfo{
+			{RecipesRef: "iron_plate", MachinesRef: "assembler_mk1"},
+		},
+	}
+	response := cits.serveAuthorized(h.Insert, http.MethodPost, "", input)
+	cits.Require().Equal(http.StatusCreated, response.Code, response.Body.String())
+
+	inputs := cits.recipeInputsOf("iron_plate")
+	cits.Require().Len(inputs, 2)
+	cits.ElementsMatch([]uint{1, 2}, []uint{inputs[0].ResourcesId, inputs[1].ResourcesId})
+	machinesRecipes, err := (&machinerecipe.MySQLRepo{DB: cits.db}).SelectMachinesRecipes(context.Background(), 0, 0, 1)
+	cits.Require().Nil(err)
+	inserted := false
+	for _, machineRecipe := range machinesRecipes {
+		inserted = inserted || (machineRecipe.RecipesId == 3 && machineRecipe.MachinesId == 4)
+	}
+	cits.True(inserted, "Machine recipe referencing stored recipe and machine has not been found")
+}
+
+func (cits *CrudIntegrationTestSuite) TestInsertReferencePrecedence() {
+	h := cits.newCrudHandler()
+	// temporary id takes precedence over name of resource inserted in the same request and of stored resource
+	input := handler.JSONData{
+		ResourcesList: []model.ResourceInfo{{Name: "copper_ore", TempId: "iron_ore"}, {Name: "iron_ore"}},
+		RecipesList:   []model.RecipeInfo{{Name: "copper_ingot", ProductionTimeS: 2, DefaultChoice: 1}},
+		RecipesInputsList: []model.RecipeInputOutputInfo{
+			{RecipesRef: "copper_ingot", ResourcesRef: "iron_ore", Amount: 1},
+		},
+	}
+	response := cits.serveAuthorized(h.Insert, http.MethodPost, "", input)
+	cits.Require().Equal(http.StatusCreated, response.Code, response.Body.String())
+	inputs := cits.recipeInputsOf("copper_ingot")
+	cits.Require().Len(inputs, 1)
+	cits.Equal(cits.resourceIdsByName("copper_ore"), []uint{inputs[0].ResourcesId})
+
+	// name of resource inserted in the same request takes precedence over name of stored resource
+	input = handler.JSONData{
+		ResourcesList: []model.ResourceInfo{{Name: "iron_ore"}},
+		RecipesList:   []model.RecipeInfo{{Name: "iron_ingot_alternative", ProductionTimeS: 2, DefaultChoice: 0}},
+		RecipesInputsList: []model.RecipeInputOutputInfo{
+			{RecipesRef: "iron_ingot_alternative", ResourcesRef: "iron_ore", Amount: 1},
+		},
+	}
+	response = cits.serveAuthorized(h.Insert, http.MethodPost, "", input)
+	cits.Require().Equal(http.StatusCreated, response.Code, response.Body.String())
+	ironOreIds := cits.resourceIdsByName("iron_ore")
+	cits.Require().Len(ironOreIds, 3)
+	inputs = cits.recipeInputsOf("iron_ingot_alternative")
+	cits.Require().Len(inputs, 1)
+	cits.Equal(ironOreIds[2], inputs[0].ResourcesId)
+}
+
+func (cits *CrudIntegrationTestSuite) TestInsertUnresolvedReference() {
+	h := cits.newCrudHandler()
+	input := handler.JSONData{
+		ResourcesList: []model.ResourceInfo{{Name: "copper_ore"}},
+		RecipesList:   []model.RecipeInfo{{Name: "copper_ingot", ProductionTimeS: 2, DefaultChoice: 1}},
+		RecipesInputsList: []model.RecipeInputOutputInfo{
+			{RecipesRef: "copper_ingot", ResourcesRef: "copper_dust", Amount: 1},
+		},
+	}
+	response := cits.serveAuthorized(h.Insert, http.MethodPost, "", input)
+	cits.Equal(http.StatusBadRequest, response.Code)
+	cits.Contains(response.Body.String(), "copper_dust")
+
+	cits.Empty(cits.resourceIdsByName("copper_ore"), "Resource inserted before unresolved reference has been found")
+	recipes, err := (&recipe.MySQLRepo{DB: cits.db}).SelectRecipes(context.Background(), 0, 0, 1)
+	cits.Require().Nil(err)
+	cits.Len(recipes, 6, "Recipe inserted before unresolved reference has been found")
+}
+
+func (cits *CrudIntegrationTestSuite) TestInsertDryRun() {
+	h := cits.newCrudHandler()
+	input := handler.JSONData{
+		ResourcesList: []model.ResourceInfo{{Name: "copper_ore"}},
+		RecipesList:   []model.RecipeInfo{{Name: "copper_ingot", ProductionTimeS: 2, DefaultChoice: 1}},
+		RecipesInputsList: []model.RecipeInputOutputInfo{
+			{RecipesRef: "copper_ingot", ResourcesRef: "copper_ore", Amount: 1},
+		},
+	}
+	response := cits.serveAuthorized(h.Insert, http.MethodPost, "&dry_run=true", input)
+	cits.Require().Equal(http.StatusOK, response.Code, response.Body.String())
+	returnedResponse := handler.InsertResponse{}
+	cits.Require().Nil(json.Unmarshal(response.Body.Bytes(), &returnedResponse))
+	cits.Equal(handler.InsertResponse{ResourcesInserted: 1, RecipesInserted: 1, RecipesInputsInserted: 1}, returnedResponse)
+
+	cits.Empty(cits.resourceIdsByName("copper_ore"), "Resource inserted in dry run has been found")
+	recipes, err := (&recipe.MySQLRepo{DB: cits.db}).SelectRecipes(context.Background(), 0, 0, 1)
+	cits.Require().Nil(err)
+	cits.Len(recipes, 6, "Recipe inserted in dry run has been found")
+}
+
+func (cits *CrudIntegrationTestSuite) TestUpdateDryRun() {
+	h := cits.newCrudHandler()
+	repo := resource.MySQLRepo{DB: cits.db}
+	expectedRows, err := repo.SelectResourcesById(context.Background(), []int{1}, 1)
+	cits.Require().Nil(err)
+	update := expectedRows[0]
+	update.Name = "renamed_resource"
+	response := cits.serveAuthorized(h.Update, http.MethodPut, "&dry_run=true", handler.JSONData{ResourcesList: []model.ResourceInfo{update}})
+	cits.Require().Equal(http.StatusOK, response.Code, response.Body.String())
+	returnedResponse := handler.UpdateResponse{}
+	cits.Require().Nil(json.Unmarshal(response.Body.Bytes(), &returnedResponse))
+	cits.Equal(handler.UpdateResponse{ResourcesUpdated: 1}, returnedResponse)
+
+	returnedRows, err := repo.SelectResourcesById(context.Background(), []int{1}, 1)
+	cits.Nil(err)
+	cits.Equal(expectedRows, returnedRows, "Resource updated in dry run has been changed")
+}
+
+func (cits *CrudIntegrationTestSuite) TestDeleteDryRun() {
+	h := cits.newCrudHandler()
+	input := handler.DeleteInput{RecipesInputsIds: []int{1}, MachinesCostsIds: []int{1, 2}}
+	response := cits.serveAuthorized(h.Delete, http.MethodDelete, "&dry_run=true", input)
+	cits.Require().Equal(http.StatusOK, response.Code, response.Body.String())
+	returnedResponse := handler.DeleteResponse{}
+	cits.Require().Nil(json.Unmarshal(response.Body.Bytes(), &returnedResponse))
+	cits.Equal(handler.DeleteResponse{RecipesInputsDeleted: 1, MachinesCostsDeleted: 2}, returnedResponse)
+
+	returnedInputs, err := (&recipeinput.MySQLRepo{DB: cits.db}).SelectRecipesInputsById(context.Background(), []int{1}, 1)
+	cits.Nil(err)
+	cits.Len(returnedInputs, 1, "Recipe input deleted in dry run has not been found")
+	returnedCosts, err := (&machinecost.MySQLRepo{DB: cits.db}).SelectMachinesCostsById(context.Background(), []int{1, 2}, 1)
+	cits.Nil(err)
+	cits.Len(returnedCosts, 2, "Machines costs deleted in dry run have not been found")
+}
+
+func (cits *CrudIntegrationTestSuite) TestUpdateIsRolledBackWhenLaterTableFails() {
+	h := cits.newCrudHandler()
+	repo := resource.MySQLRepo{DB: cits.db}
+	expectedRows, err := repo.SelectResourcesById(context.Background(), []int{1}, 1)
+	cits.Require().Nil(err)
+	update := expectedRows[0]
+	update.Name = "renamed_resource"
+	input := handler.JSONData{
+		ResourcesList: []model.ResourceInfo{update},
+		// machines costs are updated after resources, reference to missing resource violates foreign key
+		MachinesCostsList: []model.MachineCostInfo{{Id: 1, MachinesId: 1, ResourcesId: 1000, Amount: 10}},
+	}
+	response := cits.serveAuthorized(h.Update, http.MethodPut, "", input)
+	cits.Equal(http.StatusInternalServerError, response.Code)
+
+	returnedRows, err := repo.SelectResourcesById(context.Background(), []int{1}, 1)
+	cits.Nil(err)
+	cits.Equal(expectedRows, returnedRows, "Resource updated before failed update has been changed")
+}
+
+func (cits *CrudIntegrationTestSuite) TestDeleteIsRolledBackWhenLaterTableFails() {
+	h := cits.newCrudHandler()
+	// unlock tiers are deleted last, empty list of ids cannot be deleted
+	input := handler.DeleteInput{RecipesInputsIds: []int{1}, MachinesIds: []int{1}, UnlockTiersIds: []int{}}
+	response := cits.serveAuthorized(h.Delete, http.MethodDelete, "", input)
+	cits.Equal(http.StatusInternalServerError, response.Code)
+
+	returnedInputs, err := (&recipeinput.MySQLRepo{DB: cits.db}).SelectRecipesInputsById(context.Background(), []int{1}, 1)
+	cits.Nil(err)
+	cits.Len(returnedInputs, 1, "Recipe input deleted before failed delete has not been found")
+	returnedMachines, err := (&machine.MySQLRepo{DB: cits.db}).SelectMachinesById(context.Background(), []int{1}, 1)
+	cits.Nil(err)
+	cits.Len(returnedMachines, 1, "Machine deleted before failed delete has not been found")
+}
+
+// testBundle returns bundle with iron_ore resource, smelter_mk1 machine and iron_ingot recipe, which already exist in
+// test data, and copper_ore resource, which does not. The bundle gives them different attributes than test data.
+func testBundle() bundle.Bundle {
+	currentTier := uint(1)
+	return bundle.Bundle{
+		Version:   bundle.Version,
+		Resources: []bundle.Resource{{Name: "iron_ore", ResourceUnit: "items"}, {Name: "copper_ore", ResourceUnit: "items"}},
+		Machines: []bundle.Machine{{
+			Name: "smelter_mk1", InputsSolid: 1, OutputsSolid: 1, Speed: 2, PowerConsumptionKw: 4000, PowerClockExponent: 1.6, DefaultChoice: 1,
+			BuildCost: []bundle.Amount{{Resource: "copper_ore", Amount: 7}},
+		}},
+		Recipes: []bundle.Recipe{{
+			Name: "iron_ingot", ProductionTimeS: 2, DefaultChoice: 1,
+			Inputs:   []bundle.Amount{{Resource: "copper_ore", Amount: 1}},
+			Outputs:  []bundle.Amount{{Resource: "iron_ore", Amount: 2}},
+			Machines: []string{"smelter_mk1"},
+		}},
+		CurrentTier: &currentTier,
+	}
+}
+
+func (cits *CrudIntegrationTestSuite) importTestBundle(query string) handler.BundleImportResponse {
+	h := cits.newCrudHandler()
+	response := cits.serveAuthorized(h.Import, http.MethodPost, query, testBundle())
+	cits.Require().Equal(http.StatusOK, response.Code, response.Body.String())
+	returnedResponse := handler.BundleImportResponse{}
+	cits.Require().Nil(json.Unmarshal(response.Body.Bytes(), &returnedResponse))
+	return returnedResponse
+}
+
+// amountsOfRecipe maps ids of resources of recipe inputs or outputs of recipe to their amounts.
+func amountsOfRecipe(entries []model.RecipeInputOutputInfo, recipeId uint) map[uint]uint {
+	amounts := make(map[uint]uint)
+	for _, entry := range entries {
+		if entry.RecipesId == recipeId {
+			amounts[entry.ResourcesId] = entry.Amount
+		}
+	}
+	return amounts
+}
+
+// buildCostOfMachine maps ids of resources needed to construct machine of user 1 to their amounts.
+func (cits *CrudIntegrationTestSuite) buildCostOfMachine(machineId uint) map[uint]uint {
+	costs, err := (&machinecost.MySQLRepo{DB: cits.db}).SelectMachinesCosts(context.Background(), 0, 0, 1)
+	cits.Require().Nil(err)
+	amounts := make(map[uint]uint)
+	for _, cost := range costs {
+		if cost.MachinesId == machineId {
+			amounts[cost.ResourcesId] = cost.Amount
+		}
+	}
+	return amounts
+}
+
+func (cits *CrudIntegrationTestSuite) currentTier() uint {
+	progress, exists, err := (&progress.MySQLRepo{DB: cits.db}).SelectProgress(context.Background(), 1)
+	cits.Require().Nil(err)
+	cits.Require().True(exists)
+	return progress.CurrentTier
+}
+
+// assertTestDataUnchanged checks that records of test data also present in test bundle have not been changed.
+func (cits *CrudIntegrationTestSuite) assertTestDataUnchanged() {
+	ctx := context.Background()
+	resources, err := (&resource.MySQLRepo{DB: cits.db}).SelectResourcesById(ctx, []int{1}, 1)
+	cits.Require().Nil(err)
+	cits.Equal("", resources[0].ResourceUnit, "Existing resource has been changed")
+	machines, err := (&machine.MySQLRepo{DB: cits.db}).SelectMachinesById(ctx, []int{2}, 1)
+	cits.Require().Nil(err)
+	cits.Equal(float32(1), machines[0].Speed, "Existing machine has been changed")
+	cits.Equal(map[uint]uint{4: 5}, cits.buildCostOfMachine(2), "Build cost of existing machine has been changed")
+	inputs, err := (&recipeinput.MySQLRepo{DB: cits.db}).SelectRecipesInputs(ctx, 0, 0, 1)
+	cits.Require().Nil(err)
+	cits.Equal(map[uint]uint{1: 30}, amountsOfRecipe(inputs, 2), "Inputs of existing recipe have been changed")
+	outputs, err := (&recipeoutput.MySQLRepo{DB: cits.db}).SelectRecipesOutputs(ctx, 0, 0, 1)
+	cits.Require().Nil(err)
+	cits.Equal(map[uint]uint{2: 30}, amountsOfRecipe(outputs, 2), "Outputs of existing recipe have been changed")
+	cits.Equal(uint(2), cits.currentTier(), "Current tier has been changed")
+}
+
+func (cits *CrudIntegrationTestSuite) TestImportBundleSkipExisting() {
+	returnedResponse := cits.importTestBundle("")
+	cits.Equal(handler.BundleImportResponse{
+		Resources: handler.BundleImportCounts{Inserted: 1, Skipped: 1},
+		Machines:  handler.BundleImportCounts{Skipped: 1},
+		Recipes:   handler.BundleImportCounts{Skipped: 1},
+		Progress:  handler.BundleImportCounts{Skipped: 1},
+	}, returnedResponse)
+
+	cits.Len(cits.resourceIdsByName("copper_ore"), 1, "New resource of the bundle has not been inserted")
+	cits.assertTestDataUnchanged()
+}
+
+func (cits *CrudIntegrationTestSuite) TestImportBundleMerge() {
+	returnedResponse := cits.importTestBundle("&strategy=merge")
+	cits.Equal(handler.BundleImportResponse{
+		Resources: handler.BundleImportCounts{Inserted: 1, Updated: 1},
+		Machines:  handler.BundleImportCounts{Updated: 1},
+		Recipes:   handler.BundleImportCounts{Updated: 1},
+		Progress:  handler.BundleImportCounts{Updated: 1},
+	}, returnedResponse)
+
+	ctx := context.Background()
+	copperOreIds := cits.resourceIdsByName("copper_ore")
+	cits.Require().Len(copperOreIds, 1)
+	copperOreId := copperOreIds[0]
+	resources, err := (&resource.MySQLRepo{DB: cits.db}).SelectResourcesById(ctx, []int{1}, 1)
+	cits.Require().Nil(err)
+	cits.Equal("items", resources[0].ResourceUnit, "Existing resource has not been merged")
+	machines, err := (&machine.MySQLRepo{DB: cits.db}).SelectMachinesById(ctx, []int{2}, 1)
+	cits.Require().Nil(err)
+	cits.Equal(float32(2), machines[0].Speed, "Existing machine has not been merged")
+	// build cost, inputs, outputs and machines of merged records are replaced with those of the bundle
+	cits.Equal(map[uint]uint{copperOreId: 7}, cits.buildCostOfMachine(2))
+	inputs, err := (&recipeinput.MySQLRepo{DB: cits.db}).SelectRecipesInputs(ctx, 0, 0, 1)
+	cits.Require().Nil(err)
+	cits.Equal(map[uint]uint{copperOreId: 1}, amountsOfRecipe(inputs, 2))
+	outputs, err := (&recipeoutput.MySQLRepo{DB: cits.db}).SelectRecipesOutputs(ctx, 0, 0, 1)
+	cits.Require().Nil(err)
+	cits.Equal(map[uint]uint{1: 2}, amountsOfRecipe(outputs, 2))
+	machinesRecipes, err := (&machinerecipe.MySQLRepo{DB: cits.db}).SelectMachinesRecipes(ctx, 0, 0, 1)
+	cits.Require().Nil(err)
+	recipeMachines := []uint{}
+	for _, machineRecipe := range machinesRecipes {
+		if machineRecipe.RecipesId == 2 {
+			recipeMachines = append(recipeMachines, machineRecipe.MachinesId)
+		}
+	}
+	cits.Equal([]uint{2}, recipeMachines)
+	cits.Equal(uint(1), cits.currentTier())
+	// records of other machines and recipes are left untouched
+	cits.Equal(map[uint]uint{3: 6, 5: 16}, cits.buildCostOfMachine(3))
+	cits.Equal(map[uint]uint{2: 30}, amountsOfRecipe(inputs, 3))
+}
+
+func (cits *CrudIntegrationTestSuite) TestImportBundleReplace() {
+	returnedResponse := cits.importTestBundle("&strategy=replace")
+	cits.Equal(handler.BundleImportResponse{
+		Resources: handler.BundleImportCounts{Inserted: 2},
+		Machines:  handler.BundleImportCounts{Inserted: 1},
+		Recipes:   handler.BundleImportCounts{Inserted: 1},
+		Progress:  handler.BundleImportCounts{Inserted: 1},
+	}, returnedResponse)
+
+	h := cits.newCrudHandler()
+	response := cits.serveAuthorized(h.Export, http.MethodGet, "", nil)
+	cits.Require().Equal(http.StatusOK, response.Code, response.Body.String())
+	exported := bundle.Bundle{}
+	cits.Require().Nil(json.Unmarshal(response.Body.Bytes(), &exported))
+	expected := testBundle()
+	expected.UnlockTiers = []bundle.UnlockTier{}
+	expected.TransportTiers = []bundle.TransportTier{}
+	expected.Plans = []bundle.Plan{}
+	cits.Equal(expected, exported, "Data of the user differs from imported bundle")
+}
+
+func (cits *CrudIntegrationTestSuite) TestImportBundleDryRun() {
+	returnedResponse := cits.importTestBundle("&strategy=merge&dry_run=true")
+	cits.Equal(handler.BundleImportResponse{
+		Resources: handler.BundleImportCounts{Inserted: 1, Updated: 1},
+		Machines:  handler.BundleImportCounts{Updated: 1},
+		Recipes:   handler.BundleImportCounts{Updated: 1},
+		Progress:  handler.BundleImportCounts{Updated: 1},
+	}, returnedResponse)
+
+	cits.Empty(cits.resourceIdsByName("copper_ore"), "Resource imported in dry run has been found")
+	cits.assertTestDataUnchanged()
+}
@@ -0,0 +1,234 @@
+//     This is Factory Games Organizer api. Api is responsible for creating, updating and authenicating api users, CRUD operations on database associated with the api and provides production calculator service.
+//     Copyright (C) 2025  Marek Banaś
+
+//     This program is free software: you can redistribute it and/or modify
+//     it under the terms of the GNU General Public License as published by
+//     the Free Software Foundation, either version 3 of the License, or
+//     (at your option) any later version.
+
+//     This program is distributed in the hope that it will be useful,
+//     but WITHOUT ANY WARRANTY; without even the implied warranty of
+//     MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//     GNU General Public License for more details.
+
+//     You should have received a copy of the GNU General Public License
+//     along with this program.  If not, see https://www.gnu.org/licenses/.
+
+package tests
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/importer"
+	"github.com/marban004/factory_games_organizer/microservice_logic_crud/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImporterTestSuite struct {
+	suite.Suite
+	docs    []byte
+	dataRaw []byte
+}
+
+func TestImporterTestSuite(t *testing.T) {
+	suite.Run(t, &ImporterTestSuite{})
+}
+
+func (its *ImporterTestSuite) SetupSuite() {
+	docs, err := os.ReadFile("test_docs.json")
+	if err != nil {
+		its.FailNowf("unable to read test docs, error: %s", err.Error())
+	}
+	its.docs = docs
+	dataRaw, err := os.ReadFile("test_data_raw.json")
+	if err != nil {
+		its.FailNowf("unable to read test data.raw, error: %s", err.Error())
+	}
+	its.dataRaw = dataRaw
+}
+
+func (its *ImporterTestSuite) TestParseSatisfactoryDocsResources() {
+	expectedResources := []model.ResourceInfo{
+		{Name: "iron_ore", SinkValue: 1},
+		{Name: "iron_ingot", SinkValue: 2},
+		{Name: "iron_plate", SinkValue: 6},
+		{Name: "coal"},
+		{Name: "wire", SinkValue: 6},
+		{Name: "water", Liquid: 1, ResourceUnit: "litre"},
+	}
+	data, err := importer.ParseSatisfactoryDocs(its.docs)
+	its.Require().NoError(err)
+	its.Equal(expectedResources, data.Resources)
+}
+
+func (its *ImporterTestSuite) TestParseSatisfactoryDocsMachines() {
+	data, err := importer.ParseSatisfactoryDocs(its.docs)
+	its.Require().NoError(err)
+	its.Require().Len(data.Machines, 6)
+	machines := make(map[string]model.MachineInfo)
+	for _, machine := range data.Machines {
+		machines[machine.Name] = machine
+	}
+	its.Equal(uint(2), machines["foundry"].InputsSolid)
+	its.Equal(uint(1), machines["foundry"].OutputsSolid)
+	its.Equal(uint(16000), machines["foundry"].PowerConsumptionKw)
+	its.Equal(uint(1), machines["water_extractor"].OutputsLiquid)
+	its.Equal(uint(0), machines["water_extractor"].OutputsSolid)
+	its.Equal(uint(1), machines["coal_powered_generator"].InputsSolid)
+	its.Equal(uint(1), machines["coal_powered_generator"].InputsLiquid)
+	its.Equal(uint(75000), machines["coal_powered_generator"].PowerGenerationKw)
+	its.NotContains(machines, "geothermal_generator")
+}
+
+func (its *ImporterTestSuite) TestParseSatisfactoryDocsRecipes() {
+	data, err := importer.ParseSatisfactoryDocs(its.docs)
+	its.Require().NoError(err)
+	recipes := make(map[string]importer.GameRecipe)
+	for _, recipe := range data.Recipes {
+		recipes[recipe.Recipe.Name] = recipe
+	}
+	its.Len(recipes, 8)
+	expectedRecipe := importer.GameRecipe{
+		Recipe:   model.RecipeInfo{Name: "alternate_quick_wire", ProductionTimeS: 3, DefaultChoice: 0},
+		Inputs:   []importer.GameAmount{{Resource: "iron_ingot", Amount: 4}},
+		Outputs:  []importer.GameAmount{{Resource: "wire", Amount: 12}},
+		Machines: []string{"constructor"},
+	}
+	its.Equal(expectedRecipe, recipes["alternate_quick_wire"])
+	expectedRecipe = importer.GameRecipe{
+		Recipe:   model.RecipeInfo{Name: "iron_ore_extraction_miner_mk_2", ProductionTimeS: 1, DefaultChoice: 1},
+		Inputs:   []importer.GameAmount{},
+		Outputs:  []importer.GameAmount{{Resource: "iron_ore", Amount: 2}},
+		Machines: []string{"miner_mk_2"},
+	}
+	its.Equal(expectedRecipe, recipes["iron_ore_extraction_miner_mk_2"])
+	expectedRecipe = importer.GameRecipe{
+		Recipe:   model.RecipeInfo{Name: "coal_burning_coal_powered_generator", ProductionTimeS: 4, DefaultChoice: 1},
+		Inputs:   []importer.GameAmount{{Resource: "coal", Amount: 1}, {Resource: "water", Amount: 3000}},
+		Outputs:  []importer.GameAmount{},
+		Machines: []string{"coal_powered_generator"},
+	}
+	its.Equal(expectedRecipe, recipes["coal_burning_coal_powered_generator"])
+}
+
+func (its *ImporterTestSuite) TestParseSatisfactoryDocsSkipped() {
+	expectedSkipped := []importer.SkippedEntry{
+		{Kind: importer.SkippedMachine, Name: "Build_GeneratorGeoThermal_C", Reason: "building type FGBuildableGeneratorGeoThermal is not supported"},
+		{Kind: importer.SkippedRecipe, Name: "Recipe_ConstructorMk1_C", Reason: "recipe is not produced in any imported building"},
+		{Kind: importer.SkippedRecipe, Name: "Recipe_Unknown_C", Reason: "'Desc_Mystery_C' is not an item"},
+	}
+	data, err := importer.ParseSatisfactoryDocs(its.docs)
+	its.Require().NoError(err)
+	its.Equal(expectedSkipped, data.Skipped)
+}
+
+func (its *ImporterTestSuite) TestParseSatisfactoryDocsUTF16() {
+	encoded := []byte{0xFF, 0xFE}
+	for _, unit := range utf16.Encode([]rune(string(its.docs))) {
+		encoded = binary.LittleEndian.AppendUint16(encoded, unit)
+	}
+	expectedData, err := importer.ParseSatisfactoryDocs(its.docs)
+	its.Require().NoError(err)
+	data, err := importer.ParseSatisfactoryDocs(encoded)
+	its.Require().NoError(err)
+	its.Equal(expectedData, data)
+}
+
+func (its *ImporterTestSuite) TestParseSatisfactoryDocsInvalid() {
+	_, err := importer.ParseSatisfactoryDocs([]byte("{\"NativeClass\": 1}"))
+	its.Error(err)
+}
+
+func (its *ImporterTestSuite) TestParseFactorioDataRawMachines() {
+	data, err := importer.ParseFactorioDataRaw(its.dataRaw, importer.FactorioNormal)
+	its.Require().NoError(err)
+	machines := make(map[string]model.MachineInfo)
+	for _, machine := range data.Machines {
+		machines[machine.Name] = machine
+	}
+	its.Len(machines, 6)
+	expectedMachine := model.MachineInfo{Name: "assembling_machine_2", InputsSolid: 2, InputsLiquid: 1, OutputsSolid: 2, OutputsLiquid: 1, Speed: 0.75, PowerConsumptionKw: 150, PowerClockExponent: 1, DefaultChoice: 1}
+	its.Equal(expectedMachine, machines["assembling_machine_2"])
+	expectedMachine = model.MachineInfo{Name: "chemical_plant", InputsSolid: 2, InputsLiquid: 2, OutputsSolid: 0, OutputsLiquid: 2, Speed: 1, PowerConsumptionKw: 210, PowerClockExponent: 1, DefaultChoice: 1}
+	its.Equal(expectedMachine, machines["chemical_plant"])
+	its.Equal(uint(0), machines["stone_furnace"].PowerConsumptionKw)
+	its.Equal(uint(1), machines["electric_mining_drill"].InputsLiquid)
+	its.Equal(float32(0.5), machines["electric_mining_drill"].Speed)
+	its.Equal(uint(1), machines["pumpjack"].OutputsLiquid)
+	its.Equal(uint(1), machines["offshore_pump"].OutputsLiquid)
+}
+
+func (its *ImporterTestSuite) TestParseFactorioDataRawRecipes() {
+	data, err := importer.ParseFactorioDataRaw(its.dataRaw, importer.FactorioNormal)
+	its.Require().NoError(err)
+	recipes := make(map[string]importer.GameRecipe)
+	for _, recipe := range data.Recipes {
+		recipes[recipe.Recipe.Name] = recipe
+	}
+	its.Len(recipes, 11)
+	expectedRecipe := importer.GameRecipe{
+		Recipe:   model.RecipeInfo{Name: "iron_plate", ProductionTimeS: 16, DefaultChoice: 1},
+		Inputs:   []importer.GameAmount{{Resource: "iron_ore", Amount: 5}},
+		Outputs:  []importer.GameAmount{{Resource: "iron_plate", Amount: 5}},
+		Machines: []string{"stone_furnace"},
+	}
+	its.Equal(expectedRecipe, recipes["iron_plate"])
+	expectedRecipe = importer.GameRecipe{
+		Recipe:   model.RecipeInfo{Name: "sulfuric_acid", ProductionTimeS: 1, DefaultChoice: 1},
+		Inputs:   []importer.GameAmount{{Resource: "iron_plate", Amount: 1}, {Resource: "sulfur", Amount: 5}, {Resource: "water", Amount: 100}},
+		Outputs:  []importer.GameAmount{{Resource: "sulfuric_acid", Amount: 50}},
+		Machines: []string{"chemical_plant"},
+	}
+	its.Equal(expectedRecipe, recipes["sulfuric_acid"])
+	expectedRecipe = importer.GameRecipe{
+		Recipe:   model.RecipeInfo{Name: "lucky_plate", ProductionTimeS: 2, DefaultChoice: 1},
+		Inputs:   []importer.GameAmount{{Resource: "iron_ore", Amount: 4}},
+		Outputs:  []importer.GameAmount{{Resource: "iron_plate", Amount: 1}, {Resource: "copper_plate", Amount: 4}},
+		Machines: []string{"assembling_machine_2"},
+	}
+	its.Equal(expectedRecipe, recipes["lucky_plate"])
+	expectedRecipe = importer.GameRecipe{
+		Recipe:   model.RecipeInfo{Name: "uranium_ore_mining", ProductionTimeS: 2, DefaultChoice: 1},
+		Inputs:   []importer.GameAmount{{Resource: "sulfuric_acid", Amount: 1}},
+		Outputs:  []importer.GameAmount{{Resource: "uranium_ore", Amount: 1}},
+		Machines: []string{"electric_mining_drill"},
+	}
+	its.Equal(expectedRecipe, recipes["uranium_ore_mining"])
+	its.Equal(uint8(0), recipes["fill_water_barrel"].Recipe.DefaultChoice)
+	its.Empty(recipes["free_cable"].Inputs)
+	its.Equal([]importer.GameAmount{{Resource: "water", Amount: 1200}}, recipes["water_extraction_offshore_pump"].Outputs)
+	its.Equal([]importer.GameAmount{{Resource: "crude_oil", Amount: 10}}, recipes["crude_oil_mining"].Outputs)
+}
+
+func (its *ImporterTestSuite) TestParseFactorioDataRawExpensive() {
+	data, err := importer.ParseFactorioDataRaw(its.dataRaw, importer.FactorioExpensive)
+	its.Require().NoError(err)
+	for _, recipe := range data.Recipes {
+		if recipe.Recipe.Name == "iron_plate" {
+			its.Equal([]importer.GameAmount{{Resource: "iron_ore", Amount: 10}}, recipe.Inputs)
+			its.Equal([]importer.GameAmount{{Resource: "iron_plate", Amount: 5}}, recipe.Outputs)
+			return
+		}
+	}
+	its.Fail("recipe iron_plate has not been imported")
+}
+
+func (its *ImporterTestSuite) TestParseFactorioDataRawSkipped() {
+	expectedSkipped := []importer.SkippedEntry{
+		{Kind: importer.SkippedMachine, Name: "boiler", Reason: "machine type boiler is not supported"},
+		{Kind: importer.SkippedRecipe, Name: "basic-oil-processing", Reason: "recipe category 'oil-processing' is not crafted in any imported machine"},
+		{Kind: importer.SkippedRecipe, Name: "mystery", Reason: "'mystery-item' is neither an item nor a fluid"},
+		{Kind: importer.SkippedRecipe, Name: "stone mining", Reason: "resource category 'hard-solid' is not mined by any imported machine"},
+	}
+	data, err := importer.ParseFactorioDataRaw(its.dataRaw, importer.FactorioNormal)
+	its.Require().NoError(err)
+	its.Equal(expectedSkipped, data.Skipped)
+}
+
+func (its *ImporterTestSuite) TestParseFactorioDataRawInvalid() {
+	_, err := importer.ParseFactorioDataRaw([]byte("{\"item\": {}}"), importer.FactorioNormal)
+	its.Error(err)
+}
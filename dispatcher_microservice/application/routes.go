@@ -99,6 +99,17 @@ func (a *AppDispatcher) loadCrudRoutes(router chi.Router) {
 	router.Put("/", dispatcherHandlerCrud.Update)
 	router.Delete("/", dispatcherHandlerCrud.Delete)
 	router.Delete("/user", dispatcherHandlerCrud.DeleteByUser)
+	router.Get("/plans", dispatcherHandlerCrud.SelectPlans)
+	router.Post("/plans", dispatcherHandlerCrud.InsertPlans)
+	router.Put("/plans", dispatcherHandlerCrud.UpdatePlans)
+	router.Delete("/plans", dispatcherHandlerCrud.DeletePlans)
+	router.Post("/plans/recompute", dispatcherHandlerCrud.RecomputePlans)
+	router.Get("/progress", dispatcherHandlerCrud.SelectProgress)
+	router.Put("/progress", dispatcherHandlerCrud.UpdateProgress)
+	router.Post("/import/satisfactory", dispatcherHandlerCrud.ImportSatisfactory)
+	router.Post("/import/factorio", dispatcherHandlerCrud.ImportFactorio)
+	router.Get("/export", dispatcherHandlerCrud.Export)
+	router.Post("/import", dispatcherHandlerCrud.Import)
 	router.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL(fmt.Sprintf("https://%s/swagger/doc.json", dispatcherHandlerCrud.CrudMicroservicesAddresses[0])), //The url pointing to API definition
 	))
@@ -116,6 +127,9 @@ func (a *AppDispatcher) loadCalculatorRoutes(router chi.Router) {
 		CalculatorMicroservicesAddresses: a.calculatorMicroservicesAddresses,
 	}
 	router.Get("/calculate", dispatcherHandlerCalculator.Calculate)
+	router.Post("/calculate", dispatcherHandlerCalculator.CalculateMultiple)
+	router.Post("/calculate/compare", dispatcherHandlerCalculator.Compare)
+	router.Get("/calculate/sweep", dispatcherHandlerCalculator.Sweep)
 	router.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL(fmt.Sprintf("https://%s/swagger/doc.json", dispatcherHandlerCalculator.CalculatorMicroservicesAddresses[0])), //The url pointing to API definition
 	))
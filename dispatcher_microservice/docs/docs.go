@@ -24,7 +24,11 @@ const docTemplate = `{
     "paths": {
         "/calculator/calculate": {
             "get": {
-                "description": "Calculate the machines and resources needed to produce target resource with provided production rate per second. Alternative Recipe and Alternative Machine parameters can be present multiple times in request query.",
+                "description": "Calculate the machines and resources needed to produce target resource with provided production rate. Alternative Recipe, Alternative Machine, Supply and Transport Tier parameters can be present multiple times in request query.",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
                 "tags": [
                     "Calculator"
                 ],
@@ -45,10 +49,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Target production rate for the specified resource",
+                        "description": "Target production rate for the specified resource, required unless mode is 'maximize'",
                         "name": "rate",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
@@ -61,60 +64,85 @@ const docTemplate = `{
                         "description": "Alternative machine to take into consideration when calculating production tree",
                         "name": "alt_machine",
                         "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ProductionTreeCalculator"
-                        }
                     },
-                    "400": {
-                        "description": "Bad request. One of required parameters is missing",
-                        "schema": {
-                            "type": "string"
-                        }
+                    {
+                        "type": "string",
+                        "description": "Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts, 'maximize' ignores rate and calculates the highest rate achievable with supplied resources. Defaults to 'greedy'",
+                        "name": "mode",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Unexpected serverside error",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/crud": {
-            "put": {
-                "security": [
                     {
-                        "apiTokenAuth": []
-                    }
-                ],
-                "description": "Updates data in database. Updates the records based on \"id\" field of an element in the array sent in request body. If a record with a particular id does not belong to the user who presented authentication token, then that record is not updated.",
-                "consumes": [
-                    "application/json"
-                ],
-                "tags": [
-                    "CRUD Authorization required"
-                ],
-                "parameters": [
+                        "type": "string",
+                        "description": "Resource available at limited rate in 'maximize' mode, in format 'resource:rate', SuppliedResourcesPerSecond in response contains consumed rate of every supplied resource. Can be present multiple times",
+                        "name": "supply",
+                        "in": "query"
+                    },
                     {
-                        "description": "Data to be updated in the database",
-                        "name": "update",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.JSONDataCrud"
-                        }
+                        "type": "string",
+                        "description": "Format of the response, either 'json', 'dot' (Graphviz) or 'mermaid' diagram with edges labelled by resource, rate and lanes of transport tier. Defaults to 'json'",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'",
+                        "name": "objective",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption",
+                        "name": "power_target_mw",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'",
+                        "name": "cover_power",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers",
+                        "name": "transport_tier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'",
+                        "name": "split_nodes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'",
+                        "name": "sink_excess",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', BuildCost in summary totals resources needed to construct machines of the tree, MachinesWithoutBuildCost lists machines without known build cost. Defaults to 'false'",
+                        "name": "build_cost",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', Explanation of every node lists considered recipe and machine pairs and the rule that eliminated each of them. Defaults to 'false'",
+                        "name": "explain",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateResponseCrud"
+                            "$ref": "#/definitions/handler.ProductionTreeCalculator"
                         }
                     },
                     "400": {
@@ -123,12 +151,6 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Authentication error",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
                         "description": "Unexpected serverside error",
                         "schema": {
@@ -138,26 +160,110 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "security": [
-                    {
-                        "apiTokenAuth": []
-                    }
-                ],
-                "description": "Insert data into database. The user to whom the ownership of records is assigned is the user who presented the authentication token.",
+                "description": "Calculate the machines and resources needed to produce all target resources with provided production rates in one production tree, intermediate resources needed by several targets are produced by shared nodes. Alternative Recipe and Alternative Machine parameters can be present multiple times in request query.",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
                 "tags": [
-                    "CRUD Authorization required"
+                    "Calculator"
                 ],
                 "parameters": [
                     {
-                        "description": "Data to be inserted into database",
-                        "name": "insert",
+                        "type": "string",
+                        "description": "Id of users whose data will be used as the base for calculation",
+                        "name": "userid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative recipe to take into consideration when calculating production tree",
+                        "name": "alt_recipe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative machine to take into consideration when calculating production tree",
+                        "name": "alt_machine",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts. Defaults to 'greedy'",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the response, either 'json', 'dot' (Graphviz) or 'mermaid' diagram with edges labelled by resource, rate and lanes of transport tier. Defaults to 'json'",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'",
+                        "name": "objective",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption",
+                        "name": "power_target_mw",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'",
+                        "name": "cover_power",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers",
+                        "name": "transport_tier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'",
+                        "name": "split_nodes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'",
+                        "name": "sink_excess",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', BuildCost in summary totals resources needed to construct machines of the tree, MachinesWithoutBuildCost lists machines without known build cost. Defaults to 'false'",
+                        "name": "build_cost",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', Explanation of every node lists considered recipe and machine pairs and the rule that eliminated each of them. Defaults to 'false'",
+                        "name": "explain",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Resources to be produced and their target production rates",
+                        "name": "targets",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.JSONDataCrud"
+                            "$ref": "#/definitions/handler.CalculateMultipleInputCalculator"
                         }
                     }
                 ],
@@ -165,7 +271,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.InsertResponseCrud"
+                            "$ref": "#/definitions/handler.ProductionTreeCalculator"
                         }
                     },
                     "400": {
@@ -174,12 +280,6 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Authentication error",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
                         "description": "Unexpected serverside error",
                         "schema": {
@@ -187,28 +287,95 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "apiTokenAuth": []
-                    }
-                ],
-                "description": "Deletes data in database. Each table has it's own id list to be deleted. If a record with a particular id does not belong to the user who presented authentication token, then that record is not deleted.",
+            }
+        },
+        "/calculator/calculate/compare": {
+            "post": {
+                "description": "Calculate production trees producing all targets once for every scenario and compare them with the first scenario, which is the baseline. Scenarios list alternative recipes and machines used in addition to alt_recipe and alt_machine parameters.",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "CRUD Authorization required"
+                    "Calculator"
                 ],
                 "parameters": [
                     {
-                        "description": "Data to be deleted in the database",
-                        "name": "delete",
+                        "type": "string",
+                        "description": "Id of users whose data will be used as the base for calculation",
+                        "name": "userid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative recipe to take into consideration in every scenario",
+                        "name": "alt_recipe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative machine to take into consideration in every scenario",
+                        "name": "alt_machine",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts. Defaults to 'greedy'",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'",
+                        "name": "objective",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption",
+                        "name": "power_target_mw",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'",
+                        "name": "cover_power",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers",
+                        "name": "transport_tier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'",
+                        "name": "split_nodes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'",
+                        "name": "sink_excess",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Resources to be produced, their target production rates and compared scenarios",
+                        "name": "comparison",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.DeleteInputCrud"
+                            "$ref": "#/definitions/handler.CompareInputCalculator"
                         }
                     }
                 ],
@@ -216,7 +383,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.DeleteResponseCrud"
+                            "$ref": "#/definitions/handler.ComparisonCalculator"
                         }
                     },
                     "400": {
@@ -225,12 +392,6 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Authentication error",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
                         "description": "Unexpected serverside error",
                         "schema": {
@@ -240,88 +401,115 @@ const docTemplate = `{
                 }
             }
         },
-        "/crud/select": {
+        "/calculator/calculate/sweep": {
             "get": {
-                "security": [
-                    {
-                        "apiTokenAuth": []
-                    }
+                "description": "Calculate production trees producing target resource at every rate from rate_from to rate_to, increased by rate_step, and return machine counts and raw resources consumed at every rate. At most 1000 rates can be calculated at once.",
+                "produces": [
+                    "application/json"
                 ],
-                "description": "Return the records from database specified by id range. Start of range and it's size is specified for each table separately. Size describes number of records to be returned. Ranges include the starting id. Records are only returned for the user that presented authentication token. If start of the range is missing for particular table, then it is assumed to be 1. If size is ommitted, then all records are retreived. If the start of a range is a record belonging to another user, then next record belonging to the user that presented a token is retreived instead.",
                 "tags": [
-                    "CRUD Authorization required"
+                    "Calculator"
                 ],
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Id of first record to be retreived from machines table",
-                        "name": "machines_id_start",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Id of users whose data will be used as the base for calculation",
+                        "name": "userid",
+                        "in": "query",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of rows to be returned from machines table",
-                        "name": "machines_rows",
+                        "type": "string",
+                        "description": "Resource to be produced",
+                        "name": "resource",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First target production rate",
+                        "name": "rate_from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last target production rate",
+                        "name": "rate_to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Difference between consecutive target production rates",
+                        "name": "rate_step",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Raw resource available at limited rate, in format 'resource:rate', ExceededSupplies lists raw resources consumed faster than supplied. Can be present multiple times",
+                        "name": "supply",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Id of first record to be retreived from resources table",
-                        "name": "resources_id_start",
+                        "type": "string",
+                        "description": "Alternative recipe to take into consideration when calculating production tree",
+                        "name": "alt_recipe",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of rows to be returned from resources table",
-                        "name": "resources_rows",
+                        "type": "string",
+                        "description": "Alternative machine to take into consideration when calculating production tree",
+                        "name": "alt_machine",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Id of first record to be retreived from recipes table",
-                        "name": "recipes_id_start",
+                        "type": "string",
+                        "description": "Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts. Defaults to 'greedy'",
+                        "name": "mode",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of rows to be returned from recipes table",
-                        "name": "recipes_rows",
+                        "type": "string",
+                        "description": "Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'",
+                        "name": "unit",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Id of first record to be retreived from recipes_inputs table",
-                        "name": "recipes_inputs_id_start",
+                        "type": "string",
+                        "description": "Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'",
+                        "name": "objective",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of rows to be returned from recipes_inputs table",
-                        "name": "recipes_inputs_rows",
+                        "type": "string",
+                        "description": "Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption",
+                        "name": "power_target_mw",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Id of first record to be retreived from recipes_outputs table",
-                        "name": "recipes_outputs_id_start",
+                        "type": "string",
+                        "description": "If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'",
+                        "name": "cover_power",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of rows to be returned from recipes_outputs table",
-                        "name": "recipes_outputs_rows",
+                        "type": "string",
+                        "description": "Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers",
+                        "name": "transport_tier",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Id of first record to be retreived from machines_recipes table",
-                        "name": "machines_recipes_id_start",
+                        "type": "string",
+                        "description": "If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'",
+                        "name": "split_nodes",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of rows to be returned from machines_recipes table",
-                        "name": "machines_recipes_rows",
+                        "type": "string",
+                        "description": "If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'",
+                        "name": "sink_excess",
                         "in": "query"
                     }
                 ],
@@ -329,7 +517,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.JSONDataCrud"
+                            "$ref": "#/definitions/handler.SweepCalculator"
                         }
                     },
                     "400": {
@@ -338,12 +526,6 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Authentication error",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
                         "description": "Unexpected serverside error",
                         "schema": {
@@ -353,60 +535,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/crud/selectbyid": {
-            "get": {
+        "/crud": {
+            "put": {
                 "security": [
                     {
                         "apiTokenAuth": []
                     }
                 ],
-                "description": "Return the records from database specified by id. Id(s) is specified for each table in the database. If an id parameter for a particular table is omitted, the records are not retreived from that table. Each parameter can be present multiple times, in which case all records from a particular table, with those ids will be retreived and returned in an array. Data is returned for the user that provided authentication token.",
+                "description": "Updates data in database. Updates the records based on \"id\" field of an element in the array sent in request body. If a record with a particular id does not belong to the user who presented authentication token, then that record is not updated.\nAll data is updated in one transaction, nothing is updated if any record cannot be updated.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
                     "CRUD Authorization required"
                 ],
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Id of machines to be retreived from database",
-                        "name": "machines_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Id of resources to be retreived from database",
-                        "name": "resources_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Id of recipes to be retreived from database",
-                        "name": "recipes_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Id of recipes inputs to be retreived from database",
-                        "name": "recipes_inputs_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Id of recipes outputs to be retreived from database",
-                        "name": "recipes_outputs_id",
+                        "type": "boolean",
+                        "description": "If true, changes are validated and numbers of affected records are reported, but changes are rolled back",
+                        "name": "dry_run",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Id of machines recipes to be retreived from database",
-                        "name": "machines_recipes_id",
-                        "in": "query"
+                        "description": "Data to be updated in the database",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.JSONDataCrud"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.JSONDataCrud"
+                            "$ref": "#/definitions/handler.UpdateResponseCrud"
                         }
                     },
                     "400": {
@@ -428,24 +592,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/crud/user": {
-            "delete": {
+            },
+            "post": {
                 "security": [
                     {
                         "apiTokenAuth": []
                     }
                 ],
-                "description": "Deletes all data in the database that belongs to user who presented the authentication token.",
+                "description": "Insert data into database. The user to whom the ownership of records is assigned is the user who presented the authentication token.\nRecipes inputs, recipes outputs, machines recipes and machines costs can reference recipes, resources and machines by RecipesRef, ResourcesRef and MachinesRef fields instead of ids. A reference is resolved to the record with matching TempId inserted in the same request, otherwise to the record with matching name inserted in the same request, otherwise to the existing record of the user with matching name. All data is inserted in one transaction, nothing is inserted if any record cannot be inserted or any reference cannot be resolved.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
                     "CRUD Authorization required"
                 ],
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "If true, changes are validated and numbers of affected records are reported, but changes are rolled back",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Data to be inserted into database",
+                        "name": "insert",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.JSONDataCrud"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.DeleteResponseCrud"
+                            "$ref": "#/definitions/handler.InsertResponseCrud"
                         }
                     },
                     "400": {
@@ -467,19 +649,54 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "Return the status of microservice and it's database. Default working state is signified by status \"up\".",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Deletes data in database. Each table has it's own id list to be deleted. If a record with a particular id does not belong to the user who presented authentication token, then that record is not deleted.\nRecords are deleted in one transaction, nothing is deleted if any record cannot be deleted. Recipes inputs, recipes outputs, machines recipes and machines costs are deleted before machines, resources and recipes, so that records can be deleted together with records referencing them.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Dispatcher"
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "If true, changes are validated and numbers of affected records are reported, but changes are rolled back",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Data to be deleted in the database",
+                        "name": "delete",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.DeleteInputCrud"
+                        }
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.HealthResponse"
+                            "$ref": "#/definitions/handler.DeleteResponseCrud"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -491,17 +708,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/stats": {
+        "/crud/export": {
             "get": {
-                "description": "Return the usage stats of microservice.",
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Return unlock tiers, transport tiers, resources, machines, recipes, production plans and current tier of the user that provided authentication token as a self-contained bundle. Records of the bundle reference each other by names instead of database ids, build costs of machines and inputs, outputs and machines of recipes are nested in records they belong to. Bundle can be imported into any account with import endpoint.",
                 "tags": [
-                    "Dispatcher"
+                    "CRUD Authorization required"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.StatsResponse"
+                            "$ref": "#/definitions/handler.BundleCrud"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -513,28 +747,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
-            "put": {
+        "/crud/import": {
+            "post": {
                 "security": [
                     {
                         "apiTokenAuth": []
                     }
                 ],
-                "description": "Update user's data in database. The user whose data is updated is the user who presented the authentication token. Same login and password rules apply as when creating a new user account.",
+                "description": "Import bundle produced by export endpoint into account of the user that provided authentication token. Bundle must be of supported version, names must be unique among records of the same kind and every referenced name must belong to a record of the bundle. Strategy decides what happens to records with names already used by the user: 'merge' updates them with data of the bundle and replaces their build costs, inputs, outputs and machines, 'replace' deletes all data of the user before import and 'skip_existing' leaves them untouched. All data is imported in one transaction, response lists numbers of inserted, updated and skipped records.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users Authorization required"
+                    "CRUD Authorization required"
                 ],
                 "parameters": [
                     {
-                        "description": "New data of the user to be saved into database",
-                        "name": "updateUser",
+                        "type": "string",
+                        "description": "Conflict strategy, one of 'merge', 'replace' or 'skip_existing', skip_existing by default",
+                        "name": "strategy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "If true, bundle is validated and numbers of affected records are reported, but changes are rolled back",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Bundle to import",
+                        "name": "bundle",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.JSONDataUsers"
+                            "$ref": "#/definitions/handler.BundleCrud"
                         }
                     }
                 ],
@@ -542,7 +788,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateUserResponse"
+                            "$ref": "#/definitions/handler.BundleImportResponseCrud"
                         }
                     },
                     "400": {
@@ -564,23 +810,36 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/crud/import/factorio": {
             "post": {
-                "description": "Insert data of new user into database. Logins of every user must be unique. Passwords must be at least 8 characters long and maximum 72 characters long. Passwords must contain a lowercase and uppercase letter, a digit and a special character that is not a space, quote, double quote or semicolon. Logins must be at least 3 characters long and maximum 64 characters long. Logins cannot contain a space, quote, double quote or semicolon. Logins ignore letter case when logging in.",
-                "consumes": [
-                    "application/json"
-                ],
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Import recipes, items, fluids and machines from data.raw of Factorio, as dumped by the game with --dump-data option, and insert them as recipes, resources and machines of the user that provided authentication token. Assembling machines, furnaces and rocket silos run recipes of their crafting categories, mining drills and offshore pumps get recipes extracting resources. Liquid slots of machines are derived from their fluid boxes. Recipes not crafted in any imported machine are skipped. Amounts and durations are scaled by the smallest factor making all of them whole numbers. Machines and resources with names already used by the user are not inserted, existing records are used instead. Recipes with names already used by the user are skipped. All data is inserted in one transaction, response lists numbers of inserted records and skipped entries.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Users"
+                    "CRUD Authorization required"
                 ],
                 "parameters": [
                     {
-                        "description": "New user data to be inserted into database",
-                        "name": "createUser",
+                        "type": "string",
+                        "description": "Difficulty of recipes, either 'normal' or 'expensive', normal by default",
+                        "name": "difficulty",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Contents of data.raw dump",
+                        "name": "dataRaw",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.JSONDataUsers"
+                            "type": "object"
                         }
                     }
                 ],
@@ -588,7 +847,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateUserResponse"
+                            "$ref": "#/definitions/handler.ImportResponseCrud"
                         }
                     },
                     "400": {
@@ -597,6 +856,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Unexpected serverside error",
                         "schema": {
@@ -604,22 +869,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/crud/import/satisfactory": {
+            "post": {
                 "security": [
                     {
                         "apiTokenAuth": []
                     }
                 ],
-                "description": "Delete user's data in database. The user whose data is deleted is the user who presented the authentication token.",
+                "description": "Import buildings, items, fluids and recipes from Docs.json file distributed with Satisfactory and insert them as machines, resources and recipes of the user that provided authentication token. Manufacturers, extractors and fuel generators are imported, recipes not produced in any imported machine are skipped. Amounts and durations are scaled by the smallest factor making all of them whole numbers. Machines and resources with names already used by the user are not inserted, existing records are used instead. Recipes with names already used by the user are skipped. All data is inserted in one transaction, response lists numbers of inserted records and skipped entries.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Users Authorization required"
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "description": "Contents of Docs.json, UTF-16 and UTF-8 encodings are accepted",
+                        "name": "docs",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.DeleteUserResponse"
+                            "$ref": "#/definitions/handler.ImportResponseCrud"
                         }
                     },
                     "400": {
@@ -643,23 +924,85 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/login": {
-            "post": {
-                "description": "Authenticate users against the database. If verification is successfull a jwt(authentication token) is returned, that can be used to prove the user's identity to other microservices in Factory Games Organizer api.",
+        "/crud/plans": {
+            "get": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Return production plans saved by the user that provided authentication token, together with their targets, alternative recipes, alternative machines and production trees calculated when plans were saved or last recomputed. If plans_id parameter is present, only plans with those ids are returned, otherwise plans are returned from id range, specified in the same way as in select endpoint.",
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Id of plans to be retreived from database. Can be present multiple times",
+                        "name": "plans_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from plans table",
+                        "name": "plans_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from plans table",
+                        "name": "plans_rows",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PlansDataCrud"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Update names, targets, alternative recipes and alternative machines of saved production plans, based on \"id\" field of every plan sent in request body. Production tree of every plan is calculated again with updated data. If a plan with a particular id does not belong to the user who presented authentication token, then that plan is not updated.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "CRUD Authorization required"
                 ],
                 "parameters": [
                     {
-                        "description": "Login data for the user.",
-                        "name": "login",
+                        "description": "Plans to be updated",
+                        "name": "plans",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.JSONDataUsers"
+                            "$ref": "#/definitions/handler.PlansDataCrud"
                         }
                     }
                 ],
@@ -667,11 +1010,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.LoginResponse"
+                            "$ref": "#/definitions/handler.PlansUpdateResponseCrud"
                         }
                     },
                     "400": {
-                        "description": "Bad request. One of required parameters is missing or is not of valid format or invalid login data has been sent",
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
                         "schema": {
                             "type": "string"
                         }
@@ -683,200 +1032,1947 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "handler.CreateUserResponse": {
-            "type": "object",
-            "properties": {
-                "usersCreated": {
-                    "type": "integer"
-                }
-            }
-        },
-        "handler.DeleteInputCrud": {
-            "type": "object",
-            "properties": {
-                "machinesIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
+            },
+            "post": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
                     }
-                },
-                "machinesRecipesIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
+                ],
+                "description": "Save named production plans of the user that provided authentication token. For every plan, production tree producing its targets with its alternative recipes and alternative machines is calculated by calculator microservice using current data of the user and saved with the plan. Ids, users ids and production trees sent in request body are ignored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "description": "Plans to be saved",
+                        "name": "plans",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PlansDataCrud"
+                        }
                     }
-                },
-                "recipesIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PlansInsertResponseCrud"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
-                },
-                "recipesInputsIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
                     }
-                },
-                "recipesOutputsIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
+                ],
+                "description": "Delete saved production plans with provided ids. If a plan with a particular id does not belong to the user who presented authentication token, then that plan is not deleted.",
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Id of plan to be deleted. Can be present multiple times",
+                        "name": "plans_id",
+                        "in": "query",
+                        "required": true
                     }
-                },
-                "resourcesIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PlansDeleteResponseCrud"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
-        "handler.DeleteResponseCrud": {
-            "type": "object",
-            "properties": {
-                "machinesDeleted": {
-                    "type": "integer"
-                },
-                "machinesRecipesDeleted": {
-                    "type": "integer"
-                },
-                "recipesDeleted": {
-                    "type": "integer"
-                },
-                "recipesInputsDeleted": {
-                    "type": "integer"
-                },
-                "recipesOutputsDeleted": {
-                    "type": "integer"
+        "/crud/plans/recompute": {
+            "post": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Calculate production trees of saved production plans again, using current recipes, machines and resources of the user that provided authentication token. If plans_id parameter is present, only plans with those ids are recomputed, otherwise all plans of the user are. Changed is true for plans whose production tree differs from the saved one, new production trees of such plans are saved.",
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Id of plan to be recomputed. Can be present multiple times",
+                        "name": "plans_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PlansRecomputeResponseCrud"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/crud/progress": {
+            "get": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Return current progression tier of the user that provided authentication token. Recipes and machines assigned to unlock tiers with tier number higher than current tier are not used by calculator microservice. If current tier has not been set, all recipes and machines are unlocked and status 404 is returned.",
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ProgressInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Current tier has not been set",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Set current progression tier of the user that provided authentication token. Recipes and machines are unlocked when tier number of their unlock tier is not higher than current tier, recipes and machines without unlock tier are always unlocked. Users id sent in request body is ignored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "description": "Current tier of the user",
+                        "name": "progress",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ProgressInfo"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ProgressUpdateResponseCrud"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/crud/select": {
+            "get": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Return the records from database specified by id range. Start of range and it's size is specified for each table separately. Size describes number of records to be returned. Ranges include the starting id. Records are only returned for the user that presented authentication token. If start of the range is missing for particular table, then it is assumed to be 1. If size is ommitted, then all records are retreived. If the start of a range is a record belonging to another user, then next record belonging to the user that presented a token is retreived instead.",
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from machines table",
+                        "name": "machines_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from machines table",
+                        "name": "machines_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from resources table",
+                        "name": "resources_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from resources table",
+                        "name": "resources_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from recipes table",
+                        "name": "recipes_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from recipes table",
+                        "name": "recipes_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from recipes_inputs table",
+                        "name": "recipes_inputs_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from recipes_inputs table",
+                        "name": "recipes_inputs_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from recipes_outputs table",
+                        "name": "recipes_outputs_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from recipes_outputs table",
+                        "name": "recipes_outputs_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from machines_recipes table",
+                        "name": "machines_recipes_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from machines_recipes table",
+                        "name": "machines_recipes_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from transport_tiers table",
+                        "name": "transport_tiers_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from transport_tiers table",
+                        "name": "transport_tiers_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from unlock_tiers table",
+                        "name": "unlock_tiers_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from unlock_tiers table",
+                        "name": "unlock_tiers_rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of first record to be retreived from machines_costs table",
+                        "name": "machines_costs_id_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to be returned from machines_costs table",
+                        "name": "machines_costs_rows",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.JSONDataCrud"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/crud/selectbyid": {
+            "get": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Return the records from database specified by id. Id(s) is specified for each table in the database. If an id parameter for a particular table is omitted, the records are not retreived from that table. Each parameter can be present multiple times, in which case all records from a particular table, with those ids will be retreived and returned in an array. Data is returned for the user that provided authentication token.",
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Id of machines to be retreived from database",
+                        "name": "machines_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of resources to be retreived from database",
+                        "name": "resources_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of recipes to be retreived from database",
+                        "name": "recipes_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of recipes inputs to be retreived from database",
+                        "name": "recipes_inputs_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of recipes outputs to be retreived from database",
+                        "name": "recipes_outputs_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of machines recipes to be retreived from database",
+                        "name": "machines_recipes_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of transport tiers to be retreived from database",
+                        "name": "transport_tiers_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of unlock tiers to be retreived from database",
+                        "name": "unlock_tiers_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of machines costs to be retreived from database",
+                        "name": "machines_costs_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.JSONDataCrud"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/crud/user": {
+            "delete": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Deletes all data in the database that belongs to user who presented the authentication token.",
+                "tags": [
+                    "CRUD Authorization required"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.DeleteResponseCrud"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Return the status of microservice and it's database. Default working state is signified by status \"up\".",
+                "tags": [
+                    "Dispatcher"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HealthResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Return the usage stats of microservice.",
+                "tags": [
+                    "Dispatcher"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.StatsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "put": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Update user's data in database. The user whose data is updated is the user who presented the authentication token. Same login and password rules apply as when creating a new user account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users Authorization required"
+                ],
+                "parameters": [
+                    {
+                        "description": "New data of the user to be saved into database",
+                        "name": "updateUser",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.JSONDataUsers"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Insert data of new user into database. Logins of every user must be unique. Passwords must be at least 8 characters long and maximum 72 characters long. Passwords must contain a lowercase and uppercase letter, a digit and a special character that is not a space, quote, double quote or semicolon. Logins must be at least 3 characters long and maximum 64 characters long. Logins cannot contain a space, quote, double quote or semicolon. Logins ignore letter case when logging in.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "parameters": [
+                    {
+                        "description": "New user data to be inserted into database",
+                        "name": "createUser",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.JSONDataUsers"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "apiTokenAuth": []
+                    }
+                ],
+                "description": "Delete user's data in database. The user whose data is deleted is the user who presented the authentication token.",
+                "tags": [
+                    "Users Authorization required"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.DeleteUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/login": {
+            "post": {
+                "description": "Authenticate users against the database. If verification is successfull a jwt(authentication token) is returned, that can be used to prove the user's identity to other microservices in Factory Games Organizer api.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "parameters": [
+                    {
+                        "description": "Login data for the user.",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.JSONDataUsers"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request. One of required parameters is missing or is not of valid format or invalid login data has been sent",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected serverside error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handler.BundleAmountCrud": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "resource": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.BundleCrud": {
+            "type": "object",
+            "properties": {
+                "currentTier": {
+                    "type": "integer"
+                },
+                "machines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.BundleMachineCrud"
+                    }
+                },
+                "plans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.BundlePlanCrud"
+                    }
+                },
+                "recipes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.BundleRecipeCrud"
+                    }
+                },
+                "resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.BundleResourceCrud"
+                    }
+                },
+                "transportTiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.BundleTransportTierCrud"
+                    }
+                },
+                "unlockTiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.BundleUnlockTierCrud"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.BundleImportCountsCrud": {
+            "type": "object",
+            "properties": {
+                "inserted": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.BundleImportResponseCrud": {
+            "type": "object",
+            "properties": {
+                "machines": {
+                    "$ref": "#/definitions/handler.BundleImportCountsCrud"
+                },
+                "plans": {
+                    "$ref": "#/definitions/handler.BundleImportCountsCrud"
+                },
+                "progress": {
+                    "$ref": "#/definitions/handler.BundleImportCountsCrud"
+                },
+                "recipes": {
+                    "$ref": "#/definitions/handler.BundleImportCountsCrud"
+                },
+                "resources": {
+                    "$ref": "#/definitions/handler.BundleImportCountsCrud"
+                },
+                "transportTiers": {
+                    "$ref": "#/definitions/handler.BundleImportCountsCrud"
+                },
+                "unlockTiers": {
+                    "$ref": "#/definitions/handler.BundleImportCountsCrud"
+                }
+            }
+        },
+        "handler.BundleMachineCrud": {
+            "type": "object",
+            "properties": {
+                "buildCost": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.BundleAmountCrud"
+                    }
+                },
+                "defaultChoice": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "inputsLiquid": {
+                    "type": "integer"
+                },
+                "inputsSolid": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "outputsLiquid": {
+                    "type": "integer"
+                },
+                "outputsSolid": {
+                    "type": "integer"
+                },
+                "powerClockExponent": {
+                    "description": "PowerClockExponent is the exponent of clock speed in power consumption, 0 is stored as 1",
+                    "type": "number",
+                    "format": "float32"
+                },
+                "powerConsumptionKw": {
+                    "type": "integer"
+                },
+                "powerGenerationKw": {
+                    "type": "integer"
+                },
+                "speed": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "unlockTier": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.BundlePlanCrud": {
+            "type": "object",
+            "properties": {
+                "altMachines": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "altRecipes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "productionTree": {
+                    "type": "object"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.PlanTarget"
+                    }
+                }
+            }
+        },
+        "handler.BundleRecipeCrud": {
+            "type": "object",
+            "properties": {
+                "defaultChoice": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "inputs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.BundleAmountCrud"
+                    }
+                },
+                "machines": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "outputs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.BundleAmountCrud"
+                    }
+                },
+                "productionTimeS": {
+                    "type": "integer"
+                },
+                "unlockTier": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.BundleResourceCrud": {
+            "type": "object",
+            "properties": {
+                "liquid": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "resourceUnit": {
+                    "type": "string"
+                },
+                "sinkValue": {
+                    "type": "number",
+                    "format": "float32"
+                }
+            }
+        },
+        "handler.BundleTransportTierCrud": {
+            "type": "object",
+            "properties": {
+                "capacityPerS": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "liquid": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.BundleUnlockTierCrud": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "tierNumber": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.CalculateMultipleInputCalculator": {
+            "type": "object",
+            "properties": {
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.CalculationTargetCalculator"
+                    }
+                }
+            }
+        },
+        "handler.CalculationTargetCalculator": {
+            "type": "object",
+            "properties": {
+                "rate": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "resource": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CandidateExplanation": {
+            "type": "object",
+            "properties": {
+                "chosen": {
+                    "type": "boolean"
+                },
+                "machineName": {
+                    "type": "string"
+                },
+                "recipeName": {
+                    "type": "string"
+                },
+                "rejection": {
+                    "type": "string"
+                },
+                "resourcePerSecond": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "resourcePerUnit": {
+                    "type": "number",
+                    "format": "float32"
+                }
+            }
+        },
+        "handler.CompareInputCalculator": {
+            "type": "object",
+            "properties": {
+                "scenarios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ComparisonScenarioCalculator"
+                    }
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.CalculationTargetCalculator"
+                    }
+                }
+            }
+        },
+        "handler.ComparisonCalculator": {
+            "type": "object",
+            "properties": {
+                "baseline": {
+                    "type": "string"
+                },
+                "rateUnit": {
+                    "type": "string"
+                },
+                "resourceUnits": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "scenarios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ScenarioComparisonCalculator"
+                    }
+                }
+            }
+        },
+        "handler.ComparisonScenarioCalculator": {
+            "type": "object",
+            "properties": {
+                "altMachines": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "altRecipes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateUserResponse": {
+            "type": "object",
+            "properties": {
+                "usersCreated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.DeleteInputCrud": {
+            "type": "object",
+            "properties": {
+                "machinesCostsIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "machinesIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "machinesRecipesIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "recipesIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "recipesInputsIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "recipesOutputsIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "resourcesIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "transportTiersIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "unlockTiersIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "handler.DeleteResponseCrud": {
+            "type": "object",
+            "properties": {
+                "machinesCostsDeleted": {
+                    "type": "integer"
+                },
+                "machinesDeleted": {
+                    "type": "integer"
+                },
+                "machinesRecipesDeleted": {
+                    "type": "integer"
+                },
+                "plansDeleted": {
+                    "type": "integer"
+                },
+                "progressDeleted": {
+                    "type": "integer"
+                },
+                "recipesDeleted": {
+                    "type": "integer"
+                },
+                "recipesInputsDeleted": {
+                    "type": "integer"
+                },
+                "recipesOutputsDeleted": {
+                    "type": "integer"
+                },
+                "resourcesDeleted": {
+                    "type": "integer"
+                },
+                "transportTiersDeleted": {
+                    "type": "integer"
+                },
+                "unlockTiersDeleted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.DeleteUserResponse": {
+            "type": "object",
+            "properties": {
+                "usersDeleted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "calculatorMicroservice": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.MicroserviceHealth"
+                    }
+                },
+                "crudMicroservice": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.MicroserviceHealth"
+                    }
+                },
+                "dispatcherStatus": {
+                    "type": "string"
+                },
+                "usersMicroservice": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.MicroserviceHealth"
+                    }
+                }
+            }
+        },
+        "handler.ImportResponseCrud": {
+            "type": "object",
+            "properties": {
+                "machinesInserted": {
+                    "type": "integer"
+                },
+                "machinesRecipesInserted": {
+                    "type": "integer"
+                },
+                "recipesInputsInserted": {
+                    "type": "integer"
+                },
+                "recipesInserted": {
+                    "type": "integer"
+                },
+                "recipesOutputsInserted": {
+                    "type": "integer"
+                },
+                "resourcesInserted": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SkippedEntryCrud"
+                    }
+                }
+            }
+        },
+        "handler.InsertResponseCrud": {
+            "type": "object",
+            "properties": {
+                "machinesCostsInserted": {
+                    "type": "integer"
+                },
+                "machinesInserted": {
+                    "type": "integer"
+                },
+                "machinesRecipesInserted": {
+                    "type": "integer"
+                },
+                "recipesInputsInserted": {
+                    "type": "integer"
+                },
+                "recipesInserted": {
+                    "type": "integer"
+                },
+                "recipesOutputsInserted": {
+                    "type": "integer"
+                },
+                "resourcesInserted": {
+                    "type": "integer"
+                },
+                "transportTiersInserted": {
+                    "type": "integer"
+                },
+                "unlockTiersInserted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.JSONDataCrud": {
+            "type": "object",
+            "properties": {
+                "machinesCostsList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.MachineCostInfo"
+                    }
+                },
+                "machinesList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.MachineInfo"
+                    }
+                },
+                "machinesRecipesList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.MachinesRecipesInfo"
+                    }
+                },
+                "recipesInputsList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.RecipeInputOutputInfo"
+                    }
+                },
+                "recipesList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.RecipeInfo"
+                    }
+                },
+                "recipesOutputsList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.RecipeInputOutputInfo"
+                    }
+                },
+                "resourcesList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ResourceInfo"
+                    }
+                },
+                "transportTiersList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.TransportTierInfo"
+                    }
+                },
+                "unlockTiersList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.UnlockTierInfo"
+                    }
+                }
+            }
+        },
+        "handler.JSONDataUsers": {
+            "type": "object",
+            "properties": {
+                "userLogin": {
+                    "type": "string"
+                },
+                "userPassword": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "jwt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.MachineCostInfo": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "machinesId": {
+                    "type": "integer"
+                },
+                "machinesRef": {
+                    "description": "MachinesRef and ResourcesRef reference machine and resource by temporary id or name instead of id",
+                    "type": "string"
+                },
+                "resourcesId": {
+                    "type": "integer"
+                },
+                "resourcesRef": {
+                    "type": "string"
+                },
+                "usersId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.MachineInfo": {
+            "type": "object",
+            "properties": {
+                "defaultChoice": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inputsLiquid": {
+                    "type": "integer"
+                },
+                "inputsSolid": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "outputsLiquid": {
+                    "type": "integer"
+                },
+                "outputsSolid": {
+                    "type": "integer"
+                },
+                "powerClockExponent": {
+                    "description": "PowerClockExponent is the exponent of clock speed in power consumption, 0 is stored as 1",
+                    "type": "number",
+                    "format": "float32"
+                },
+                "powerConsumptionKw": {
+                    "type": "integer"
+                },
+                "powerGenerationKw": {
+                    "type": "integer"
+                },
+                "speed": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "tempId": {
+                    "description": "TempId is a temporary id of machine inserted in the same request as records referencing it",
+                    "type": "string"
+                },
+                "unlockTiersId": {
+                    "type": "integer"
+                },
+                "usersId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.MachineSummaryCalculator": {
+            "type": "object",
+            "properties": {
+                "machineCount": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "machineName": {
+                    "type": "string"
+                },
+                "machineNumber": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "totalPowerConsumedkW": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "handler.MachinesRecipesInfo": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "machinesId": {
+                    "type": "integer"
+                },
+                "machinesRef": {
+                    "type": "string"
+                },
+                "recipesId": {
+                    "type": "integer"
+                },
+                "recipesRef": {
+                    "description": "RecipesRef and MachinesRef reference recipe and machine by temporary id or name instead of id",
+                    "type": "string"
+                },
+                "usersId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.MicroserviceHealth": {
+            "type": "object",
+            "properties": {
+                "databaseStatus": {
+                    "type": "string"
+                },
+                "microserviceStatus": {
+                    "type": "string"
+                },
+                "microserviceURL": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.NodeExplanation": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.CandidateExplanation"
+                    }
+                },
+                "resource": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.PlanInfo": {
+            "type": "object",
+            "properties": {
+                "altMachines": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "altRecipes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "productionTree": {
+                    "type": "object"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.PlanTarget"
+                    }
+                },
+                "usersId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.PlanTarget": {
+            "type": "object",
+            "properties": {
+                "rate": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "resource": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.PlansDataCrud": {
+            "type": "object",
+            "properties": {
+                "plansList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.PlanInfo"
+                    }
+                }
+            }
+        },
+        "handler.PlansDeleteResponseCrud": {
+            "type": "object",
+            "properties": {
+                "plansDeleted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.PlansInsertResponseCrud": {
+            "type": "object",
+            "properties": {
+                "plansInserted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.PlansRecomputeResponseCrud": {
+            "type": "object",
+            "properties": {
+                "plansList": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.RecomputedPlanCrud"
+                    }
+                }
+            }
+        },
+        "handler.PlansUpdateResponseCrud": {
+            "type": "object",
+            "properties": {
+                "plansUpdated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ProductionEdge": {
+            "type": "object",
+            "properties": {
+                "lanes": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "resourcePerSecond": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "resourcePerUnit": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "sourceNode": {
+                    "type": "integer"
+                },
+                "targetNode": {
+                    "type": "integer"
+                },
+                "transportTier": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ProductionSummaryCalculator": {
+            "type": "object",
+            "properties": {
+                "buildCost": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "byproductsPerSecond": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "byproductsPerUnit": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "machines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.MachineSummaryCalculator"
+                    }
+                },
+                "machinesWithoutBuildCost": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "powerBalancekW": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "rawResourcesPerSecond": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "rawResourcesPerUnit": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "sinkPointsPerSecond": {
+                    "type": "number",
+                    "format": "float32"
                 },
-                "resourcesDeleted": {
-                    "type": "integer"
+                "sinkPointsPerUnit": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "sunkResourcesPerSecond": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "sunkResourcesPerUnit": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "totalPowerConsumedkW": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "totalPowerGeneratedkW": {
+                    "type": "integer",
+                    "format": "int64"
                 }
             }
         },
-        "handler.DeleteUserResponse": {
+        "handler.ProductionTargetCalculator": {
             "type": "object",
             "properties": {
-                "usersDeleted": {
+                "rate": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "ratePerUnit": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "sourceNode": {
                     "type": "integer"
                 }
             }
         },
-        "handler.HealthResponse": {
+        "handler.ProductionTreeCalculator": {
             "type": "object",
             "properties": {
-                "calculatorMicroservice": {
+                "edges": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handler.MicroserviceHealth"
+                        "$ref": "#/definitions/handler.ProductionEdge"
                     }
                 },
-                "crudMicroservice": {
+                "excessResources": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handler.MicroserviceHealth"
+                        "$ref": "#/definitions/handler.ResourceSource"
                     }
                 },
-                "dispatcherStatus": {
+                "rateUnit": {
                     "type": "string"
                 },
-                "usersMicroservice": {
+                "rejectedPairings": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handler.MicroserviceHealth"
+                        "$ref": "#/definitions/handler.RejectedPairing"
                     }
-                }
-            }
-        },
-        "handler.InsertResponseCrud": {
-            "type": "object",
-            "properties": {
-                "machinesInserted": {
-                    "type": "integer"
                 },
-                "machinesRecipesInserted": {
-                    "type": "integer"
+                "resourceUnits": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "recipesInputsInserted": {
-                    "type": "integer"
+                "summary": {
+                    "$ref": "#/definitions/handler.ProductionSummaryCalculator"
                 },
-                "recipesInserted": {
-                    "type": "integer"
+                "suppliedResourcesPerSecond": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
                 },
-                "recipesOutputsInserted": {
-                    "type": "integer"
+                "suppliedResourcesPerUnit": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
                 },
-                "resourcesInserted": {
+                "targetResource": {
+                    "type": "string"
+                },
+                "targetResourceRate": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "targetResourceRatePerUnit": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "targetResourceSourceNode": {
                     "type": "integer"
-                }
-            }
-        },
-        "handler.JSONDataCrud": {
-            "type": "object",
-            "properties": {
-                "machinesList": {
+                },
+                "targets": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handler.MachineInfo"
+                        "$ref": "#/definitions/handler.ProductionTargetCalculator"
                     }
                 },
-                "machinesRecipesList": {
+                "treeNodes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handler.MachinesRecipesInfo"
+                        "$ref": "#/definitions/handler.ProductionTreeNode"
                     }
+                }
+            }
+        },
+        "handler.ProductionTreeNode": {
+            "type": "object",
+            "properties": {
+                "clockPercentage": {
+                    "type": "number",
+                    "format": "float32"
                 },
-                "recipesInputsList": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handler.RecipeInputOutputInfo"
+                "explanation": {
+                    "$ref": "#/definitions/handler.NodeExplanation"
+                },
+                "machineCount": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "machineName": {
+                    "type": "string"
+                },
+                "machineNumber": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "nodeId": {
+                    "type": "integer"
+                },
+                "powerGeneratedkW": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "producedResourcesPerSecond": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
                     }
                 },
-                "recipesList": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handler.RecipeInfo"
+                "producedResourcesPerUnit": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
                     }
                 },
-                "recipesOutputsList": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handler.RecipeInputOutputInfo"
+                "recipeName": {
+                    "type": "string"
+                },
+                "requiredResourcesPerSecond": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
                     }
                 },
-                "resourcesList": {
+                "requiredResourcesPerUnit": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "sinkPointsPerSecond": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "sinkPointsPerUnit": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "sourceNodes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handler.ResourceInfo"
+                        "type": "integer"
                     }
+                },
+                "sunkResource": {
+                    "type": "string"
+                },
+                "totalPowerConsumedkW": {
+                    "type": "integer",
+                    "format": "int64"
                 }
             }
         },
-        "handler.JSONDataUsers": {
+        "handler.ProgressInfo": {
             "type": "object",
             "properties": {
-                "userLogin": {
-                    "type": "string"
+                "currentTier": {
+                    "type": "integer"
                 },
-                "userPassword": {
-                    "type": "string"
+                "usersId": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.LoginResponse": {
+        "handler.ProgressUpdateResponseCrud": {
             "type": "object",
             "properties": {
-                "jwt": {
-                    "type": "string"
+                "progressUpdated": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.MachineInfo": {
+        "handler.RecipeInfo": {
             "type": "object",
             "properties": {
                 "defaultChoice": {
@@ -886,229 +2982,418 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
-                "inputsLiquid": {
+                "name": {
+                    "type": "string"
+                },
+                "productionTimeS": {
                     "type": "integer"
                 },
-                "inputsSolid": {
+                "tempId": {
+                    "description": "TempId is a temporary id of recipe inserted in the same request as records referencing it",
+                    "type": "string"
+                },
+                "unlockTiersId": {
+                    "type": "integer"
+                },
+                "usersId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.RecipeInputOutputInfo": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "recipesId": {
+                    "type": "integer"
+                },
+                "recipesRef": {
+                    "description": "RecipesRef and ResourcesRef reference recipe and resource by temporary id or name instead of id",
+                    "type": "string"
+                },
+                "resourcesId": {
+                    "type": "integer"
+                },
+                "resourcesRef": {
+                    "type": "string"
+                },
+                "usersId": {
                     "type": "integer"
+                }
+            }
+        },
+        "handler.RecomputedPlanCrud": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "productionTree": {
+                    "type": "object"
+                }
+            }
+        },
+        "handler.RejectedPairing": {
+            "type": "object",
+            "properties": {
+                "machineName": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "recipeName": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ResourceDeltaCalculator": {
+            "type": "object",
+            "properties": {
+                "baselinePerSecond": {
+                    "type": "number",
+                    "format": "float32"
                 },
-                "name": {
-                    "type": "string"
+                "baselinePerUnit": {
+                    "type": "number",
+                    "format": "float32"
                 },
-                "outputsLiquid": {
-                    "type": "integer"
+                "deltaPerSecond": {
+                    "type": "number",
+                    "format": "float32"
                 },
-                "outputsSolid": {
-                    "type": "integer"
+                "deltaPerUnit": {
+                    "type": "number",
+                    "format": "float32"
                 },
-                "powerConsumptionKw": {
-                    "type": "integer"
+                "kind": {
+                    "type": "string"
                 },
-                "speed": {
+                "resource": {
+                    "type": "string"
+                },
+                "scenarioPerSecond": {
                     "type": "number",
                     "format": "float32"
                 },
-                "usersId": {
-                    "type": "integer"
+                "scenarioPerUnit": {
+                    "type": "number",
+                    "format": "float32"
                 }
             }
         },
-        "handler.MachinesRecipesInfo": {
+        "handler.ResourceInfo": {
             "type": "object",
             "properties": {
                 "id": {
                     "type": "integer"
                 },
-                "machinesId": {
-                    "type": "integer"
+                "liquid": {
+                    "type": "integer",
+                    "format": "int32"
                 },
-                "recipesId": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
+                },
+                "resourceUnit": {
+                    "type": "string"
+                },
+                "sinkValue": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "tempId": {
+                    "description": "TempId is a temporary id of resource inserted in the same request as records referencing it",
+                    "type": "string"
                 },
                 "usersId": {
                     "type": "integer"
                 }
             }
         },
-        "handler.MicroserviceHealth": {
+        "handler.ResourceSource": {
             "type": "object",
             "properties": {
-                "databaseStatus": {
-                    "type": "string"
+                "excessProducedResourcePerSecond": {
+                    "type": "number",
+                    "format": "float32"
                 },
-                "microserviceStatus": {
+                "excessProducedResourcePerUnit": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "excessResourceName": {
                     "type": "string"
                 },
-                "microserviceURL": {
+                "lanes": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "nodeId": {
+                    "type": "integer"
+                },
+                "transportTier": {
                     "type": "string"
                 }
             }
         },
-        "handler.ProductionTreeCalculator": {
+        "handler.ScenarioComparisonCalculator": {
             "type": "object",
             "properties": {
-                "excessResources": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handler.ResourceSource"
+                "byproductsPerSecond": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
                     }
                 },
-                "targetResource": {
-                    "type": "string"
+                "byproductsPerUnit": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
                 },
-                "targetResourceRate": {
+                "machineCount": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "machineCountDelta": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "machineNumber": {
                     "type": "number",
                     "format": "float32"
                 },
-                "targetResourceSourceNode": {
-                    "type": "integer"
+                "machineNumberDelta": {
+                    "type": "number",
+                    "format": "float32"
                 },
-                "treeNodes": {
+                "machines": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handler.ProductionTreeNode"
+                        "$ref": "#/definitions/handler.MachineSummaryCalculator"
                     }
-                }
-            }
-        },
-        "handler.ProductionTreeNode": {
-            "type": "object",
-            "properties": {
-                "machineName": {
+                },
+                "name": {
                     "type": "string"
                 },
-                "machineNumber": {
-                    "type": "number",
-                    "format": "float32"
+                "powerBalancekW": {
+                    "type": "integer",
+                    "format": "int64"
                 },
-                "nodeId": {
-                    "type": "integer"
+                "powerBalancekWDelta": {
+                    "type": "integer",
+                    "format": "int64"
                 },
-                "producedResourcesPerSecond": {
+                "rawResourcesPerSecond": {
                     "type": "object",
                     "additionalProperties": {
                         "type": "number",
                         "format": "float32"
                     }
                 },
-                "recipeName": {
-                    "type": "string"
-                },
-                "requiredResourcesPerSecond": {
+                "rawResourcesPerUnit": {
                     "type": "object",
                     "additionalProperties": {
                         "type": "number",
                         "format": "float32"
                     }
                 },
-                "sourceNodes": {
+                "resourceDeltas": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/handler.ResourceDeltaCalculator"
                     }
                 },
                 "totalPowerConsumedkW": {
                     "type": "integer",
                     "format": "int64"
+                },
+                "totalPowerConsumedkWDelta": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "totalPowerGeneratedkW": {
+                    "type": "integer",
+                    "format": "int64"
                 }
             }
         },
-        "handler.RecipeInfo": {
+        "handler.SkippedEntryCrud": {
             "type": "object",
             "properties": {
-                "defaultChoice": {
-                    "type": "integer",
-                    "format": "int32"
-                },
-                "id": {
-                    "type": "integer"
+                "kind": {
+                    "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "productionTimeS": {
-                    "type": "integer"
-                },
-                "usersId": {
-                    "type": "integer"
+                "reason": {
+                    "type": "string"
                 }
             }
         },
-        "handler.RecipeInputOutputInfo": {
+        "handler.StatsResponse": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "integer"
+                "apiUsageStats": {
+                    "type": "object"
                 },
-                "id": {
-                    "type": "integer"
+                "noPeriods": {
+                    "type": "integer",
+                    "format": "int64"
                 },
-                "recipesId": {
-                    "type": "integer"
+                "trackingPeriodMs": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "handler.SweepCalculator": {
+            "type": "object",
+            "properties": {
+                "rateUnit": {
+                    "type": "string"
                 },
-                "resourcesId": {
-                    "type": "integer"
+                "resource": {
+                    "type": "string"
                 },
-                "usersId": {
-                    "type": "integer"
+                "resourceUnits": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SweepStepCalculator"
+                    }
                 }
             }
         },
-        "handler.ResourceInfo": {
+        "handler.SweepNodeCalculator": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
-                },
-                "liquid": {
+                "machineCount": {
                     "type": "integer",
-                    "format": "int32"
+                    "format": "int64"
                 },
-                "name": {
+                "machineName": {
                     "type": "string"
                 },
-                "resourceUnit": {
+                "machineNumber": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "recipeName": {
                     "type": "string"
+                }
+            }
+        },
+        "handler.SweepStepCalculator": {
+            "type": "object",
+            "properties": {
+                "exceededSupplies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "usersId": {
-                    "type": "integer"
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SweepNodeCalculator"
+                    }
+                },
+                "nodesWithNewMachines": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "rate": {
+                    "type": "number",
+                    "format": "float32"
+                },
+                "rawResourcesPerSecond": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "rawResourcesPerUnit": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float32"
+                    }
+                },
+                "totalMachineCount": {
+                    "type": "integer",
+                    "format": "int64"
                 }
             }
         },
-        "handler.ResourceSource": {
+        "handler.TransportTierInfo": {
             "type": "object",
             "properties": {
-                "excessProducedResourcePerSecond": {
+                "capacityPerS": {
                     "type": "number",
                     "format": "float32"
                 },
-                "excessResourceName": {
+                "id": {
+                    "type": "integer"
+                },
+                "liquid": {
+                    "type": "integer",
+                    "format": "int32"
+                },
+                "name": {
                     "type": "string"
                 },
-                "nodeId": {
+                "usersId": {
                     "type": "integer"
                 }
             }
         },
-        "handler.StatsResponse": {
+        "handler.UnlockTierInfo": {
             "type": "object",
             "properties": {
-                "apiUsageStats": {
-                    "type": "object"
+                "id": {
+                    "type": "integer"
                 },
-                "noPeriods": {
-                    "type": "integer",
-                    "format": "int64"
+                "name": {
+                    "type": "string"
                 },
-                "trackingPeriodMs": {
-                    "type": "integer",
-                    "format": "int64"
+                "tierNumber": {
+                    "type": "integer"
+                },
+                "usersId": {
+                    "type": "integer"
                 }
             }
         },
         "handler.UpdateResponseCrud": {
             "type": "object",
             "properties": {
+                "machinesCostsUpdated": {
+                    "type": "integer"
+                },
                 "machinesRecipesUpdated": {
                     "type": "integer"
                 },
@@ -1126,6 +3411,12 @@ const docTemplate = `{
                 },
                 "resourcesUpdated": {
                     "type": "integer"
+                },
+                "transportTiersUpdated": {
+                    "type": "integer"
+                },
+                "unlockTiersUpdated": {
+                    "type": "integer"
                 }
             }
         },
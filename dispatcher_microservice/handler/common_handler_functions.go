@@ -61,6 +61,9 @@ func (h *CommonHandlerFunctions) redirectRequest(w http.ResponseWriter, r *http.
 		w.Write([]byte("could not communicate with microservice"))
 		return
 	}
+	if contentType := response.Header.Get("Content-Type"); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
 	w.WriteHeader(response.StatusCode)
 	temp := make([]byte, 1)
 	for {
@@ -25,17 +25,118 @@ type DispatcherCalculator struct {
 
 // Calculate return the calculated production tree for specified resource
 //
-//	@Description	Calculate the machines and resources needed to produce target resource with provided production rate per second. Alternative Recipe and Alternative Machine parameters can be present multiple times in request query.
-//	@Param			userid		query	string	true	"Id of users whose data will be used as the base for calculation"
-//	@Param			resource	query	string	true	"Resource to be produced"
-//	@Param			rate		query	string	true	"Target production rate for the specified resource"
-//	@Param			alt_recipe	query	string	false	"Alternative recipe to take into consideration when calculating production tree"
-//	@Param			alt_machine	query	string	false	"Alternative machine to take into consideration when calculating production tree"
+//	@Description	Calculate the machines and resources needed to produce target resource with provided production rate. Alternative Recipe, Alternative Machine, Supply and Transport Tier parameters can be present multiple times in request query.
+//	@Param			userid			query	string	true	"Id of users whose data will be used as the base for calculation"
+//	@Param			resource		query	string	true	"Resource to be produced"
+//	@Param			rate			query	string	false	"Target production rate for the specified resource, required unless mode is 'maximize'"
+//	@Param			alt_recipe		query	string	false	"Alternative recipe to take into consideration when calculating production tree"
+//	@Param			alt_machine		query	string	false	"Alternative machine to take into consideration when calculating production tree"
+//	@Param			mode			query	string	false	"Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts, 'maximize' ignores rate and calculates the highest rate achievable with supplied resources. Defaults to 'greedy'"
+//	@Param			supply			query	string	false	"Resource available at limited rate in 'maximize' mode, in format 'resource:rate', SuppliedResourcesPerSecond in response contains consumed rate of every supplied resource. Can be present multiple times"
+//	@Param			format			query	string	false	"Format of the response, either 'json', 'dot' (Graphviz) or 'mermaid' diagram with edges labelled by resource, rate and lanes of transport tier. Defaults to 'json'"
+//	@Param			unit			query	string	false	"Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'"
+//	@Param			objective		query	string	false	"Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'"
+//	@Param			power_target_mw	query	string	false	"Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption"
+//	@Param			cover_power		query	string	false	"If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'"
+//	@Param			transport_tier	query	string	false	"Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers"
+//	@Param			split_nodes		query	string	false	"If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'"
+//	@Param			sink_excess		query	string	false	"If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'"
+//	@Param			build_cost		query	string	false	"If 'true', BuildCost in summary totals resources needed to construct machines of the tree, MachinesWithoutBuildCost lists machines without known build cost. Defaults to 'false'"
+//	@Param			explain			query	string	false	"If 'true', Explanation of every node lists considered recipe and machine pairs and the rule that eliminated each of them. Defaults to 'false'"
 //	@Tags			Calculator
+//	@Produce		json,plain
 //	@Success		200	{object}	handler.ProductionTreeCalculator
-//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing"
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
 //	@Failure		500	{string}	string	"Unexpected serverside error"
 //	@Router			/calculator/calculate [get]
 func (h *DispatcherCalculator) Calculate(w http.ResponseWriter, r *http.Request) {
 	h.CommonHandlerFunctions.redirectRequest(w, r, "calculate", h.CalculatorMicroservicesAddresses)
 }
+
+// CalculateMultiple return the calculated production tree for several target resources
+//
+//	@Description	Calculate the machines and resources needed to produce all target resources with provided production rates in one production tree, intermediate resources needed by several targets are produced by shared nodes. Alternative Recipe and Alternative Machine parameters can be present multiple times in request query.
+//	@Param			userid			query	string										true	"Id of users whose data will be used as the base for calculation"
+//	@Param			alt_recipe		query	string										false	"Alternative recipe to take into consideration when calculating production tree"
+//	@Param			alt_machine		query	string										false	"Alternative machine to take into consideration when calculating production tree"
+//	@Param			mode			query	string										false	"Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts. Defaults to 'greedy'"
+//	@Param			format			query	string										false	"Format of the response, either 'json', 'dot' (Graphviz) or 'mermaid' diagram with edges labelled by resource, rate and lanes of transport tier. Defaults to 'json'"
+//	@Param			unit			query	string										false	"Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'"
+//	@Param			objective		query	string										false	"Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'"
+//	@Param			power_target_mw	query	string										false	"Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption"
+//	@Param			cover_power		query	string										false	"If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'"
+//	@Param			transport_tier	query	string										false	"Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers"
+//	@Param			split_nodes		query	string										false	"If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'"
+//	@Param			sink_excess		query	string										false	"If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'"
+//	@Param			build_cost		query	string										false	"If 'true', BuildCost in summary totals resources needed to construct machines of the tree, MachinesWithoutBuildCost lists machines without known build cost. Defaults to 'false'"
+//	@Param			explain			query	string										false	"If 'true', Explanation of every node lists considered recipe and machine pairs and the rule that eliminated each of them. Defaults to 'false'"
+//	@Param			targets			body	handler.CalculateMultipleInputCalculator	true	"Resources to be produced and their target production rates"
+//	@Tags			Calculator
+//
+//	@Accept			json
+//	@Produce		json,plain
+//
+//	@Success		200	{object}	handler.ProductionTreeCalculator
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/calculator/calculate [post]
+func (h *DispatcherCalculator) CalculateMultiple(w http.ResponseWriter, r *http.Request) {
+	h.CommonHandlerFunctions.redirectRequest(w, r, "calculate", h.CalculatorMicroservicesAddresses)
+}
+
+// Compare return the comparison of production trees calculated with several sets of alternative recipes and machines
+//
+//	@Description	Calculate production trees producing all targets once for every scenario and compare them with the first scenario, which is the baseline. Scenarios list alternative recipes and machines used in addition to alt_recipe and alt_machine parameters.
+//	@Param			userid			query	string							true	"Id of users whose data will be used as the base for calculation"
+//	@Param			alt_recipe		query	string							false	"Alternative recipe to take into consideration in every scenario"
+//	@Param			alt_machine		query	string							false	"Alternative machine to take into consideration in every scenario"
+//	@Param			mode			query	string							false	"Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts. Defaults to 'greedy'"
+//	@Param			unit			query	string							false	"Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'"
+//	@Param			objective		query	string							false	"Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'"
+//	@Param			power_target_mw	query	string							false	"Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption"
+//	@Param			cover_power		query	string							false	"If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'"
+//	@Param			transport_tier	query	string							false	"Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers"
+//	@Param			split_nodes		query	string							false	"If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'"
+//	@Param			sink_excess		query	string							false	"If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'"
+//	@Param			comparison		body	handler.CompareInputCalculator	true	"Resources to be produced, their target production rates and compared scenarios"
+//	@Tags			Calculator
+//
+//	@Accept			json
+//	@Produce		json
+//
+//	@Success		200	{object}	handler.ComparisonCalculator
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/calculator/calculate/compare [post]
+func (h *DispatcherCalculator) Compare(w http.ResponseWriter, r *http.Request) {
+	h.CommonHandlerFunctions.redirectRequest(w, r, "calculate/compare", h.CalculatorMicroservicesAddresses)
+}
+
+// Sweep return machine counts and raw resources of production trees for a range of target rates
+//
+//	@Description	Calculate production trees producing target resource at every rate from rate_from to rate_to, increased by rate_step, and return machine counts and raw resources consumed at every rate. At most 1000 rates can be calculated at once.
+//	@Param			userid			query	string	true	"Id of users whose data will be used as the base for calculation"
+//	@Param			resource		query	string	true	"Resource to be produced"
+//	@Param			rate_from		query	string	true	"First target production rate"
+//	@Param			rate_to			query	string	true	"Last target production rate"
+//	@Param			rate_step		query	string	true	"Difference between consecutive target production rates"
+//	@Param			supply			query	string	false	"Raw resource available at limited rate, in format 'resource:rate', ExceededSupplies lists raw resources consumed faster than supplied. Can be present multiple times"
+//	@Param			alt_recipe		query	string	false	"Alternative recipe to take into consideration when calculating production tree"
+//	@Param			alt_machine		query	string	false	"Alternative machine to take into consideration when calculating production tree"
+//	@Param			mode			query	string	false	"Calculation mode: 'greedy' chooses the best recipe separately for each resource, 'optimize' solves the whole recipe graph as a linear program, which can split production of a resource across recipes and reuse byproducts. Defaults to 'greedy'"
+//	@Param			unit			query	string	false	"Time unit of rates in request and of rate fields named PerUnit, either 's', 'min' or 'h'. Fields named PerSecond and rates of targets in response always hold rates per second, PerUnit fields are only filled for units other than 's'. Defaults to 's'"
+//	@Param			objective		query	string	false	"Objective used to choose recipes and machines across the whole tree: 'rate' prefers allowed alternative recipes and then the fastest recipe, 'machines' minimizes number of machines, 'power' minimizes power consumption, 'raw' minimizes consumption of raw and supplied resources. Defaults to 'rate'"
+//	@Param			power_target_mw	query	string	false	"Power in MW that generators added to production tree, together with the chain producing their fuel, have to provide on top of covered consumption"
+//	@Param			cover_power		query	string	false	"If 'true', generators added to production tree cover power consumed by all its machines. Defaults to 'false'"
+//	@Param			transport_tier	query	string	false	"Transport tier allowed to carry resources between nodes, the allowed tier with the highest capacity for liquids or solids is used for every edge and excess resource. Can be present multiple times. Defaults to all transport tiers"
+//	@Param			split_nodes		query	string	false	"If 'true', nodes are split into copies so that no edge needs more than one lane of transport tier. Defaults to 'false'"
+//	@Param			sink_excess		query	string	false	"If 'true', excess resources with sink value are put into sink nodes earning sink points, other excess resources are consumed by allowed disposal recipe with the highest consumption rate when possible. Defaults to 'false'"
+//	@Tags			Calculator
+//	@Produce		json
+//	@Success		200	{object}	handler.SweepCalculator
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/calculator/calculate/sweep [get]
+func (h *DispatcherCalculator) Sweep(w http.ResponseWriter, r *http.Request) {
+	h.CommonHandlerFunctions.redirectRequest(w, r, "calculate/sweep", h.CalculatorMicroservicesAddresses)
+}
@@ -32,6 +32,9 @@ type DispatcherCrud struct {
 //	@Param			recipes_inputs_id	query	integer	false	"Id of recipes inputs to be retreived from database"
 //	@Param			recipes_outputs_id	query	integer	false	"Id of recipes outputs to be retreived from database"
 //	@Param			machines_recipes_id	query	integer	false	"Id of machines recipes to be retreived from database"
+//	@Param			transport_tiers_id	query	integer	false	"Id of transport tiers to be retreived from database"
+//	@Param			unlock_tiers_id		query	integer	false	"Id of unlock tiers to be retreived from database"
+//	@Param			machines_costs_id	query	integer	false	"Id of machines costs to be retreived from database"
 //	@Tags			CRUD Authorization required
 //	@Success		200	{object}	handler.JSONDataCrud
 //	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
@@ -71,6 +74,12 @@ func (h *DispatcherCrud) SelectByID(w http.ResponseWriter, r *http.Request) {
 //	@Param			recipes_outputs_rows		query	integer	false	"Number of rows to be returned from recipes_outputs table"
 //	@Param			machines_recipes_id_start	query	integer	false	"Id of first record to be retreived from machines_recipes table"
 //	@Param			machines_recipes_rows		query	integer	false	"Number of rows to be returned from machines_recipes table"
+//	@Param			transport_tiers_id_start	query	integer	false	"Id of first record to be retreived from transport_tiers table"
+//	@Param			transport_tiers_rows		query	integer	false	"Number of rows to be returned from transport_tiers table"
+//	@Param			unlock_tiers_id_start		query	integer	false	"Id of first record to be retreived from unlock_tiers table"
+//	@Param			unlock_tiers_rows			query	integer	false	"Number of rows to be returned from unlock_tiers table"
+//	@Param			machines_costs_id_start		query	integer	false	"Id of first record to be retreived from machines_costs table"
+//	@Param			machines_costs_rows			query	integer	false	"Number of rows to be returned from machines_costs table"
 //	@Tags			CRUD Authorization required
 //	@Success		200	{object}	handler.JSONDataCrud
 //	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
@@ -98,6 +107,8 @@ func (h *DispatcherCrud) Select(w http.ResponseWriter, r *http.Request) {
 // Insert insert record(s) into the database
 //
 //	@Description	Insert data into database. The user to whom the ownership of records is assigned is the user who presented the authentication token.
+//	@Description	Recipes inputs, recipes outputs, machines recipes and machines costs can reference recipes, resources and machines by RecipesRef, ResourcesRef and MachinesRef fields instead of ids. A reference is resolved to the record with matching TempId inserted in the same request, otherwise to the record with matching name inserted in the same request, otherwise to the existing record of the user with matching name. All data is inserted in one transaction, nothing is inserted if any record cannot be inserted or any reference cannot be resolved.
+//	@Param			dry_run	query	bool					false	"If true, changes are validated and numbers of affected records are reported, but changes are rolled back"
 //	@Param			insert	body	handler.JSONDataCrud	true	"Data to be inserted into database"
 //	@Tags			CRUD Authorization required
 //
@@ -129,6 +140,8 @@ func (h *DispatcherCrud) Insert(w http.ResponseWriter, r *http.Request) {
 // Update update record(s) in the database
 //
 //	@Description	Updates data in database. Updates the records based on "id" field of an element in the array sent in request body. If a record with a particular id does not belong to the user who presented authentication token, then that record is not updated.
+//	@Description	All data is updated in one transaction, nothing is updated if any record cannot be updated.
+//	@Param			dry_run	query	bool					false	"If true, changes are validated and numbers of affected records are reported, but changes are rolled back"
 //	@Param			update	body	handler.JSONDataCrud	true	"Data to be updated in the database"
 //	@Tags			CRUD Authorization required
 //
@@ -160,6 +173,8 @@ func (h *DispatcherCrud) Update(w http.ResponseWriter, r *http.Request) {
 // Delete delete record(s) in the database
 //
 //	@Description	Deletes data in database. Each table has it's own id list to be deleted. If a record with a particular id does not belong to the user who presented authentication token, then that record is not deleted.
+//	@Description	Records are deleted in one transaction, nothing is deleted if any record cannot be deleted. Recipes inputs, recipes outputs, machines recipes and machines costs are deleted before machines, resources and recipes, so that records can be deleted together with records referencing them.
+//	@Param			dry_run	query	bool					false	"If true, changes are validated and numbers of affected records are reported, but changes are rolled back"
 //	@Param			delete	body	handler.DeleteInputCrud	true	"Data to be deleted in the database"
 //	@Tags			CRUD Authorization required
 //
@@ -215,3 +230,333 @@ func (h *DispatcherCrud) DeleteByUser(w http.ResponseWriter, r *http.Request) {
 	}
 	h.CommonHandlerFunctions.redirectRequest(w, r, "user", h.CrudMicroservicesAddresses)
 }
+
+// SelectPlans return saved production plans
+//
+//	@Description	Return production plans saved by the user that provided authentication token, together with their targets, alternative recipes, alternative machines and production trees calculated when plans were saved or last recomputed. If plans_id parameter is present, only plans with those ids are returned, otherwise plans are returned from id range, specified in the same way as in select endpoint.
+//	@Param			plans_id		query	integer	false	"Id of plans to be retreived from database. Can be present multiple times"
+//	@Param			plans_id_start	query	integer	false	"Id of first record to be retreived from plans table"
+//	@Param			plans_rows		query	integer	false	"Number of rows to be returned from plans table"
+//	@Tags			CRUD Authorization required
+//	@Success		200	{object}	handler.PlansDataCrud
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/crud/plans [get]
+//
+//	@Security		apiTokenAuth
+func (h *DispatcherCrud) SelectPlans(w http.ResponseWriter, r *http.Request) {
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, _ := h.CommonHandlerFunctions.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	h.CommonHandlerFunctions.redirectRequest(w, r, "plans", h.CrudMicroservicesAddresses)
+}
+
+// InsertPlans save production plans
+//
+//	@Description	Save named production plans of the user that provided authentication token. For every plan, production tree producing its targets with its alternative recipes and alternative machines is calculated by calculator microservice using current data of the user and saved with the plan. Ids, users ids and production trees sent in request body are ignored.
+//	@Param			plans	body	handler.PlansDataCrud	true	"Plans to be saved"
+//	@Tags			CRUD Authorization required
+//
+//	@Accept			json
+//
+//	@Success		201	{object}	handler.PlansInsertResponseCrud
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/crud/plans [post]
+//
+//	@Security		apiTokenAuth
+func (h *DispatcherCrud) InsertPlans(w http.ResponseWriter, r *http.Request) {
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, _ := h.CommonHandlerFunctions.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	h.CommonHandlerFunctions.redirectRequest(w, r, "plans", h.CrudMicroservicesAddresses)
+}
+
+// UpdatePlans update saved production plans
+//
+//	@Description	Update names, targets, alternative recipes and alternative machines of saved production plans, based on "id" field of every plan sent in request body. Production tree of every plan is calculated again with updated data. If a plan with a particular id does not belong to the user who presented authentication token, then that plan is not updated.
+//	@Param			plans	body	handler.PlansDataCrud	true	"Plans to be updated"
+//	@Tags			CRUD Authorization required
+//
+//	@Accept			json
+//
+//	@Success		200	{object}	handler.PlansUpdateResponseCrud
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/crud/plans [put]
+//
+//	@Security		apiTokenAuth
+func (h *DispatcherCrud) UpdatePlans(w http.ResponseWriter, r *http.Request) {
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, _ := h.CommonHandlerFunctions.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	h.CommonHandlerFunctions.redirectRequest(w, r, "plans", h.CrudMicroservicesAddresses)
+}
+
+// DeletePlans delete saved production plans
+//
+//	@Description	Delete saved production plans with provided ids. If a plan with a particular id does not belong to the user who presented authentication token, then that plan is not deleted.
+//	@Param			plans_id	query	integer	true	"Id of plan to be deleted. Can be present multiple times"
+//	@Tags			CRUD Authorization required
+//	@Success		200	{object}	handler.PlansDeleteResponseCrud
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/crud/plans [delete]
+//
+//	@Security		apiTokenAuth
+func (h *DispatcherCrud) DeletePlans(w http.ResponseWriter, r *http.Request) {
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, _ := h.CommonHandlerFunctions.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	h.CommonHandlerFunctions.redirectRequest(w, r, "plans", h.CrudMicroservicesAddresses)
+}
+
+// RecomputePlans calculate saved production plans again
+//
+//	@Description	Calculate production trees of saved production plans again, using current recipes, machines and resources of the user that provided authentication token. If plans_id parameter is present, only plans with those ids are recomputed, otherwise all plans of the user are. Changed is true for plans whose production tree differs from the saved one, new production trees of such plans are saved.
+//	@Param			plans_id	query	integer	false	"Id of plan to be recomputed. Can be present multiple times"
+//	@Tags			CRUD Authorization required
+//	@Success		200	{object}	handler.PlansRecomputeResponseCrud
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/crud/plans/recompute [post]
+//
+//	@Security		apiTokenAuth
+func (h *DispatcherCrud) RecomputePlans(w http.ResponseWriter, r *http.Request) {
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, _ := h.CommonHandlerFunctions.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	h.CommonHandlerFunctions.redirectRequest(w, r, "plans/recompute", h.CrudMicroservicesAddresses)
+}
+
+// SelectProgress return current tier of the user
+//
+//	@Description	Return current progression tier of the user that provided authentication token. Recipes and machines assigned to unlock tiers with tier number higher than current tier are not used by calculator microservice. If current tier has not been set, all recipes and machines are unlocked and status 404 is returned.
+//	@Tags			CRUD Authorization required
+//	@Success		200	{object}	handler.ProgressInfo
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		404	{string}	string	"Current tier has not been set"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/crud/progress [get]
+//
+//	@Security		apiTokenAuth
+func (h *DispatcherCrud) SelectProgress(w http.ResponseWriter, r *http.Request) {
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, _ := h.CommonHandlerFunctions.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	h.CommonHandlerFunctions.redirectRequest(w, r, "progress", h.CrudMicroservicesAddresses)
+}
+
+// UpdateProgress set current tier of the user
+//
+//	@Description	Set current progression tier of the user that provided authentication token. Recipes and machines are unlocked when tier number of their unlock tier is not higher than current tier, recipes and machines without unlock tier are always unlocked. Users id sent in request body is ignored.
+//	@Param			progress	body	handler.ProgressInfo	true	"Current tier of the user"
+//	@Tags			CRUD Authorization required
+//
+//	@Accept			json
+//
+//	@Success		200	{object}	handler.ProgressUpdateResponseCrud
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/crud/progress [put]
+//
+//	@Security		apiTokenAuth
+func (h *DispatcherCrud) UpdateProgress(w http.ResponseWriter, r *http.Request) {
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, _ := h.CommonHandlerFunctions.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	h.CommonHandlerFunctions.redirectRequest(w, r, "progress", h.CrudMicroservicesAddresses)
+}
+
+// ImportSatisfactory import game data from Satisfactory Docs.json
+//
+//	@Description	Import buildings, items, fluids and recipes from Docs.json file distributed with Satisfactory and insert them as machines, resources and recipes of the user that provided authentication token. Manufacturers, extractors and fuel generators are imported, recipes not produced in any imported machine are skipped. Amounts and durations are scaled by the smallest factor making all of them whole numbers. Machines and resources with names already used by the user are not inserted, existing records are used instead. Recipes with names already used by the user are skipped. All data is inserted in one transaction, response lists numbers of inserted records and skipped entries.
+//	@Param			docs	body	object	true	"Contents of Docs.json, UTF-16 and UTF-8 encodings are accepted"
+//	@Tags			CRUD Authorization required
+//
+//	@Accept			json
+//
+//	@Success		200	{object}	handler.ImportResponseCrud
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/crud/import/satisfactory [post]
+//
+//	@Security		apiTokenAuth
+func (h *DispatcherCrud) ImportSatisfactory(w http.ResponseWriter, r *http.Request) {
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, _ := h.CommonHandlerFunctions.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	h.CommonHandlerFunctions.redirectRequest(w, r, "import/satisfactory", h.CrudMicroservicesAddresses)
+}
+
+// ImportFactorio import game data from Factorio data.raw
+//
+//	@Description	Import recipes, items, fluids and machines from data.raw of Factorio, as dumped by the game with --dump-data option, and insert them as recipes, resources and machines of the user that provided authentication token. Assembling machines, furnaces and rocket silos run recipes of their crafting categories, mining drills and offshore pumps get recipes extracting resources. Liquid slots of machines are derived from their fluid boxes. Recipes not crafted in any imported machine are skipped. Amounts and durations are scaled by the smallest factor making all of them whole numbers. Machines and resources with names already used by the user are not inserted, existing records are used instead. Recipes with names already used by the user are skipped. All data is inserted in one transaction, response lists numbers of inserted records and skipped entries.
+//	@Param			difficulty	query	string	false	"Difficulty of recipes, either 'normal' or 'expensive', normal by default"
+//	@Param			dataRaw		body	object	true	"Contents of data.raw dump"
+//	@Tags			CRUD Authorization required
+//
+//	@Accept			json
+//
+//	@Success		200	{object}	handler.ImportResponseCrud
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/crud/import/factorio [post]
+//
+//	@Security		apiTokenAuth
+func (h *DispatcherCrud) ImportFactorio(w http.ResponseWriter, r *http.Request) {
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, _ := h.CommonHandlerFunctions.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	h.CommonHandlerFunctions.redirectRequest(w, r, "import/factorio", h.CrudMicroservicesAddresses)
+}
+
+// Export return all data of the user as a bundle
+//
+//	@Description	Return unlock tiers, transport tiers, resources, machines, recipes, production plans and current tier of the user that provided authentication token as a self-contained bundle. Records of the bundle reference each other by names instead of database ids, build costs of machines and inputs, outputs and machines of recipes are nested in records they belong to. Bundle can be imported into any account with import endpoint.
+//	@Tags			CRUD Authorization required
+//	@Success		200	{object}	handler.BundleCrud
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/crud/export [get]
+//
+//	@Security		apiTokenAuth
+func (h *DispatcherCrud) Export(w http.ResponseWriter, r *http.Request) {
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, _ := h.CommonHandlerFunctions.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	h.CommonHandlerFunctions.redirectRequest(w, r, "export", h.CrudMicroservicesAddresses)
+}
+
+// Import load bundle into account of the user
+//
+//	@Description	Import bundle produced by export endpoint into account of the user that provided authentication token. Bundle must be of supported version, names must be unique among records of the same kind and every referenced name must belong to a record of the bundle. Strategy decides what happens to records with names already used by the user: 'merge' updates them with data of the bundle and replaces their build costs, inputs, outputs and machines, 'replace' deletes all data of the user before import and 'skip_existing' leaves them untouched. All data is imported in one transaction, response lists numbers of inserted, updated and skipped records.
+//	@Param			strategy	query	string				false	"Conflict strategy, one of 'merge', 'replace' or 'skip_existing', skip_existing by default"
+//	@Param			dry_run		query	bool				false	"If true, bundle is validated and numbers of affected records are reported, but changes are rolled back"
+//	@Param			bundle		body	handler.BundleCrud	true	"Bundle to import"
+//	@Tags			CRUD Authorization required
+//
+//	@Accept			json
+//
+//	@Success		200	{object}	handler.BundleImportResponseCrud
+//	@Failure		400	{string}	string	"Bad request. One of required parameters is missing or is not of valid format"
+//	@Failure		401	{string}	string	"Authentication error"
+//	@Failure		500	{string}	string	"Unexpected serverside error"
+//	@Router			/crud/import [post]
+//
+//	@Security		apiTokenAuth
+func (h *DispatcherCrud) Import(w http.ResponseWriter, r *http.Request) {
+	jwt := r.URL.Query().Get("jwt")
+	if len(jwt) <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("jwt parameter cannot be empty"))
+		return
+	}
+	valid, _ := h.CommonHandlerFunctions.verifyJWT(jwt)
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("provided jwt is invalid"))
+		return
+	}
+	h.CommonHandlerFunctions.redirectRequest(w, r, "import", h.CrudMicroservicesAddresses)
+}
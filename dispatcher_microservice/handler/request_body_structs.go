@@ -16,6 +16,8 @@
 
 package handler
 
+import "encoding/json"
+
 type JSONDataUsers struct {
 	UserLogin    string
 	UserPassword string
@@ -28,6 +30,9 @@ type JSONDataCrud struct {
 	RecipesInputsList   []RecipeInputOutputInfo
 	RecipesOutputsList  []RecipeInputOutputInfo
 	MachinesRecipesList []MachinesRecipesInfo
+	TransportTiersList  []TransportTierInfo
+	UnlockTiersList     []UnlockTierInfo
+	MachinesCostsList   []MachineCostInfo
 }
 
 type MachineInfo struct {
@@ -40,7 +45,13 @@ type MachineInfo struct {
 	OutputsLiquid      uint
 	Speed              float32
 	PowerConsumptionKw uint
+	// PowerClockExponent is the exponent of clock speed in power consumption, 0 is stored as 1
+	PowerClockExponent float32
+	PowerGenerationKw  uint
 	DefaultChoice      uint8
+	UnlockTiersId      uint
+	// TempId is a temporary id of machine inserted in the same request as records referencing it
+	TempId string `json:",omitempty"`
 }
 
 type MachinesRecipesInfo struct {
@@ -48,6 +59,9 @@ type MachinesRecipesInfo struct {
 	UsersId    uint
 	RecipesId  uint
 	MachinesId uint
+	// RecipesRef and MachinesRef reference recipe and machine by temporary id or name instead of id
+	RecipesRef  string `json:",omitempty"`
+	MachinesRef string `json:",omitempty"`
 }
 
 type RecipeInfo struct {
@@ -56,6 +70,9 @@ type RecipeInfo struct {
 	UsersId         uint
 	ProductionTimeS uint
 	DefaultChoice   uint8
+	UnlockTiersId   uint
+	// TempId is a temporary id of recipe inserted in the same request as records referencing it
+	TempId string `json:",omitempty"`
 }
 
 type RecipeInputOutputInfo struct {
@@ -64,6 +81,9 @@ type RecipeInputOutputInfo struct {
 	RecipesId   uint
 	ResourcesId uint
 	Amount      uint
+	// RecipesRef and ResourcesRef reference recipe and resource by temporary id or name instead of id
+	RecipesRef   string `json:",omitempty"`
+	ResourcesRef string `json:",omitempty"`
 }
 
 type ResourceInfo struct {
@@ -72,6 +92,127 @@ type ResourceInfo struct {
 	UsersId      uint
 	Liquid       uint8
 	ResourceUnit string
+	SinkValue    float32
+	// TempId is a temporary id of resource inserted in the same request as records referencing it
+	TempId string `json:",omitempty"`
+}
+
+type TransportTierInfo struct {
+	Id           uint
+	Name         string
+	UsersId      uint
+	Liquid       uint8
+	CapacityPerS float32
+}
+
+type UnlockTierInfo struct {
+	Id         uint
+	Name       string
+	UsersId    uint
+	TierNumber uint
+}
+
+type MachineCostInfo struct {
+	Id          uint
+	UsersId     uint
+	MachinesId  uint
+	ResourcesId uint
+	Amount      uint
+	// MachinesRef and ResourcesRef reference machine and resource by temporary id or name instead of id
+	MachinesRef  string `json:",omitempty"`
+	ResourcesRef string `json:",omitempty"`
+}
+
+type ProgressInfo struct {
+	UsersId     uint
+	CurrentTier uint
+}
+
+type PlanTarget struct {
+	Resource string
+	Rate     float32
+}
+
+type PlanInfo struct {
+	Id             uint
+	Name           string
+	UsersId        uint
+	Targets        []PlanTarget
+	AltRecipes     []string
+	AltMachines    []string
+	ProductionTree json.RawMessage `swaggertype:"object"`
+}
+
+type PlansDataCrud struct {
+	PlansList []PlanInfo
+}
+
+type BundleCrud struct {
+	Version        uint
+	UnlockTiers    []BundleUnlockTierCrud
+	TransportTiers []BundleTransportTierCrud
+	Resources      []BundleResourceCrud
+	Machines       []BundleMachineCrud
+	Recipes        []BundleRecipeCrud
+	Plans          []BundlePlanCrud
+	CurrentTier    *uint
+}
+
+type BundleUnlockTierCrud struct {
+	Name       string
+	TierNumber uint
+}
+
+type BundleTransportTierCrud struct {
+	Name         string
+	Liquid       uint8
+	CapacityPerS float32
+}
+
+type BundleResourceCrud struct {
+	Name         string
+	Liquid       uint8
+	ResourceUnit string
+	SinkValue    float32
+}
+
+type BundleMachineCrud struct {
+	Name               string
+	InputsSolid        uint
+	InputsLiquid       uint
+	OutputsSolid       uint
+	OutputsLiquid      uint
+	Speed              float32
+	PowerConsumptionKw uint
+	// PowerClockExponent is the exponent of clock speed in power consumption, 0 is stored as 1
+	PowerClockExponent float32
+	PowerGenerationKw  uint
+	DefaultChoice      uint8
+	UnlockTier         string
+	BuildCost          []BundleAmountCrud
+}
+
+type BundleRecipeCrud struct {
+	Name            string
+	ProductionTimeS uint
+	DefaultChoice   uint8
+	UnlockTier      string
+	Inputs          []BundleAmountCrud
+	Outputs         []BundleAmountCrud
+	Machines        []string
+}
+
+type BundleAmountCrud struct {
+	Resource string
+	Amount   uint
+}
+
+type BundlePlanCrud struct {
+	Name           string
+	Targets        []PlanTarget
+	AltRecipes     []string
+	AltMachines    []string
+	ProductionTree json.RawMessage `swaggertype:"object"`
 }
 
 type DeleteInputCrud struct {
@@ -81,4 +222,27 @@ type DeleteInputCrud struct {
 	RecipesInputsIds   []int
 	RecipesOutputsIds  []int
 	MachinesRecipesIds []int
+	TransportTiersIds  []int
+	UnlockTiersIds     []int
+	MachinesCostsIds   []int
+}
+
+type CalculationTargetCalculator struct {
+	Resource string
+	Rate     float32
+}
+
+type CalculateMultipleInputCalculator struct {
+	Targets []CalculationTargetCalculator
+}
+
+type ComparisonScenarioCalculator struct {
+	Name        string
+	AltRecipes  []string
+	AltMachines []string
+}
+
+type CompareInputCalculator struct {
+	Targets   []CalculationTargetCalculator
+	Scenarios []ComparisonScenarioCalculator
 }
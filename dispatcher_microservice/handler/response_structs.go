@@ -17,6 +17,8 @@
 package handler
 
 import (
+	"encoding/json"
+
 	orderedmap "github.com/wk8/go-ordered-map/v2"
 )
 
@@ -56,6 +58,9 @@ type InsertResponseCrud struct {
 	RecipesInputsInserted   uint
 	RecipesOutputsInserted  uint
 	MachinesRecipesInserted uint
+	TransportTiersInserted  uint
+	UnlockTiersInserted     uint
+	MachinesCostsInserted   uint
 }
 
 type UpdateResponseCrud struct {
@@ -65,6 +70,9 @@ type UpdateResponseCrud struct {
 	RecipesInputsUpdated   uint
 	RecipesOutputsUpdated  uint
 	MachinesRecipesUpdated uint
+	TransportTiersUpdated  uint
+	UnlockTiersUpdated     uint
+	MachinesCostsUpdated   uint
 }
 
 type DeleteResponseCrud struct {
@@ -74,20 +82,111 @@ type DeleteResponseCrud struct {
 	RecipesInputsDeleted   uint
 	RecipesOutputsDeleted  uint
 	MachinesRecipesDeleted uint
+	TransportTiersDeleted  uint
+	UnlockTiersDeleted     uint
+	MachinesCostsDeleted   uint
+	PlansDeleted           uint
+	ProgressDeleted        uint
+}
+
+type ProgressUpdateResponseCrud struct {
+	ProgressUpdated uint
+}
+
+type PlansInsertResponseCrud struct {
+	PlansInserted uint
+}
+
+type PlansUpdateResponseCrud struct {
+	PlansUpdated uint
+}
+
+type PlansDeleteResponseCrud struct {
+	PlansDeleted uint
+}
+
+type RecomputedPlanCrud struct {
+	Id             uint
+	Name           string
+	Changed        bool
+	ProductionTree json.RawMessage `swaggertype:"object"`
+}
+
+type PlansRecomputeResponseCrud struct {
+	PlansList []RecomputedPlanCrud
 }
 
 type ProductionTreeCalculator struct {
-	TreeNodes                []*ProductionTreeNode
-	TargetResource           string
-	TargetResourceRate       float32
-	TargetResourceSourceNode int
-	ExcessResources          []*ResourceSource
+	TreeNodes                  []*ProductionTreeNode
+	TargetResource             string
+	TargetResourceRate         float32
+	TargetResourceRatePerUnit  float32
+	TargetResourceSourceNode   int
+	Targets                    []*ProductionTargetCalculator
+	ExcessResources            []*ResourceSource
+	Edges                      []*ProductionEdge
+	RejectedPairings           []*RejectedPairing
+	SuppliedResourcesPerSecond map[string]float32
+	SuppliedResourcesPerUnit   map[string]float32
+	Summary                    *ProductionSummaryCalculator
+	RateUnit                   string
+	ResourceUnits              map[string]string
+}
+
+type ProductionSummaryCalculator struct {
+	Machines                 []*MachineSummaryCalculator
+	TotalPowerConsumedkW     uint64
+	TotalPowerGeneratedkW    uint64
+	PowerBalancekW           int64
+	RawResourcesPerSecond    map[string]float32
+	ByproductsPerSecond      map[string]float32
+	SunkResourcesPerSecond   map[string]float32
+	SinkPointsPerSecond      float32
+	RawResourcesPerUnit      map[string]float32
+	ByproductsPerUnit        map[string]float32
+	SunkResourcesPerUnit     map[string]float32
+	SinkPointsPerUnit        float32
+	BuildCost                map[string]uint64
+	MachinesWithoutBuildCost []string
+}
+
+type MachineSummaryCalculator struct {
+	MachineName          string
+	MachineCount         uint64
+	MachineNumber        float32
+	TotalPowerConsumedkW uint64
+}
+
+type ProductionTargetCalculator struct {
+	Resource    string
+	Rate        float32
+	RatePerUnit float32
+	SourceNode  int
 }
 
 type ResourceSource struct {
 	NodeId                          int
 	ExcessResourceName              string
 	ExcessProducedResourcePerSecond float32
+	ExcessProducedResourcePerUnit   float32
+	TransportTier                   string
+	Lanes                           uint64
+}
+
+type RejectedPairing struct {
+	RecipeName  string
+	MachineName string
+	Reason      string
+}
+
+type ProductionEdge struct {
+	SourceNode        int
+	TargetNode        int
+	Resource          string
+	ResourcePerSecond float32
+	ResourcePerUnit   float32
+	TransportTier     string
+	Lanes             uint64
 }
 
 type ProductionTreeNode struct {
@@ -95,10 +194,94 @@ type ProductionTreeNode struct {
 	RecipeName                 string
 	MachineName                string
 	MachineNumber              float32
+	MachineCount               uint64
+	ClockPercentage            float32
 	TotalPowerConsumedkW       uint64
+	PowerGeneratedkW           uint64
 	RequiredResourcesPerSecond map[string]float32
 	ProducedResourcesPerSecond map[string]float32
+	RequiredResourcesPerUnit   map[string]float32
+	ProducedResourcesPerUnit   map[string]float32
 	SourceNodes                []int
+	SunkResource               string
+	SinkPointsPerSecond        float32
+	SinkPointsPerUnit          float32
+	Explanation                *NodeExplanation
+}
+
+type NodeExplanation struct {
+	Resource   string
+	Candidates []*CandidateExplanation
+}
+
+type CandidateExplanation struct {
+	RecipeName        string
+	MachineName       string
+	ResourcePerSecond float32
+	ResourcePerUnit   float32
+	Chosen            bool
+	Rejection         string
+}
+
+type ComparisonCalculator struct {
+	Baseline      string
+	Scenarios     []*ScenarioComparisonCalculator
+	RateUnit      string
+	ResourceUnits map[string]string
+}
+
+type ScenarioComparisonCalculator struct {
+	Name                      string
+	MachineCount              uint64
+	MachineNumber             float32
+	TotalPowerConsumedkW      uint64
+	TotalPowerGeneratedkW     uint64
+	PowerBalancekW            int64
+	Machines                  []*MachineSummaryCalculator
+	RawResourcesPerSecond     map[string]float32
+	ByproductsPerSecond       map[string]float32
+	RawResourcesPerUnit       map[string]float32
+	ByproductsPerUnit         map[string]float32
+	MachineCountDelta         int64
+	MachineNumberDelta        float32
+	TotalPowerConsumedkWDelta int64
+	PowerBalancekWDelta       int64
+	ResourceDeltas            []*ResourceDeltaCalculator
+}
+
+type ResourceDeltaCalculator struct {
+	Resource          string
+	Kind              string
+	BaselinePerSecond float32
+	ScenarioPerSecond float32
+	DeltaPerSecond    float32
+	BaselinePerUnit   float32
+	ScenarioPerUnit   float32
+	DeltaPerUnit      float32
+}
+
+type SweepCalculator struct {
+	Resource      string
+	Steps         []*SweepStepCalculator
+	RateUnit      string
+	ResourceUnits map[string]string
+}
+
+type SweepStepCalculator struct {
+	Rate                  float32
+	TotalMachineCount     uint64
+	Nodes                 []*SweepNodeCalculator
+	NodesWithNewMachines  []int
+	RawResourcesPerSecond map[string]float32
+	RawResourcesPerUnit   map[string]float32
+	ExceededSupplies      []string
+}
+
+type SweepNodeCalculator struct {
+	RecipeName    string
+	MachineName   string
+	MachineCount  uint64
+	MachineNumber float32
 }
 
 type StatsResponse struct {
@@ -106,3 +289,35 @@ type StatsResponse struct {
 	TrackingPeriodMs int64
 	NoPeriods        uint64
 }
+
+type ImportResponseCrud struct {
+	MachinesInserted        uint
+	ResourcesInserted       uint
+	RecipesInserted         uint
+	RecipesInputsInserted   uint
+	RecipesOutputsInserted  uint
+	MachinesRecipesInserted uint
+	Skipped                 []SkippedEntryCrud
+}
+
+type SkippedEntryCrud struct {
+	Kind   string
+	Name   string
+	Reason string
+}
+
+type BundleImportResponseCrud struct {
+	UnlockTiers    BundleImportCountsCrud
+	TransportTiers BundleImportCountsCrud
+	Resources      BundleImportCountsCrud
+	Machines       BundleImportCountsCrud
+	Recipes        BundleImportCountsCrud
+	Plans          BundleImportCountsCrud
+	Progress       BundleImportCountsCrud
+}
+
+type BundleImportCountsCrud struct {
+	Inserted uint
+	Updated  uint
+	Skipped  uint
+}